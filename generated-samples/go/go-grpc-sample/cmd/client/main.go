@@ -0,0 +1,60 @@
+// Command client calls the Item service through the generated connect-go
+// client. It speaks the Connect protocol by default, or gRPC with -grpc:
+//
+//  go run ./cmd/client -title "Write docs"
+//  go run ./cmd/client -grpc
+package main
+
+import (
+    "context"
+    "crypto/tls"
+    "flag"
+    "fmt"
+    "log"
+    "net"
+    "net/http"
+    "connectrpc.com/connect"
+    "golang.org/x/net/http2"
+    itemv1 "myapp/gen/item/v1"
+    "myapp/gen/item/v1/itemv1connect"
+)
+
+func main() {
+    baseURL := flag.String("url", "http://localhost:8080", "server base URL")
+    useGRPC := flag.Bool("grpc", false, "use the gRPC protocol instead of Connect")
+    title := flag.String("title", "", "create an item with this title before listing")
+    flag.Parse()
+
+    httpClient := http.DefaultClient
+    var opts []connect.ClientOption
+    if *useGRPC {
+        // gRPC needs HTTP/2; the server speaks it without TLS (h2c)
+        httpClient = &http.Client{Transport: &http2.Transport{
+            AllowHTTP: true,
+            DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+                var d net.Dialer
+                return d.DialContext(ctx, network, addr)
+            },
+        }}
+        opts = append(opts, connect.WithGRPC())
+    }
+    client := itemv1connect.NewItemServiceClient(httpClient, *baseURL, opts...)
+    ctx := context.Background()
+
+    if *title != "" {
+        res, err := client.CreateItem(ctx, connect.NewRequest(&itemv1.CreateItemRequest{Title: *title}))
+        if err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("created %s\n", res.Msg.Item.Id)
+    }
+
+    res, err := client.ListItems(ctx, connect.NewRequest(&itemv1.ListItemsRequest{PageSize: 10}))
+    if err != nil {
+        log.Fatal(err)
+    }
+    for _, item := range res.Msg.Items {
+        fmt.Printf("%s\t%s\n", item.Id, item.Title)
+    }
+    fmt.Printf("%d of %d items\n", len(res.Msg.Items), res.Msg.TotalSize)
+}
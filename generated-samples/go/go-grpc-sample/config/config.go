@@ -0,0 +1,151 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "log/slog"
+    "os"
+    "strconv"
+    "time"
+    "github.com/joho/godotenv"
+    "myapp/database"
+)
+
+// Config is every setting the API reads from the environment. Load fills
+// it once at startup; nothing else should call os.Getenv.
+type Config struct {
+    Port     string
+    Env      string
+    LogLevel slog.Level
+    Server   Server
+    Database Database
+    Cache    Cache
+}
+
+// Server holds the http.Server timeouts. Requests slower than
+// ReadTimeout/WriteTimeout are cut off; ShutdownTimeout bounds how long
+// in-flight requests get to finish after SIGINT/SIGTERM.
+type Server struct {
+    ReadHeaderTimeout time.Duration
+    ReadTimeout       time.Duration
+    WriteTimeout      time.Duration
+    IdleTimeout       time.Duration
+    ShutdownTimeout   time.Duration
+}
+
+// Database is handed to store.Open. URL is the connection string from
+// database.BuildDSN: DATABASE_URL, or with Postgres the DB_* variables.
+type Database struct {
+    URL           string
+    RunMigrations bool
+}
+
+// Cache is handed to cache.Open. Item reads are cached for TTL (0
+// disables the cache); RedisURL is used with --cache redis and, when
+// empty, leaves the cache in process.
+type Cache struct {
+    TTL      time.Duration
+    RedisURL string
+}
+
+// Load reads .env (when present) and the environment. Unset variables
+// take their defaults; malformed ones are errors, all reported together
+// so a bad deploy shows every problem at once.
+func Load() (*Config, error) {
+    godotenv.Load()
+
+    var l loader
+    c := &Config{
+        Port:     l.port("PORT", "8080"),
+        Env:      os.Getenv("NODE_ENV"),
+        LogLevel: l.level("LOG_LEVEL", slog.LevelInfo),
+        Server: Server{
+            ReadHeaderTimeout: l.duration("READ_HEADER_TIMEOUT", 5*time.Second),
+            ReadTimeout:       l.duration("READ_TIMEOUT", 15*time.Second),
+            WriteTimeout:      l.duration("WRITE_TIMEOUT", 15*time.Second),
+            IdleTimeout:       l.duration("IDLE_TIMEOUT", 60*time.Second),
+            ShutdownTimeout:   l.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+        },
+        Database: Database{
+            RunMigrations: l.boolean("RUN_MIGRATIONS", true),
+        },
+        Cache: Cache{
+            TTL:      l.duration("CACHE_TTL", 0),
+            RedisURL: os.Getenv("CACHE_REDIS_URL"),
+        },
+    }
+
+    // The connection string is built once, here, so invalid database or
+    // TLS settings fail at startup rather than on first use
+    dsn, err := database.BuildDSN(os.Getenv)
+    if err != nil {
+        l.errs = append(l.errs, err)
+    }
+    c.Database.URL = dsn
+
+    if err := errors.Join(l.errs...); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+    return c, nil
+}
+
+// loader parses variables, collecting an error for each malformed one
+// and returning the default in its place.
+type loader struct {
+    errs []error
+}
+
+func (l *loader) fail(key, value, want string) {
+    l.errs = append(l.errs, fmt.Errorf("%s=%q: want %s", key, value, want))
+}
+
+func (l *loader) boolean(key string, def bool) bool {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    b, err := strconv.ParseBool(v)
+    if err != nil {
+        l.fail(key, v, "true or false")
+        return def
+    }
+    return b
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil || d < 0 {
+        l.fail(key, v, "a duration such as 30s or 10m")
+        return def
+    }
+    return d
+}
+
+func (l *loader) port(key, def string) string {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+        l.fail(key, v, "a port between 1 and 65535")
+        return def
+    }
+    return v
+}
+
+func (l *loader) level(key string, def slog.Level) slog.Level {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    var level slog.Level
+    if err := level.UnmarshalText([]byte(v)); err != nil {
+        l.fail(key, v, "debug, info, warn or error")
+        return def
+    }
+    return level
+}
@@ -0,0 +1,10 @@
+package database
+
+// BuildDSN returns the connection string config.Load hands to store.Open:
+// DATABASE_URL as set, since the memory, SQLite and MySQL stores take it
+// in their own form. getenv looks it up. Generated with --database
+// postgres, this file also assembles it from the DB_* variables and
+// checks its TLS settings.
+func BuildDSN(getenv func(string) string) (string, error) {
+    return getenv("DATABASE_URL"), nil
+}
@@ -0,0 +1,17 @@
+module myapp
+
+go 1.21
+
+require (
+    connectrpc.com/connect v1.16.2
+    connectrpc.com/grpchealth v1.3.0
+    connectrpc.com/grpcreflect v1.2.0
+    connectrpc.com/vanguard v0.2.0
+    github.com/go-chi/chi/v5 v5.0.11
+    github.com/go-playground/validator/v10 v10.22.0
+    github.com/joho/godotenv v1.5.1
+    golang.org/x/net v0.26.0
+    google.golang.org/genproto/googleapis/api v0.0.0-20240604185151-ef581f913117
+    google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117
+    google.golang.org/protobuf v1.34.2
+)
@@ -0,0 +1,66 @@
+package logging
+
+import (
+    "context"
+    "log/slog"
+    "net/http"
+    "os"
+)
+
+type loggerKey struct{}
+
+// Setup installs the app logger: readable text when env is "development",
+// JSON lines otherwise. The stdlib log package is routed through it, so
+// stray log.Printf calls still come out structured.
+func Setup(env string, level slog.Level) {
+    opts := &slog.HandlerOptions{Level: level}
+    var h slog.Handler = slog.NewJSONHandler(os.Stderr, opts)
+    if env == "development" {
+        h = slog.NewTextHandler(os.Stderr, opts)
+    }
+    slog.SetDefault(slog.New(h))
+}
+
+// Middleware stores a logger tagged with the request ID, method and path
+// in each request's context. It must run after the middleware that
+// assigns request IDs; requestID reads the ID back out.
+func Middleware(requestID func(context.Context) string) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            logger := slog.Default().With(
+                "request_id", requestID(r.Context()),
+                "method", r.Method,
+                "path", r.URL.Path,
+            )
+            ctx := context.WithValue(r.Context(), loggerKey{}, logger)
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
+
+// FromContext returns the request's logger, or the default logger outside
+// a request.
+func FromContext(ctx context.Context) *slog.Logger {
+    if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+        return logger
+    }
+    return slog.Default()
+}
+
+// Debug, Info, Warn and Error log through the request's logger. args are
+// alternating keys and values, e.g. logging.Error(ctx, "save failed", "err", err).
+func Debug(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).DebugContext(ctx, msg, args...)
+}
+
+func Info(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).InfoContext(ctx, msg, args...)
+}
+
+func Warn(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).WarnContext(ctx, msg, args...)
+}
+
+func Error(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).ErrorContext(ctx, msg, args...)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "log"
+    "net/http"
+    "os/signal"
+    "syscall"
+    "connectrpc.com/grpchealth"
+    "connectrpc.com/grpcreflect"
+    "connectrpc.com/vanguard"
+    "github.com/go-chi/chi/v5"
+    "github.com/go-chi/chi/v5/middleware"
+    "golang.org/x/net/http2"
+    "golang.org/x/net/http2/h2c"
+    "myapp/cache"
+    "myapp/config"
+    "myapp/gen/item/v1/itemv1connect"
+    "myapp/health"
+    "myapp/logging"
+    "myapp/service"
+    "myapp/store"
+)
+
+func main() {
+    // Settings from .env and the environment, validated up front
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    logging.Setup(cfg.Env, cfg.LogLevel)
+
+    // Readiness checks, run by /readyz
+    checks := &health.Checks{}
+
+    // Item store (backend chosen at generation time)
+    items, closeStore, err := store.Open(context.Background(), cfg.Database)
+    if err != nil {
+        log.Fatalf("store: %v", err)
+    }
+    defer closeStore()
+    if db, ok := items.(store.Pinger); ok {
+        checks.Register("database", db.Ping)
+    }
+
+    // Item reads cached for CACHE_TTL (0 disables it): in process, or in
+    // Redis when generated with --cache redis
+    if cfg.Cache.TTL > 0 {
+        itemCache, err := cache.Open(context.Background(), cfg.Cache)
+        if err != nil {
+            log.Fatalf("cache: %v", err)
+        }
+        defer itemCache.Close()
+        items = store.NewCachedStore(items, itemCache, cfg.Cache.TTL)
+        checks.RegisterOptional("cache", itemCache.Ping)
+    }
+
+    // The connect-go handler answers Connect, gRPC and gRPC-Web; the
+    // transcoder in front of it adds REST/JSON at the google.api.http
+    // paths in proto/item/v1/item.proto
+    path, handler := itemv1connect.NewItemServiceHandler(service.NewItems(items))
+    transcoder, err := vanguard.NewTranscoder([]*vanguard.Service{
+        vanguard.NewService(path, handler),
+    })
+    if err != nil {
+        log.Fatalf("transcoder: %v", err)
+    }
+
+    mux := http.NewServeMux()
+    // Plain HTTP probes: /healthz is liveness, /readyz runs the checks
+    mux.HandleFunc("/healthz", health.Live)
+    mux.HandleFunc("/readyz", checks.Handler)
+
+    // Standard gRPC health checks, and reflection so grpcurl and other
+    // tools can discover the API without the .proto files
+    mux.Handle(grpchealth.NewHandler(grpchealth.NewStaticChecker(itemv1connect.ItemServiceName)))
+    reflector := grpcreflect.NewStaticReflector(itemv1connect.ItemServiceName)
+    mux.Handle(grpcreflect.NewHandlerV1(reflector))
+    mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+
+    mux.Handle("/", transcoder)
+
+    h := chi.Chain(
+        middleware.RequestID,
+        logging.Middleware(middleware.GetReqID),
+        logging.AccessLog,
+        middleware.Recoverer,
+    ).Handler(mux)
+
+    port := cfg.Port
+    // gRPC needs HTTP/2; h2c serves it over plain TCP alongside HTTP/1.1.
+    // Timeouts keep slow or stalled clients from holding connections open.
+    srv := &http.Server{
+        Addr:              ":" + port,
+        Handler:           h2c.NewHandler(h, &http2.Server{}),
+        ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+        ReadTimeout:       cfg.Server.ReadTimeout,
+        WriteTimeout:      cfg.Server.WriteTimeout,
+        IdleTimeout:       cfg.Server.IdleTimeout,
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    go func() {
+        log.Println("🚀 Item service listening on http://localhost:" + port)
+        if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            log.Fatal(err)
+        }
+    }()
+
+    <-ctx.Done()
+    stop()
+    log.Println("Shutting down (Ctrl+C again to force)")
+
+    // In-flight calls get SHUTDOWN_TIMEOUT to finish, then are cut off
+    shutdown, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+    defer cancel()
+    if err := srv.Shutdown(shutdown); err != nil {
+        log.Printf("shutdown: %v", err)
+        srv.Close()
+    }
+}
+
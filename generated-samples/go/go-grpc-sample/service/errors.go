@@ -0,0 +1,86 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "sort"
+    "strings"
+    "connectrpc.com/connect"
+    "google.golang.org/genproto/googleapis/rpc/errdetails"
+    "myapp/logging"
+    "myapp/models"
+    "myapp/store"
+    "myapp/validation"
+)
+
+// itemFields carries the rules for an item's fields, checked after create
+// and after an update is applied.
+type itemFields struct {
+    Title       string `json:"title" validate:"notblank,max=200"`
+    Description string `json:"description" validate:"max=5000"`
+}
+
+func validate(item models.Item) error {
+    violations := fieldViolations{}
+    for field, msg := range validation.Struct(itemFields{Title: item.Title, Description: item.Description}) {
+        violations.add(field, msg)
+    }
+    return violations.err()
+}
+
+// fieldViolations collects bad request fields for an InvalidArgument
+// error. The messages go into the error text and, for clients that read
+// details, into a google.rpc.BadRequest.
+type fieldViolations map[string]string
+
+func (v fieldViolations) add(field, msg string) {
+    if _, ok := v[field]; !ok {
+        v[field] = msg
+    }
+}
+
+func (v fieldViolations) err() error {
+    if len(v) == 0 {
+        return nil
+    }
+    fields := make([]string, 0, len(v))
+    for field := range v {
+        fields = append(fields, field)
+    }
+    sort.Strings(fields)
+
+    details := &errdetails.BadRequest{}
+    texts := make([]string, 0, len(fields))
+    for _, field := range fields {
+        details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+            Field:       field,
+            Description: v[field],
+        })
+        texts = append(texts, field+" "+v[field])
+    }
+
+    err := connect.NewError(connect.CodeInvalidArgument, errors.New(strings.Join(texts, "; ")))
+    if detail, derr := connect.NewErrorDetail(details); derr == nil {
+        err.AddDetail(detail)
+    }
+    return err
+}
+
+// storeError maps store errors onto RPC codes. Anything unexpected is
+// logged and reported as Internal without its details.
+func storeError(ctx context.Context, err error) error {
+    switch {
+    case errors.Is(err, store.ErrNotFound):
+        return connect.NewError(connect.CodeNotFound, err)
+    case errors.Is(err, store.ErrConflict):
+        // Another write landed between the read and the update; the
+        // client can retry the whole call
+        return connect.NewError(connect.CodeAborted, err)
+    case errors.Is(err, context.Canceled):
+        return connect.NewError(connect.CodeCanceled, err)
+    case errors.Is(err, context.DeadlineExceeded):
+        return connect.NewError(connect.CodeDeadlineExceeded, err)
+    }
+    logging.Error(ctx, "store operation failed", "err", err)
+    return connect.NewError(connect.CodeInternal, errors.New("internal error"))
+}
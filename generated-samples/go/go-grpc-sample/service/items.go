@@ -0,0 +1,141 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "strconv"
+    "connectrpc.com/connect"
+    itemv1 "myapp/gen/item/v1"
+    "myapp/gen/item/v1/itemv1connect"
+    "myapp/models"
+    "myapp/store"
+)
+
+// Page sizes for ListItems when the request leaves page_size at 0, and
+// the most a request may ask for
+const (
+    defaultPageSize = 20
+    maxPageSize     = 100
+)
+
+// Items implements the generated ItemService handler on top of the item
+// store.
+type Items struct {
+    store store.ItemStore
+}
+
+var _ itemv1connect.ItemServiceHandler = (*Items)(nil)
+
+func NewItems(s store.ItemStore) *Items {
+    return &Items{store: s}
+}
+
+func (s *Items) ListItems(ctx context.Context, req *connect.Request[itemv1.ListItemsRequest]) (*connect.Response[itemv1.ListItemsResponse], error) {
+    msg := req.Msg
+    violations := fieldViolations{}
+    if !store.ValidSort(msg.Sort) {
+        violations.add("sort", "must be one of relevance, id, -id, title, -title")
+    }
+    if msg.PageSize < 0 || msg.PageSize > maxPageSize {
+        violations.add("page_size", "must be between 0 and "+strconv.Itoa(maxPageSize))
+    }
+    offset, err := parsePageToken(msg.PageToken)
+    if err != nil {
+        violations.add("page_token", "is not a token from a previous page")
+    }
+    if err := violations.err(); err != nil {
+        return nil, err
+    }
+
+    size := int(msg.PageSize)
+    if size == 0 {
+        size = defaultPageSize
+    }
+    page, err := s.store.Find(ctx, store.ListQuery{
+        Search: msg.Query,
+        Sort:   msg.Sort,
+        Offset: offset,
+        Limit:  size,
+    })
+    if err != nil {
+        return nil, storeError(ctx, err)
+    }
+
+    res := &itemv1.ListItemsResponse{TotalSize: int32(page.Total)}
+    for _, item := range page.Items {
+        res.Items = append(res.Items, toProto(item))
+    }
+    if next := offset + len(page.Items); next < page.Total {
+        res.NextPageToken = strconv.Itoa(next)
+    }
+    return connect.NewResponse(res), nil
+}
+
+func (s *Items) GetItem(ctx context.Context, req *connect.Request[itemv1.GetItemRequest]) (*connect.Response[itemv1.GetItemResponse], error) {
+    item, err := s.store.Get(ctx, req.Msg.Id)
+    if err != nil {
+        return nil, storeError(ctx, err)
+    }
+    return connect.NewResponse(&itemv1.GetItemResponse{Item: toProto(item)}), nil
+}
+
+func (s *Items) CreateItem(ctx context.Context, req *connect.Request[itemv1.CreateItemRequest]) (*connect.Response[itemv1.CreateItemResponse], error) {
+    item := models.Item{Title: req.Msg.Title, Description: req.Msg.Description}
+    if err := validate(item); err != nil {
+        return nil, err
+    }
+    item, err := s.store.Create(ctx, item)
+    if err != nil {
+        return nil, storeError(ctx, err)
+    }
+    return connect.NewResponse(&itemv1.CreateItemResponse{Item: toProto(item)}), nil
+}
+
+func (s *Items) UpdateItem(ctx context.Context, req *connect.Request[itemv1.UpdateItemRequest]) (*connect.Response[itemv1.UpdateItemResponse], error) {
+    item, err := s.store.Get(ctx, req.Msg.Id)
+    if err != nil {
+        return nil, storeError(ctx, err)
+    }
+    if req.Msg.Title != nil {
+        item.Title = *req.Msg.Title
+    }
+    if req.Msg.Description != nil {
+        item.Description = *req.Msg.Description
+    }
+    if err := validate(item); err != nil {
+        return nil, err
+    }
+    item, err = s.store.Update(ctx, item)
+    if err != nil {
+        return nil, storeError(ctx, err)
+    }
+    return connect.NewResponse(&itemv1.UpdateItemResponse{Item: toProto(item)}), nil
+}
+
+func (s *Items) DeleteItem(ctx context.Context, req *connect.Request[itemv1.DeleteItemRequest]) (*connect.Response[itemv1.DeleteItemResponse], error) {
+    if err := s.store.Delete(ctx, req.Msg.Id); err != nil {
+        return nil, storeError(ctx, err)
+    }
+    return connect.NewResponse(&itemv1.DeleteItemResponse{}), nil
+}
+
+func toProto(item models.Item) *itemv1.Item {
+    return &itemv1.Item{
+        Id:          item.ID,
+        Title:       item.Title,
+        Description: item.Description,
+    }
+}
+
+// Page tokens are the offset of the page's first item. Clients must treat
+// them as opaque, so the encoding can change later.
+func parsePageToken(token string) (int, error) {
+    if token == "" {
+        return 0, nil
+    }
+    offset, err := strconv.Atoi(token)
+    if err != nil || offset < 0 {
+        return 0, errors.New("invalid page token")
+    }
+    return offset, nil
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "time"
+    "myapp/cache"
+    "myapp/logging"
+    "myapp/models"
+)
+
+// CachedStore reads items through a cache (cache-aside): Get serves a
+// cached copy for up to ttl, and Update (even one that conflicts),
+// UpdateMany and Delete drop the entries of the items they touch. Lists
+// and searches always reach the store. Cache failures are logged and fall
+// through to the store, so an outage only costs speed.
+type CachedStore struct {
+    next  ItemStore
+    cache cache.Cache
+    ttl   time.Duration
+}
+
+func NewCachedStore(next ItemStore, c cache.Cache, ttl time.Duration) *CachedStore {
+    return &CachedStore{next: next, cache: c, ttl: ttl}
+}
+
+func itemKey(id string) string {
+    return "item:" + id
+}
+
+func (s *CachedStore) List(ctx context.Context) ([]models.Item, error) {
+    return s.next.List(ctx)
+}
+
+func (s *CachedStore) Find(ctx context.Context, q ListQuery) (Page, error) {
+    return s.next.Find(ctx, q)
+}
+
+func (s *CachedStore) Get(ctx context.Context, id string) (models.Item, error) {
+    key := itemKey(id)
+    data, ok, err := s.cache.Get(ctx, key)
+    if err != nil {
+        logging.Warn(ctx, "cache get failed", "key", key, "err", err)
+    }
+    var item models.Item
+    if ok && json.Unmarshal(data, &item) == nil {
+        return item, nil
+    }
+
+    item, err = s.next.Get(ctx, id)
+    if err != nil {
+        return item, err
+    }
+    if data, err := json.Marshal(item); err == nil {
+        if err := s.cache.Set(ctx, key, data, s.ttl); err != nil {
+            logging.Warn(ctx, "cache set failed", "key", key, "err", err)
+        }
+    }
+    return item, nil
+}
+
+func (s *CachedStore) Create(ctx context.Context, item models.Item) (models.Item, error) {
+    return s.next.Create(ctx, item)
+}
+
+func (s *CachedStore) Update(ctx context.Context, item models.Item) (models.Item, error) {
+    updated, err := s.next.Update(ctx, item)
+    // A conflict can mean the cached copy is the stale one the caller
+    // read, so drop it too and let the retry read the store
+    if err == nil || errors.Is(err, ErrConflict) {
+        s.invalidate(ctx, item.ID)
+    }
+    return updated, err
+}
+
+func (s *CachedStore) Delete(ctx context.Context, id string) error {
+    err := s.next.Delete(ctx, id)
+    if err == nil {
+        s.invalidate(ctx, id)
+    }
+    return err
+}
+
+func (s *CachedStore) UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) ([]models.Item, error) {
+    updated, err := s.next.UpdateMany(ctx, ids, fn)
+    if err == nil {
+        s.invalidate(ctx, ids...)
+    }
+    return updated, err
+}
+
+// invalidate drops cached copies after a write. If that fails the stale
+// copy is served until it expires, so the failure is logged loudly.
+func (s *CachedStore) invalidate(ctx context.Context, ids ...string) {
+    keys := make([]string, len(ids))
+    for i, id := range ids {
+        keys[i] = itemKey(id)
+    }
+    if err := s.cache.Delete(ctx, keys...); err != nil {
+        logging.Error(ctx, "cache invalidation failed", "keys", keys, "err", err)
+    }
+}
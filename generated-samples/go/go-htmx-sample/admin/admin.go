@@ -0,0 +1,22 @@
+package admin
+
+import (
+    "github.com/go-chi/chi/v5"
+    "myapp/auth"
+    "myapp/clock"
+    "myapp/config"
+    "myapp/events"
+    "myapp/store"
+)
+
+// Admin is the /admin back office. This project was generated without
+// --admin, so there is none; generate with --admin to get an item table
+// with bulk actions, metrics cards and, with --auth, user management.
+type Admin struct{}
+
+func New(cfg config.Admin, items store.ItemStore, bus *events.Bus, authn *auth.Auth, clk clock.Clock) *Admin {
+    return &Admin{}
+}
+
+// Routes mounts the /admin pages.
+func (a *Admin) Routes(r chi.Router) {}
@@ -0,0 +1,33 @@
+package api
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "myapp/logging"
+    "myapp/store"
+)
+
+// WriteJSON encodes v as the response body.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(v)
+}
+
+// WriteStoreError maps store errors to JSON error responses shared by
+// every API version.
+func WriteStoreError(w http.ResponseWriter, r *http.Request, err error) {
+    switch {
+    case errors.Is(err, context.Canceled):
+    case errors.Is(err, store.ErrNotFound):
+        WriteJSON(w, http.StatusNotFound, map[string]string{"error": "item not found"})
+    case errors.Is(err, context.DeadlineExceeded):
+        logging.Warn(r.Context(), "store operation timed out", "err", err)
+        WriteJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "request timed out"})
+    default:
+        logging.Error(r.Context(), "store operation failed", "err", err)
+        WriteJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+    }
+}
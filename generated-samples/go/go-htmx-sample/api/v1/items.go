@@ -0,0 +1,50 @@
+// Package v1 is the first JSON API. Its wire format is frozen: change
+// serialization in a newer version instead.
+package v1
+
+import (
+    "net/http"
+    "github.com/go-chi/chi/v5"
+    "myapp/api"
+    "myapp/models"
+    "myapp/store"
+)
+
+type Item struct {
+    ID          string `json:"id"`
+    Title       string `json:"title"`
+    Description string `json:"description"`
+}
+
+func fromModel(m models.Item) Item {
+    return Item{ID: m.ID, Title: m.Title, Description: m.Description}
+}
+
+// Routes serves /items and /items/{id} as bare JSON arrays and objects.
+func Routes(items store.ItemStore) chi.Router {
+    r := chi.NewRouter()
+
+    r.Get("/items", func(w http.ResponseWriter, r *http.Request) {
+        list, err := items.List(r.Context())
+        if err != nil {
+            api.WriteStoreError(w, r, err)
+            return
+        }
+        out := make([]Item, len(list))
+        for i, m := range list {
+            out[i] = fromModel(m)
+        }
+        api.WriteJSON(w, http.StatusOK, out)
+    })
+
+    r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+        m, err := items.Get(r.Context(), chi.URLParam(r, "id"))
+        if err != nil {
+            api.WriteStoreError(w, r, err)
+            return
+        }
+        api.WriteJSON(w, http.StatusOK, fromModel(m))
+    })
+
+    return r
+}
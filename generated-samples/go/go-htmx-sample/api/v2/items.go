@@ -0,0 +1,82 @@
+// Package v2 wraps collections in an envelope and adds attachment
+// metadata and links. v1 keeps serving the old shape.
+package v2
+
+import (
+    "net/http"
+    "github.com/go-chi/chi/v5"
+    "myapp/api"
+    "myapp/models"
+    "myapp/store"
+    "myapp/views"
+)
+
+type Item struct {
+    ID          string      `json:"id"`
+    Title       string      `json:"title"`
+    Description string      `json:"description"`
+    Attachment  *Attachment `json:"attachment,omitempty"`
+    Links       Links       `json:"links"`
+}
+
+type Attachment struct {
+    Filename    string `json:"filename"`
+    ContentType string `json:"content_type"`
+    Download    string `json:"download"`
+}
+
+type Links struct {
+    Self string `json:"self"`
+}
+
+type List struct {
+    Data  []Item `json:"data"`
+    Count int    `json:"count"`
+}
+
+func fromModel(m models.Item) Item {
+    item := Item{
+        ID:          m.ID,
+        Title:       m.Title,
+        Description: m.Description,
+        Links:       Links{Self: views.Path("/v2/items/" + m.ID)},
+    }
+    if m.Attachment != nil {
+        item.Attachment = &Attachment{
+            Filename:    m.Attachment.Filename,
+            ContentType: m.Attachment.ContentType,
+            Download:    views.Path("/items/" + m.ID + "/download"),
+        }
+    }
+    return item
+}
+
+// Routes serves /items as a {"data", "count"} envelope and /items/{id}
+// with links.
+func Routes(items store.ItemStore) chi.Router {
+    r := chi.NewRouter()
+
+    r.Get("/items", func(w http.ResponseWriter, r *http.Request) {
+        list, err := items.List(r.Context())
+        if err != nil {
+            api.WriteStoreError(w, r, err)
+            return
+        }
+        out := List{Data: make([]Item, len(list)), Count: len(list)}
+        for i, m := range list {
+            out.Data[i] = fromModel(m)
+        }
+        api.WriteJSON(w, http.StatusOK, out)
+    })
+
+    r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+        m, err := items.Get(r.Context(), chi.URLParam(r, "id"))
+        if err != nil {
+            api.WriteStoreError(w, r, err)
+            return
+        }
+        api.WriteJSON(w, http.StatusOK, fromModel(m))
+    })
+
+    return r
+}
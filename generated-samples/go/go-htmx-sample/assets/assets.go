@@ -0,0 +1,85 @@
+package assets
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "io"
+    "io/fs"
+    "net/http"
+    "path"
+    "strings"
+)
+
+// Manifest maps static asset names to content-hashed names such as
+// style.css -> style.3f2a9c1b.css, so a changed file gets a new URL and
+// browsers can cache every version forever.
+type Manifest struct {
+    files  fs.FS
+    hashed map[string]string // style.css -> style.3f2a9c1b.css
+    source map[string]string // style.3f2a9c1b.css -> style.css
+}
+
+// Load hashes every file in files once, at startup.
+func Load(files fs.FS) (*Manifest, error) {
+    m := &Manifest{files: files, hashed: map[string]string{}, source: map[string]string{}}
+
+    err := fs.WalkDir(files, ".", func(name string, d fs.DirEntry, err error) error {
+        if err != nil || d.IsDir() {
+            return err
+        }
+        sum, err := hashFile(files, name)
+        if err != nil {
+            return err
+        }
+        ext := path.Ext(name)
+        hashed := strings.TrimSuffix(name, ext) + "." + sum + ext
+        m.hashed[name] = hashed
+        m.source[hashed] = name
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return m, nil
+}
+
+func hashFile(files fs.FS, name string) (string, error) {
+    f, err := files.Open(name)
+    if err != nil {
+        return "", err
+    }
+    defer f.Close()
+
+    h := sha256.New()
+    if _, err := io.Copy(h, f); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(h.Sum(nil))[:8], nil
+}
+
+// Lookup returns the hashed name for an asset, or name itself when the
+// asset is unknown.
+func (m *Manifest) Lookup(name string) string {
+    if hashed, ok := m.hashed[name]; ok {
+        return hashed
+    }
+    return name
+}
+
+// Handler serves assets by hashed name with an immutable cache lifetime.
+// Plain names still work but must be revalidated.
+func (m *Manifest) Handler() http.Handler {
+    files := http.FileServer(http.FS(m.files))
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        name := strings.TrimPrefix(r.URL.Path, "/")
+        if source, ok := m.source[name]; ok {
+            w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+            r2 := r.Clone(r.Context())
+            r2.URL.Path = "/" + source
+            files.ServeHTTP(w, r2)
+            return
+        }
+        w.Header().Set("Cache-Control", "no-cache")
+        files.ServeHTTP(w, r)
+    })
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+    "context"
+    "net/http"
+    "time"
+    "github.com/go-chi/chi/v5"
+    "myapp/clock"
+    "myapp/config"
+    "myapp/mailer"
+)
+
+// Auth wires sign-in into the router. This project was generated without
+// --auth, so there is no login flow and every request is anonymous;
+// generate with --auth session|jwt|oauth to get one.
+type Auth struct{}
+
+func Open(cfg config.Auth, clk clock.Clock) (*Auth, error) {
+    return &Auth{}, nil
+}
+
+// Routes mounts the login, logout and register pages.
+func (a *Auth) Routes(r chi.Router) {}
+
+// Middleware stores the signed-in user, if any, in the request context.
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+    return next
+}
+
+// Require protects routes that need a signed-in user.
+func (a *Auth) Require(next http.Handler) http.Handler {
+    return next
+}
+
+// OnRegister sets a function called after each new account is created.
+func (a *Auth) OnRegister(fn func(ctx context.Context, email string) error) {}
+
+// UseMailer sets the mailer verification and password reset emails are
+// sent with.
+func (a *Auth) UseMailer(m mailer.Mailer) {}
+
+// PurgeExpired removes expired sessions and returns how many; without a
+// login flow there are none.
+func (a *Auth) PurgeExpired(now time.Time) int {
+    return 0
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// Cache holds byte values with a per-entry expiry. Implementations are
+// safe for concurrent use; a miss is (nil, false, nil).
+type Cache interface {
+    Get(ctx context.Context, key string) ([]byte, bool, error)
+    Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+    Delete(ctx context.Context, keys ...string) error
+    // Ping checks the backend is reachable, for the readiness probe
+    Ping(ctx context.Context) error
+    Close() error
+}
+
+// maxEntries bounds the in-process cache; once full, new values are not
+// cached until expired entries are swept.
+const maxEntries = 10000
+
+// Memory is an in-process Cache. Every process has its own, so with more
+// than one replica an item changed through one stays stale in the others
+// until its entry expires; use Redis there.
+type Memory struct {
+    mu      sync.Mutex
+    entries map[string]entry
+}
+
+type entry struct {
+    value   []byte
+    expires time.Time
+}
+
+func NewMemory() *Memory {
+    return &Memory{entries: make(map[string]entry)}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    e, ok := m.entries[key]
+    if !ok {
+        return nil, false, nil
+    }
+    if time.Now().After(e.expires) {
+        delete(m.entries, key)
+        return nil, false, nil
+    }
+    return e.value, true, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    now := time.Now()
+    if _, ok := m.entries[key]; !ok && len(m.entries) >= maxEntries {
+        for k, e := range m.entries {
+            if now.After(e.expires) {
+                delete(m.entries, k)
+            }
+        }
+        if len(m.entries) >= maxEntries {
+            return nil
+        }
+    }
+    m.entries[key] = entry{value: value, expires: now.Add(ttl)}
+    return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    for _, key := range keys {
+        delete(m.entries, key)
+    }
+    return nil
+}
+
+func (m *Memory) Ping(ctx context.Context) error {
+    return nil
+}
+
+func (m *Memory) Close() error {
+    return nil
+}
@@ -0,0 +1,66 @@
+package clock
+
+import (
+    "sync"
+    "time"
+)
+
+// Clock is the source of the current time. Code that makes time-based
+// decisions (windows, expiry, timestamps) takes a Clock instead of calling
+// time.Now so tests can control it.
+type Clock interface {
+    Now() time.Time
+}
+
+// System reads the wall clock and reports times in loc.
+type System struct {
+    loc *time.Location
+}
+
+func New(loc *time.Location) System {
+    if loc == nil {
+        loc = time.UTC
+    }
+    return System{loc: loc}
+}
+
+// Load returns a system clock in the named IANA timezone ("" means UTC).
+func Load(name string) (System, error) {
+    loc, err := time.LoadLocation(name)
+    if err != nil {
+        return System{}, err
+    }
+    return New(loc), nil
+}
+
+func (c System) Now() time.Time {
+    return time.Now().In(c.loc)
+}
+
+// Fake is a manually driven clock for tests.
+type Fake struct {
+    mu  sync.Mutex
+    now time.Time
+}
+
+func NewFake(start time.Time) *Fake {
+    return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Time {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return f.now
+}
+
+func (f *Fake) Advance(d time.Duration) {
+    f.mu.Lock()
+    f.now = f.now.Add(d)
+    f.mu.Unlock()
+}
+
+func (f *Fake) Set(t time.Time) {
+    f.mu.Lock()
+    f.now = t
+    f.mu.Unlock()
+}
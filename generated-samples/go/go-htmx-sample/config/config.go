@@ -0,0 +1,508 @@
+package config
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log/slog"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+    "github.com/joho/godotenv"
+    "myapp/database"
+    mw "myapp/middleware"
+    "myapp/secrets"
+)
+
+// Config is every setting the app reads from the environment. Load fills
+// it once at startup; nothing else should call os.Getenv.
+type Config struct {
+    Port            string
+    BasePath        string
+    Env             string
+    LogLevel        slog.Level
+    // Access log line format; the app logger's follows NODE_ENV
+    AccessLogFormat string
+    Timezone        string
+    SeedFixture     bool
+
+    Server    Server
+    Database  Database
+    Cache     Cache
+    Auth      Auth
+    Admin     Admin
+    Mail      Mail
+    Flags     Flags
+    Telemetry Telemetry
+    Jobs      Jobs
+    Security  Security
+    RateLimit RateLimit
+    Storage   Storage
+
+    SlowQueryThreshold time.Duration
+    QueryCountWarn     int64
+    AuditLog           bool
+    SanitizeFields     string
+    ListColumns        string
+    SearchEmpty        string
+    PageSize           int
+    ErrorFormat        string
+    ThemeToggle        bool
+    OpenAPIValidate    bool
+    APIDocs            bool
+
+    FormNonce    bool
+    FormNonceTTL time.Duration
+
+    MaxURLLength   int
+    MaxQueryLength int
+    MaxInFlight    int
+    FormMaxBody    int64
+    UploadMaxBody  int64
+
+    ReadyDependencies string
+    ReadyPollInterval time.Duration
+
+    ResponseCacheTTL  time.Duration
+    ResponseCacheVary []string
+    CleanupInterval   time.Duration
+    ItemRetention     time.Duration
+}
+
+// Server holds the http.Server timeouts. Requests slower than
+// ReadTimeout/WriteTimeout are cut off; ShutdownTimeout bounds how long
+// in-flight requests get to finish after SIGINT/SIGTERM. RequestTimeout
+// is the deadline item handlers pass to the store (0 disables it); keep
+// it below WriteTimeout so a timed-out request can still be answered.
+type Server struct {
+    ReadHeaderTimeout time.Duration
+    ReadTimeout       time.Duration
+    WriteTimeout      time.Duration
+    IdleTimeout       time.Duration
+    ShutdownTimeout   time.Duration
+    RequestTimeout    time.Duration
+}
+
+// Database is handed to store.Open. URL is the connection string from
+// database.BuildDSN: DATABASE_URL, or with Postgres the DB_* variables.
+type Database struct {
+    URL           string
+    RunMigrations bool
+}
+
+// Security holds the CSRF and response-header settings. An empty CSRFKey
+// means a random key per process (see main).
+type Security struct {
+    CSRF         bool
+    CSRFKey      string
+    CSP          string
+    FrameOptions string
+    HSTS         bool
+    HSTSMaxAge   time.Duration
+}
+
+// Auth is handed to auth.Open; which fields matter depends on the login
+// flow chosen at generation time.
+type Auth struct {
+    SessionTTL         time.Duration
+    SessionSecret      string
+    JWTSecret          string
+    AppURL             string
+    GitHubClientID     string
+    GitHubClientSecret string
+    GoogleClientID     string
+    GoogleClientSecret string
+}
+
+// Admin is handed to admin.New (generated with --admin). With --auth,
+// only the accounts in Emails may open /admin; without a login flow the
+// back office is as open as the rest of the app.
+type Admin struct {
+    Emails []string
+}
+
+// Mail is handed to mailer.Open. Messages come from From; with --mailer
+// smtp they are sent through SMTPHost (none logs them instead).
+type Mail struct {
+    From         string
+    SMTPHost     string
+    SMTPPort     string
+    SMTPUsername string
+    SMTPPassword string
+}
+
+// Flags is handed to flags.Open. Rules are FEATURE_FLAGS entries: "key"
+// on, "!key" off, "key=25%" on for a quarter of signed-in users.
+type Flags struct {
+    Rules []string
+}
+
+// Cache is handed to cache.Open. Item reads are cached for TTL (0
+// disables the cache); RedisURL is used with --cache redis and, when
+// empty, leaves the cache in process.
+type Cache struct {
+    TTL      time.Duration
+    RedisURL string
+}
+
+// Jobs is handed to jobs.Open and the worker (generated with --jobs).
+// asynq queues in Redis at RedisURL; river in the Postgres database.
+// Failed jobs are retried up to MaxRetries times, waiting BackoffBase
+// doubled on each attempt, at most BackoffMax. On shutdown the worker
+// gives running jobs ShutdownTimeout to finish.
+type Jobs struct {
+    RedisURL        string
+    Concurrency     int
+    MaxRetries      int
+    BackoffBase     time.Duration
+    BackoffMax      time.Duration
+    ShutdownTimeout time.Duration
+}
+
+// RateLimit is handed to ratelimit.Open. Each client may make Limit
+// requests per Window (0 disables limiting). Generated with --ratelimit
+// token-bucket, these refill continuously with bursts of up to Burst
+// (default Limit), and Routes adds limits for single routes, e.g.
+// "POST /login=5/1m".
+type RateLimit struct {
+    Limit  int
+    Window time.Duration
+    Burst  int
+    Routes []string
+}
+
+// Storage is handed to storage.Open. Attachments are kept in Dir, or
+// with --storage s3 in Bucket under Prefix (Endpoint and PathStyle reach
+// S3-compatible services such as MinIO; credentials and region come from
+// the usual AWS_* variables). Files over MaxSize, or whose sniffed type
+// matches none of Types, are refused. S3 downloads redirect to presigned
+// links valid for URLTTL.
+type Storage struct {
+    Dir       string
+    MaxSize   int64
+    Types     []string
+    Bucket    string
+    Prefix    string
+    Endpoint  string
+    PathStyle bool
+    URLTTL    time.Duration
+}
+
+// DefaultUploadTypes are the images browsers display. SVG is left out as
+// it can carry scripts.
+var DefaultUploadTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// Telemetry is handed to telemetry.Setup (generated with --observability
+// otel). Traces, and metrics when OTLPMetrics is set, are pushed to
+// OTLPEndpoint over OTLP/HTTP; without an endpoint nothing is exported
+// and metrics are only served at /metrics. The OTEL_EXPORTER_OTLP_*
+// variables (headers, per-signal endpoints) are read by the exporters.
+type Telemetry struct {
+    ServiceName  string
+    OTLPEndpoint string
+    OTLPMetrics  bool
+}
+
+// secretsTimeout bounds the secret store fetch, so an unreachable store
+// fails startup instead of hanging it.
+const secretsTimeout = 10 * time.Second
+
+// Load reads .env (when present) and the environment, with secrets from
+// the store named by SECRETS_PATH replacing their .env values (see
+// secrets.Load). Unset variables take their defaults; malformed ones are
+// errors, all reported together so a bad deploy shows every problem at
+// once.
+func Load() (*Config, error) {
+    godotenv.Load()
+
+    ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+    defer cancel()
+    if err := secrets.Load(ctx, os.Getenv("SECRETS_PATH")); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+
+    var l loader
+    c := &Config{
+        Port:            l.port("PORT", "3000"),
+        BasePath:        os.Getenv("BASE_PATH"),
+        Env:             os.Getenv("NODE_ENV"),
+        LogLevel:        l.level("LOG_LEVEL", slog.LevelInfo),
+        AccessLogFormat: l.oneOf("ACCESS_LOG_FORMAT", "text", "json", "clf", "combined"),
+        Timezone:        os.Getenv("APP_TIMEZONE"),
+        SeedFixture:     l.boolean("SEED_FIXTURE", false),
+
+        Server: Server{
+            ReadHeaderTimeout: l.duration("READ_HEADER_TIMEOUT", 5*time.Second),
+            ReadTimeout:       l.duration("READ_TIMEOUT", 30*time.Second),
+            WriteTimeout:      l.duration("WRITE_TIMEOUT", 30*time.Second),
+            IdleTimeout:       l.duration("IDLE_TIMEOUT", 120*time.Second),
+            ShutdownTimeout:   l.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+            RequestTimeout:    l.duration("REQUEST_TIMEOUT", 15*time.Second),
+        },
+        Database: Database{
+            RunMigrations: l.boolean("RUN_MIGRATIONS", true),
+        },
+        Cache: Cache{
+            TTL:      l.duration("CACHE_TTL", 0),
+            RedisURL: os.Getenv("CACHE_REDIS_URL"),
+        },
+        Telemetry: Telemetry{
+            ServiceName:  os.Getenv("OTEL_SERVICE_NAME"),
+            OTLPEndpoint: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+            OTLPMetrics:  l.boolean("OTEL_METRICS_OTLP", false),
+        },
+        Auth: Auth{
+            SessionTTL:         l.duration("AUTH_SESSION_TTL", 24*time.Hour),
+            SessionSecret:      os.Getenv("SESSION_SECRET"),
+            JWTSecret:          os.Getenv("JWT_SECRET"),
+            AppURL:             strings.TrimSuffix(os.Getenv("APP_URL"), "/"),
+            GitHubClientID:     os.Getenv("GITHUB_CLIENT_ID"),
+            GitHubClientSecret: os.Getenv("GITHUB_CLIENT_SECRET"),
+            GoogleClientID:     os.Getenv("GOOGLE_CLIENT_ID"),
+            GoogleClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+        },
+        Admin: Admin{
+            Emails: l.list("ADMIN_EMAILS"),
+        },
+        Mail: Mail{
+            From:         l.str("MAIL_FROM", "no-reply@localhost"),
+            SMTPHost:     os.Getenv("SMTP_HOST"),
+            SMTPPort:     l.port("SMTP_PORT", "587"),
+            SMTPUsername: os.Getenv("SMTP_USERNAME"),
+            SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+        },
+        Flags: Flags{
+            Rules: l.list("FEATURE_FLAGS"),
+        },
+        Jobs: Jobs{
+            RedisURL:        l.str("REDIS_URL", "redis://localhost:6379/0"),
+            Concurrency:     l.integer("JOBS_CONCURRENCY", 10),
+            MaxRetries:      l.integer("JOBS_MAX_RETRIES", 5),
+            BackoffBase:     l.duration("JOBS_BACKOFF_BASE", 10*time.Second),
+            BackoffMax:      l.duration("JOBS_BACKOFF_MAX", 10*time.Minute),
+            ShutdownTimeout: l.duration("JOBS_SHUTDOWN_TIMEOUT", 30*time.Second),
+        },
+        RateLimit: RateLimit{
+            Limit:  l.integer("RATE_LIMIT", 0),
+            Window: l.duration("RATE_LIMIT_WINDOW", time.Minute),
+            Burst:  l.integer("RATE_LIMIT_BURST", 0),
+            Routes: l.list("RATE_LIMIT_ROUTES"),
+        },
+        Storage: Storage{
+            Dir:       l.str("UPLOAD_DIR", "uploads"),
+            MaxSize:   l.byteSize("UPLOAD_MAX_SIZE", 10<<20),
+            Types:     l.list("UPLOAD_TYPES"),
+            Bucket:    os.Getenv("S3_BUCKET"),
+            Prefix:    os.Getenv("S3_PREFIX"),
+            Endpoint:  os.Getenv("S3_ENDPOINT"),
+            PathStyle: l.boolean("S3_PATH_STYLE", false),
+            URLTTL:    l.duration("S3_URL_TTL", 15*time.Minute),
+        },
+        Security: Security{
+            CSRF:         l.boolean("CSRF", true),
+            CSRFKey:      os.Getenv("CSRF_KEY"),
+            CSP:          l.str("CONTENT_SECURITY_POLICY", mw.DefaultCSP),
+            FrameOptions: l.oneOf("X_FRAME_OPTIONS", "DENY", "SAMEORIGIN", "off"),
+            HSTS:         l.boolean("HSTS", false),
+            HSTSMaxAge:   l.duration("HSTS_MAX_AGE", 365*24*time.Hour),
+        },
+
+        SlowQueryThreshold: l.duration("SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+        QueryCountWarn:     int64(l.integer("QUERY_COUNT_WARN", 10)),
+        AuditLog:           l.boolean("AUDIT_LOG", false),
+        SanitizeFields:     os.Getenv("SANITIZE_FIELDS"),
+        ListColumns:        os.Getenv("LIST_COLUMNS"),
+        SearchEmpty:        l.oneOf("SEARCH_EMPTY", "all", "none"),
+        PageSize:           l.integer("PAGE_SIZE", 20),
+        ErrorFormat:        l.oneOf("ERROR_FORMAT", "html", "json", "problem"),
+        ThemeToggle:        l.boolean("THEME_TOGGLE", false),
+        OpenAPIValidate:    l.boolean("OPENAPI_VALIDATE", false),
+        APIDocs:            l.boolean("API_DOCS", true),
+
+        FormNonce:    l.boolean("FORM_NONCE", false),
+        FormNonceTTL: l.duration("FORM_NONCE_TTL", 30*time.Minute),
+
+        MaxURLLength:   l.integer("MAX_URL_LENGTH", 2048),
+        MaxQueryLength: l.integer("MAX_QUERY_LENGTH", 1024),
+        MaxInFlight:    l.integer("MAX_IN_FLIGHT", 0),
+        FormMaxBody:    l.byteSize("FORM_MAX_BODY", 1<<20),
+        UploadMaxBody:  l.byteSize("UPLOAD_MAX_BODY", 32<<20),
+
+        ReadyDependencies: os.Getenv("READY_DEPENDENCIES"),
+        ReadyPollInterval: l.duration("READY_POLL_INTERVAL", 10*time.Second),
+
+        ResponseCacheTTL:  l.duration("RESPONSE_CACHE_TTL", 0),
+        ResponseCacheVary: l.list("RESPONSE_CACHE_VARY"),
+        CleanupInterval:   l.duration("CLEANUP_INTERVAL", 0),
+        ItemRetention:     l.duration("ITEM_RETENTION", 30*24*time.Hour),
+    }
+
+    // Sessions need a lifetime; 0 means the default day
+    if c.Auth.SessionTTL == 0 {
+        c.Auth.SessionTTL = 24 * time.Hour
+    }
+    if len(c.Storage.Types) == 0 {
+        c.Storage.Types = DefaultUploadTypes
+    }
+    if c.Jobs.Concurrency == 0 {
+        l.fail("JOBS_CONCURRENCY", "0", "1 or more")
+    }
+    // A ticker can't run every 0s
+    if c.ReadyPollInterval == 0 {
+        l.fail("READY_POLL_INTERVAL", "0", "a duration above 0 such as 10s")
+    }
+
+    // "off" drops a header entirely
+    if c.Security.CSP == "off" {
+        c.Security.CSP = ""
+    }
+    if c.Security.FrameOptions == "off" {
+        c.Security.FrameOptions = ""
+    }
+    if key := c.Security.CSRFKey; key != "" && len(key) < 32 {
+        l.fail("CSRF_KEY", "(hidden)", "at least 32 characters")
+    }
+
+    // The connection string is built once, here, so invalid database or
+    // TLS settings fail at startup rather than on first use
+    dsn, err := database.BuildDSN(os.Getenv)
+    if err != nil {
+        l.errs = append(l.errs, err)
+    }
+    c.Database.URL = dsn
+
+    if err := errors.Join(l.errs...); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+    return c, nil
+}
+
+// loader parses variables, collecting an error for each malformed one
+// and returning the default in its place.
+type loader struct {
+    errs []error
+}
+
+func (l *loader) fail(key, value, want string) {
+    l.errs = append(l.errs, fmt.Errorf("%s=%q: want %s", key, value, want))
+}
+
+// get reads key from the environment, falling back to the default the
+// generator's options chose for it (see defaults.go).
+func (l *loader) get(key string) string {
+    if v := os.Getenv(key); v != "" {
+        return v
+    }
+    return defaults[key]
+}
+
+func (l *loader) str(key, def string) string {
+    if v := l.get(key); v != "" {
+        return v
+    }
+    return def
+}
+
+func (l *loader) list(key string) []string {
+    var items []string
+    for _, item := range strings.Split(l.get(key), ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return items
+}
+
+func (l *loader) boolean(key string, def bool) bool {
+    v := l.get(key)
+    if v == "" {
+        return def
+    }
+    b, err := strconv.ParseBool(v)
+    if err != nil {
+        l.fail(key, v, "true or false")
+        return def
+    }
+    return b
+}
+
+func (l *loader) integer(key string, def int) int {
+    v := l.get(key)
+    if v == "" {
+        return def
+    }
+    n, err := strconv.Atoi(v)
+    if err != nil || n < 0 {
+        l.fail(key, v, "a whole number, 0 or more")
+        return def
+    }
+    return n
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+    v := l.get(key)
+    if v == "" {
+        return def
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil || d < 0 {
+        l.fail(key, v, "a duration such as 30s or 10m")
+        return def
+    }
+    return d
+}
+
+// byteSize reads sizes such as 512KB or 32MB; 0 keeps the default.
+func (l *loader) byteSize(key string, def int64) int64 {
+    v := l.get(key)
+    if v == "" {
+        return def
+    }
+    n, err := mw.ParseByteSize(v)
+    if err != nil {
+        l.fail(key, v, "a size such as 512KB or 32MB")
+        return def
+    }
+    if n == 0 {
+        return def
+    }
+    return n
+}
+
+func (l *loader) port(key, def string) string {
+    v := l.str(key, def)
+    if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+        l.fail(key, v, "a port between 1 and 65535")
+        return def
+    }
+    return v
+}
+
+func (l *loader) level(key string, def slog.Level) slog.Level {
+    v := l.get(key)
+    if v == "" {
+        return def
+    }
+    var level slog.Level
+    if err := level.UnmarshalText([]byte(v)); err != nil {
+        l.fail(key, v, "debug, info, warn or error")
+        return def
+    }
+    return level
+}
+
+// oneOf accepts one of the listed values; the first is the default.
+func (l *loader) oneOf(key string, allowed ...string) string {
+    v := l.get(key)
+    if v == "" {
+        return allowed[0]
+    }
+    for _, a := range allowed {
+        if v == a {
+            return v
+        }
+    }
+    l.fail(key, v, strings.Join(allowed, ", "))
+    return allowed[0]
+}
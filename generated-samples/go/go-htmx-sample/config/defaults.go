@@ -0,0 +1,6 @@
+package config
+
+// defaults holds the values generator options chose for settings left
+// unset, e.g. ACCESS_LOG_FORMAT=clf from --log clf. The environment
+// overrides them, and the loader's own defaults apply to everything else.
+var defaults = map[string]string{}
@@ -0,0 +1,111 @@
+package events
+
+import (
+    "context"
+    "sync"
+    "myapp/models"
+)
+
+// Event is anything published on the bus.
+type Event interface {
+    EventName() string
+}
+
+type ItemCreated struct{ Item models.Item }
+type ItemUpdated struct{ Item models.Item }
+type ItemDeleted struct{ ID string }
+
+func (ItemCreated) EventName() string { return "item.created" }
+func (ItemUpdated) EventName() string { return "item.updated" }
+func (ItemDeleted) EventName() string { return "item.deleted" }
+
+type envelope struct {
+    ctx   context.Context
+    event Event
+}
+
+// Bus is an in-process event bus. Events are delivered asynchronously by a
+// single dispatcher, so every subscriber sees them in publish order.
+type Bus struct {
+    subMu    sync.RWMutex
+    handlers []func(context.Context, Event)
+
+    mu     sync.RWMutex
+    closed bool
+
+    queue chan envelope
+    done  chan struct{}
+}
+
+func NewBus(buffer int) *Bus {
+    b := &Bus{
+        queue: make(chan envelope, buffer),
+        done:  make(chan struct{}),
+    }
+    go b.dispatch()
+    return b
+}
+
+// Subscribe registers fn for events of type E only.
+func Subscribe[E Event](b *Bus, fn func(ctx context.Context, e E)) {
+    b.subMu.Lock()
+    defer b.subMu.Unlock()
+    b.handlers = append(b.handlers, func(ctx context.Context, e Event) {
+        if typed, ok := e.(E); ok {
+            fn(ctx, typed)
+        }
+    })
+}
+
+// SubscribeAll registers fn for every event.
+func (b *Bus) SubscribeAll(fn func(ctx context.Context, e Event)) {
+    b.subMu.Lock()
+    defer b.subMu.Unlock()
+    b.handlers = append(b.handlers, fn)
+}
+
+// Publish queues e for delivery. The request's cancellation is detached so
+// subscribers still run after the response is sent, but context values
+// such as the request ID are kept. A nil or closed bus drops the event.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+    if b == nil {
+        return
+    }
+    b.mu.RLock()
+    defer b.mu.RUnlock()
+    if b.closed {
+        return
+    }
+    b.queue <- envelope{ctx: context.WithoutCancel(ctx), event: e}
+}
+
+// Close stops accepting events and waits until the queue has drained or
+// ctx expires.
+func (b *Bus) Close(ctx context.Context) error {
+    b.mu.Lock()
+    if !b.closed {
+        b.closed = true
+        close(b.queue)
+    }
+    b.mu.Unlock()
+
+    select {
+    case <-b.done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+func (b *Bus) dispatch() {
+    defer close(b.done)
+    for env := range b.queue {
+        b.subMu.RLock()
+        handlers := b.handlers
+        b.subMu.RUnlock()
+
+        for _, h := range handlers {
+            h(env.ctx, env.event)
+        }
+    }
+}
@@ -0,0 +1,64 @@
+package flags
+
+import (
+    "context"
+    "fmt"
+    "hash/fnv"
+    "strconv"
+    "strings"
+)
+
+// Env is the built-in provider, configured by FEATURE_FLAGS: "key" turns
+// a flag on, "!key" turns it off and "key=25%" rolls it out to a stable
+// 25% of signed-in users. Flags it doesn't mention keep their default.
+type Env struct {
+    rules map[string]int // percent of targets the flag is on for
+}
+
+// ParseEnv reads FEATURE_FLAGS entries.
+func ParseEnv(entries []string) (*Env, error) {
+    e := &Env{rules: make(map[string]int, len(entries))}
+    for _, entry := range entries {
+        key, percent := entry, 100
+        switch {
+        case strings.HasPrefix(entry, "!"):
+            key, percent = entry[1:], 0
+        case strings.Contains(entry, "="):
+            var value string
+            key, value, _ = strings.Cut(entry, "=")
+            n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+            if err != nil || n < 0 || n > 100 {
+                return nil, fmt.Errorf("flag %q: rollout must be 0%% to 100%%, got %q", key, value)
+            }
+            percent = n
+        }
+        if key == "" {
+            return nil, fmt.Errorf("flag entry %q has no key", entry)
+        }
+        e.rules[key] = percent
+    }
+    return e, nil
+}
+
+// Boolean implements Provider.
+func (e *Env) Boolean(_ context.Context, key string, def bool, target Target) bool {
+    percent, ok := e.rules[key]
+    switch {
+    case !ok:
+        return def
+    case percent >= 100:
+        return true
+    case percent <= 0 || target.Key == "":
+        return false
+    }
+    return bucket(key, target.Key) < percent
+}
+
+// bucket places a target in 0-99 for key. Hashing the key with the target
+// keeps a user in or out across requests while spreading different flags'
+// rollouts over different users.
+func bucket(key, target string) int {
+    h := fnv.New32a()
+    h.Write([]byte(key + "/" + target))
+    return int(h.Sum32() % 100)
+}
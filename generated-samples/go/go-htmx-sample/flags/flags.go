@@ -0,0 +1,85 @@
+// Package flags gates features per request. Flags are declared below with
+// their default; a Provider decides their value at runtime, so a feature
+// can ship dark and be rolled out without a deploy.
+package flags
+
+import (
+    "context"
+    "net/http"
+    "sync"
+    "myapp/reqctx"
+)
+
+// Flag is a boolean feature switch. Default is used when the provider has
+// no value for Key (or fails to evaluate it).
+type Flag struct {
+    Key         string
+    Default     bool
+    Description string
+}
+
+// Declared flags. Add new ones here and gate code with Enabled.
+var (
+    ItemCount = Flag{Key: "item-count", Description: "Show the number of items above the list"}
+)
+
+// All lists the declared flags, for docs and admin pages.
+var All = []Flag{ItemCount}
+
+// Target is who a flag is evaluated for: OpenFeature's targeting key
+// (the signed-in user, "" when anonymous) plus attributes.
+type Target struct {
+    Key    string
+    Tenant string
+}
+
+// Provider evaluates boolean flags. It mirrors OpenFeature's boolean
+// evaluation, so a vendor SDK can sit behind it.
+type Provider interface {
+    Boolean(ctx context.Context, key string, def bool, target Target) bool
+}
+
+type requestKey struct{}
+
+// request memoizes evaluations so a page sees one value per flag.
+type request struct {
+    provider Provider
+    mu       sync.Mutex
+    values   map[string]bool
+}
+
+// Middleware makes p available to Enabled for the rest of the request.
+// Mount it after auth so the target is the signed-in user.
+func Middleware(p Provider) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            ctx := context.WithValue(r.Context(), requestKey{}, &request{provider: p})
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
+
+// Enabled reports whether f is on for the request in ctx. Outside a
+// request (or without Middleware) it returns the flag's default.
+func Enabled(ctx context.Context, f Flag) bool {
+    req, ok := ctx.Value(requestKey{}).(*request)
+    if !ok {
+        return f.Default
+    }
+    req.mu.Lock()
+    defer req.mu.Unlock()
+    if on, ok := req.values[f.Key]; ok {
+        return on
+    }
+    on := req.provider.Boolean(ctx, f.Key, f.Default, TargetFor(ctx))
+    if req.values == nil {
+        req.values = make(map[string]bool)
+    }
+    req.values[f.Key] = on
+    return on
+}
+
+// TargetFor describes the request's user for flag targeting.
+func TargetFor(ctx context.Context) Target {
+    return Target{Key: reqctx.User(ctx), Tenant: reqctx.Tenant(ctx)}
+}
@@ -0,0 +1,9 @@
+package flags
+
+import "myapp/config"
+
+// Open returns the flag provider: FEATURE_FLAGS. Generating with
+// --flags openfeature evaluates flags through the OpenFeature SDK instead.
+func Open(cfg config.Flags) (Provider, error) {
+    return ParseEnv(cfg.Rules)
+}
@@ -1,9 +1,14 @@
-module test-go-htmx-app
+module myapp
 
 go 1.21
 
 require (
     github.com/a-h/templ v0.2.543
+    github.com/getkin/kin-openapi v0.123.0
     github.com/go-chi/chi/v5 v5.0.11
+    github.com/go-playground/validator/v10 v10.22.0
+    github.com/gorilla/csrf v1.7.3
     github.com/joho/godotenv v1.5.1
+    github.com/microcosm-cc/bluemonday v1.0.26
+    golang.org/x/text v0.14.0
 )
\ No newline at end of file
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+    "net/http"
+    "regexp"
+    "strconv"
+    "myapp/events"
+    "myapp/httpctx"
+    "myapp/models"
+    "myapp/validation"
+    "myapp/views"
+)
+
+// Rows shown in an empty batch form, and the most a submission may carry
+const (
+    batchRows    = 3
+    maxBatchRows = 50
+)
+
+var batchField = regexp.MustCompile(`^items\[(\d+)\]\.(title|description)$`)
+
+// BatchItemForm renders an empty form for creating several items at once.
+func BatchItemForm(w http.ResponseWriter, r *http.Request) {
+    component := views.BatchItemForm(make([]models.Item, batchRows), validation.Errors{})
+    component.Render(r.Context(), w)
+}
+
+// CreateItems creates every filled-in row of the batch form, or none of
+// them: errors from all rows are collected under their "items[i].field"
+// paths and the form is re-rendered with each message at its input.
+func CreateItems(w http.ResponseWriter, r *http.Request) {
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+
+    rows := batchRowsFrom(r)
+    errs := validation.Errors{}
+    filled := 0
+    for i, row := range rows {
+        if blankRow(row) {
+            continue
+        }
+        filled++
+        errs.Nest(validation.Index("items", i), validation.Struct(newItemForm(row)))
+    }
+    if filled == 0 {
+        errs.Add("items", "Add at least one item")
+    }
+    if !errs.Empty() {
+        writeValidationErrors(w, r, errs, views.BatchItemForm(rows, errs))
+        return
+    }
+
+    for _, row := range rows {
+        if blankRow(row) {
+            continue
+        }
+        row.OwnerID = httpctx.User(r.Context())
+        item, err := itemStore.Create(r.Context(), row)
+        if err != nil {
+            writeStoreError(w, r, err)
+            return
+        }
+        bus.Publish(r.Context(), events.ItemCreated{Item: item})
+    }
+
+    w.Header().Set("HX-Redirect", views.Path("/items"))
+    w.WriteHeader(http.StatusCreated)
+}
+
+// batchRowsFrom reads items[i].title / items[i].description fields into
+// rows ordered by index. Rows left completely blank are not validated.
+func batchRowsFrom(r *http.Request) []models.Item {
+    var rows []models.Item
+    for key, values := range r.PostForm {
+        m := batchField.FindStringSubmatch(key)
+        if m == nil {
+            continue
+        }
+        i, err := strconv.Atoi(m[1])
+        if err != nil || i >= maxBatchRows {
+            continue
+        }
+        for len(rows) <= i {
+            rows = append(rows, models.Item{})
+        }
+        value := fields.Clean(m[2], values[0])
+        if m[2] == "title" {
+            rows[i].Title = value
+        } else {
+            rows[i].Description = value
+        }
+    }
+    return rows
+}
+
+func blankRow(row models.Item) bool {
+    return row.Title == "" && row.Description == ""
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+    "net/http"
+    "myapp/events"
+    "myapp/models"
+    "myapp/validation"
+)
+
+// Fields that may be set in bulk, and how each is applied
+var bulkEditable = map[string]func(item *models.Item, value string){
+    "title":       func(item *models.Item, value string) { item.Title = value },
+    "description": func(item *models.Item, value string) { item.Description = value },
+}
+
+// BulkUpdateItems sets one field to the same value on every selected item
+// in a single store transaction, then re-renders the list.
+func BulkUpdateItems(w http.ResponseWriter, r *http.Request) {
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+    ids := r.Form["id"]
+    field := r.FormValue("field")
+
+    apply, ok := bulkEditable[field]
+    if !ok {
+        writeError(w, r, http.StatusBadRequest, "Field cannot be bulk-edited")
+        return
+    }
+    if len(ids) == 0 {
+        writeError(w, r, http.StatusBadRequest, "No items selected")
+        return
+    }
+
+    value := fields.Clean(field, r.FormValue("value"))
+    var probe models.Item
+    apply(&probe, value)
+    if msg := validation.Struct(newItemForm(probe)).Get(field); msg != "" {
+        writeError(w, r, http.StatusUnprocessableEntity, msg)
+        return
+    }
+    updated, err := itemStore.UpdateMany(r.Context(), ids, func(item *models.Item) {
+        apply(item, value)
+    })
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    for _, item := range updated {
+        bus.Publish(r.Context(), events.ItemUpdated{Item: item})
+    }
+
+    ListItems(w, r)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+    "errors"
+    "io"
+    "mime"
+    "net/http"
+    "strings"
+    "github.com/go-chi/chi/v5"
+    "myapp/config"
+    "myapp/httpctx"
+    "myapp/logging"
+    "myapp/models"
+    "myapp/storage"
+)
+
+// Where attachments are stored, and the size and type limits uploads are
+// checked against
+var (
+    blobs   storage.Blob = &storage.Local{Dir: "uploads"}
+    uploads              = config.Storage{MaxSize: 10 << 20, Types: config.DefaultUploadTypes}
+)
+
+func UseStorage(b storage.Blob, cfg config.Storage) {
+    blobs = b
+    uploads = cfg
+}
+
+// canAccess allows anyone to read unowned items and only the owner to read
+// owned ones.
+func canAccess(r *http.Request, item models.Item) bool {
+    return item.OwnerID == "" || item.OwnerID == httpctx.User(r.Context())
+}
+
+// DownloadItem serves an item's attachment: as a download, or with
+// ?inline=1 displayed in the page (images only). Items the caller may not
+// see answer 404 rather than 403 so their existence isn't leaked.
+// Downloads from storage that presigns links (S3) redirect to one;
+// otherwise the file is streamed, and range requests answer 206.
+func DownloadItem(w http.ResponseWriter, r *http.Request) {
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil || item.Attachment == nil || !canAccess(r, item) {
+        writeError(w, r, http.StatusNotFound, "Item not found")
+        return
+    }
+    attachment := item.Attachment
+
+    disposition := "attachment"
+    if r.URL.Query().Get("inline") != "" && strings.HasPrefix(attachment.ContentType, "image/") {
+        disposition = "inline"
+    }
+    disposition = mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename})
+
+    // Inline images stay on this origin, which the default CSP allows
+    if strings.HasPrefix(disposition, "attachment") {
+        url, err := blobs.URL(r.Context(), attachment.Path, disposition)
+        if err != nil {
+            logging.Error(r.Context(), "presign download", "err", err)
+            writeError(w, r, http.StatusInternalServerError, "Something went wrong")
+            return
+        }
+        if url != "" {
+            http.Redirect(w, r, url, http.StatusFound)
+            return
+        }
+    }
+
+    f, modTime, err := blobs.Open(r.Context(), attachment.Path)
+    if errors.Is(err, storage.ErrNotFound) {
+        writeError(w, r, http.StatusNotFound, "Item not found")
+        return
+    }
+    if err != nil {
+        logging.Error(r.Context(), "open attachment", "err", err)
+        writeError(w, r, http.StatusInternalServerError, "Something went wrong")
+        return
+    }
+    defer f.Close()
+
+    contentType := attachment.ContentType
+    if contentType == "" {
+        contentType = "application/octet-stream"
+    }
+    w.Header().Set("Content-Type", contentType)
+    w.Header().Set("Content-Disposition", disposition)
+    w.Header().Set("X-Content-Type-Options", "nosniff")
+    if rs, ok := f.(io.ReadSeeker); ok {
+        http.ServeContent(w, r, attachment.Filename, modTime, rs)
+        return
+    }
+    io.Copy(w, f)
+}
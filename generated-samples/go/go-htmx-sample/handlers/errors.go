@@ -0,0 +1,119 @@
+package handlers
+
+import (
+    "encoding/json"
+    "fmt"
+    "html"
+    "net/http"
+    "github.com/a-h/templ"
+    "github.com/gorilla/csrf"
+    "myapp/i18n"
+    "myapp/logging"
+    "myapp/validation"
+)
+
+// Error response formats selectable via ERROR_FORMAT
+const (
+    ErrorFormatHTML    = "html"
+    ErrorFormatJSON    = "json"
+    ErrorFormatProblem = "problem"
+)
+
+var errorFormat = ErrorFormatHTML
+
+func UseErrorFormat(format string) {
+    switch format {
+    case ErrorFormatJSON, ErrorFormatProblem:
+        errorFormat = format
+    default:
+        errorFormat = ErrorFormatHTML
+    }
+}
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+    Type     string `json:"type"`
+    Title    string `json:"title"`
+    Status   int    `json:"status"`
+    Detail   string `json:"detail,omitempty"`
+    Instance string `json:"instance,omitempty"`
+    // Errors is an extension member carrying field errors keyed by path
+    Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// writeError is the single place error responses are serialized. detail
+// is an English message key, translated for HTML responses.
+func writeError(w http.ResponseWriter, r *http.Request, status int, detail string) {
+    switch errorFormat {
+    case ErrorFormatProblem:
+        w.Header().Set("Content-Type", "application/problem+json")
+        w.WriteHeader(status)
+        json.NewEncoder(w).Encode(Problem{
+            Type:     "about:blank",
+            Title:    http.StatusText(status),
+            Status:   status,
+            Detail:   detail,
+            Instance: r.URL.Path,
+        })
+    case ErrorFormatJSON:
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(status)
+        json.NewEncoder(w).Encode(map[string][]string{"errors": {detail}})
+    default:
+        w.Header().Set("Content-Type", "text/html; charset=utf-8")
+        w.WriteHeader(status)
+        fmt.Fprintf(w, "<p>%s</p>", html.EscapeString(i18n.T(r.Context(), detail)))
+    }
+}
+
+// writeValidationErrors answers 422 with field errors keyed by path. HTML
+// responses re-render form, which shows each message beside its input.
+func writeValidationErrors(w http.ResponseWriter, r *http.Request, errs validation.Errors, form templ.Component) {
+    status := http.StatusUnprocessableEntity
+    switch errorFormat {
+    case ErrorFormatProblem:
+        w.Header().Set("Content-Type", "application/problem+json")
+        w.WriteHeader(status)
+        json.NewEncoder(w).Encode(Problem{
+            Type:     "about:blank",
+            Title:    http.StatusText(status),
+            Status:   status,
+            Detail:   "One or more fields are invalid",
+            Instance: r.URL.Path,
+            Errors:   errs,
+        })
+    case ErrorFormatJSON:
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(status)
+        json.NewEncoder(w).Encode(map[string]validation.Errors{"errors": errs})
+    default:
+        // The layout's htmx-config swaps 422 responses like 2xx ones
+        w.WriteHeader(status)
+        form.Render(r.Context(), w)
+    }
+}
+
+// writeConflict answers 409 when an edit was made to a stale copy. HTML
+// responses render form, the edit form reloaded with the stored item, so
+// the user can redo their change on top of it.
+func writeConflict(w http.ResponseWriter, r *http.Request, form templ.Component) {
+    if errorFormat != ErrorFormatHTML {
+        writeError(w, r, http.StatusConflict, "Item changed elsewhere, please retry")
+        return
+    }
+    // The layout's htmx-config swaps 409 responses like 2xx ones
+    w.WriteHeader(http.StatusConflict)
+    form.Render(r.Context(), w)
+}
+
+// CSRFFailure answers requests the CSRF middleware rejected: usually a page
+// left open across a restart (new key) or a cross-site form post.
+func CSRFFailure(w http.ResponseWriter, r *http.Request) {
+    logging.Warn(r.Context(), "csrf check failed", "reason", csrf.FailureReason(r))
+    writeError(w, r, http.StatusForbidden, "This form has expired, please reload the page")
+}
+
+// NotFound answers unmatched routes in the configured error format.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+    writeError(w, r, http.StatusNotFound, "Page not found")
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+    "net/http"
+    "myapp/models"
+)
+
+// itemForm is the item form submitted by create, edit and each batch row.
+// Limits match the ItemForm schema in openapi.yaml.
+type itemForm struct {
+    Title       string `form:"title" validate:"notblank,max=200"`
+    Description string `form:"description" validate:"max=5000"`
+}
+
+func newItemForm(item models.Item) itemForm {
+    return itemForm{Title: item.Title, Description: item.Description}
+}
+
+// readItemForm reads the submitted fields, sanitized per SANITIZE_FIELDS.
+func readItemForm(r *http.Request) itemForm {
+    return itemForm{
+        Title:       fields.Clean("title", r.FormValue("title")),
+        Description: fields.Clean("description", r.FormValue("description")),
+    }
+}
+
+func (f itemForm) apply(item *models.Item) {
+    item.Title = f.Title
+    item.Description = f.Description
+}
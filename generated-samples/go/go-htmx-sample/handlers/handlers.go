@@ -1,116 +1,251 @@
 package handlers
 
 import (
-    "fmt"
+    "context"
+    "errors"
     "net/http"
+    "strconv"
+    "strings"
     "github.com/go-chi/chi/v5"
+    "myapp/events"
+    "myapp/httpctx"
+    "myapp/logging"
     "myapp/models"
+    "myapp/sanitize"
+    "myapp/search"
+    "myapp/store"
+    "myapp/validation"
     "myapp/views"
 )
 
-// In-memory storage (replace with database in production)
-var items []models.Item
-var nextID int
+// Item persistence (in-memory unless main wires another backend)
+var itemStore store.ItemStore = store.NewMemoryStore()
 
-func init() {
-    nextID = 1
-    items = []models.Item{
-        {ID: "1", Title: "Sample Item", Description: "A sample item"},
-    }
-    nextID = 2
+func UseStore(s store.ItemStore) {
+    itemStore = s
+}
+
+// Item change notifications (nil disables publishing)
+var bus *events.Bus
+
+func UseEvents(b *events.Bus) {
+    bus = b
+}
+
+// Per-field HTML sanitization applied to user input
+var fields = sanitize.Fields{}
+
+func UseSanitizer(f sanitize.Fields) {
+    fields = f
+}
+
+// Fields rendered by the list fragment
+var listColumns = views.AllColumns
+
+func UseListColumns(cols views.ListColumns) {
+    listColumns = cols
+}
+
+// What an empty search shows (the initial list render is an empty search)
+var emptySearch = search.EmptyAll
+
+func UseEmptySearch(mode search.EmptyQuery) {
+    emptySearch = mode
 }
 
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
-    w.Header().Set("Content-Type", "application/json")
-    fmt.Fprintf(w, `{"status":"healthy","service":"Go HTMX App"}`)
+// Items per list page unless ?per_page asks otherwise; requests are capped
+// at maxPageSize
+const maxPageSize = 100
+
+var pageSize = 20
+
+func UsePageSize(n int) {
+    pageSize = min(n, maxPageSize)
+}
+
+// writeStoreError maps store errors onto HTTP responses. A cancelled
+// context means the client hung up, which is not a server error: nothing
+// is written and the access log records it as 499. A passed deadline
+// (REQUEST_TIMEOUT) is answered with 503 so the client can retry.
+func writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
+    if errors.Is(err, context.Canceled) {
+        return
+    }
+    if errors.Is(err, context.DeadlineExceeded) {
+        logging.Warn(r.Context(), "store operation timed out", "err", err)
+        writeError(w, r, http.StatusServiceUnavailable, "Request timed out, please retry")
+        return
+    }
+    if errors.Is(err, store.ErrNotFound) {
+        writeError(w, r, http.StatusNotFound, "Item not found")
+        return
+    }
+    if errors.Is(err, store.ErrConflict) {
+        writeError(w, r, http.StatusConflict, "Item changed elsewhere, please retry")
+        return
+    }
+    logging.Error(r.Context(), "store operation failed", "err", err)
+    writeError(w, r, http.StatusInternalServerError, "Something went wrong")
 }
 
 func HomePage(w http.ResponseWriter, r *http.Request) {
-    component := views.Home()
-    component.Render(r.Context(), w)
+    views.RenderHome(r.Context(), w, themeFor(r), themeToggle)
 }
 
+// ListItems renders one page of the item list. ?q searches titles and
+// descriptions, ?sort orders by a store.SortFields field ("-" for
+// descending) or by relevance, the default while searching, and
+// ?page/?per_page pick the page. Bad values fall back to
+// their defaults rather than erroring, since they come from links and
+// form controls.
 func ListItems(w http.ResponseWriter, r *http.Request) {
-    component := views.ItemList(items)
+    query := r.URL.Query()
+    pager := views.Pager{
+        Page:    positiveInt(query.Get("page"), 1),
+        PerPage: min(positiveInt(query.Get("per_page"), pageSize), maxPageSize),
+        Query:   query.Get("q"),
+        Sort:    query.Get("sort"),
+    }
+    if !store.ValidSort(pager.Sort) {
+        pager.Sort = ""
+    }
+
+    empty := "No items yet"
+    if strings.TrimSpace(pager.Query) != "" {
+        empty = "No matching items"
+    } else if emptySearch == search.EmptyNone {
+        views.ItemList(nil, listColumns, "Type to search items", pager).Render(r.Context(), w)
+        return
+    }
+
+    page, err := itemStore.Find(r.Context(), store.ListQuery{
+        Search: pager.Query,
+        Sort:   pager.Sort,
+        Offset: (pager.Page - 1) * pager.PerPage,
+        Limit:  pager.PerPage,
+    })
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    pager.Total = page.Total
+
+    component := views.ItemList(page.Items, listColumns, empty, pager)
     component.Render(r.Context(), w)
 }
 
+// positiveInt parses a query value, returning def unless it is a whole
+// number above zero.
+func positiveInt(s string, def int) int {
+    n, err := strconv.Atoi(s)
+    if err != nil || n < 1 {
+        return def
+    }
+    return n
+}
+
 func GetItem(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    
-    for _, item := range items {
-        if item.ID == id {
-            component := views.ItemDetail(item)
-            component.Render(r.Context(), w)
-            return
-        }
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
     }
-    
-    w.WriteHeader(http.StatusNotFound)
-    fmt.Fprintf(w, "<p>Item not found</p>")
+
+    component := views.ItemDetail(item)
+    component.Render(r.Context(), w)
 }
 
 func CreateItem(w http.ResponseWriter, r *http.Request) {
-    r.ParseForm()
-    title := r.FormValue("title")
-    description := r.FormValue("description")
-    
-    item := models.Item{
-        ID: fmt.Sprintf("%d", nextID),
-        Title: title,
-        Description: description,
-    }
-    nextID++
-    
-    items = append(items, item)
-    
-    w.Header().Set("HX-Redirect", "/items")
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+    form := readItemForm(r)
+    item := models.Item{OwnerID: httpctx.User(r.Context())}
+    form.apply(&item)
+    if errs := validation.Struct(form); !errs.Empty() {
+        // The form targets the list; swap the form itself instead
+        w.Header().Set("HX-Retarget", "#new-item")
+        w.Header().Set("HX-Reswap", "outerHTML")
+        writeValidationErrors(w, r, errs, views.NewItemForm(item, errs))
+        return
+    }
+
+    item, err := itemStore.Create(r.Context(), item)
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    bus.Publish(r.Context(), events.ItemCreated{Item: item})
+
+    w.Header().Set("HX-Redirect", views.Path("/items"))
     w.WriteHeader(http.StatusCreated)
 }
 
 func EditItemForm(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    
-    for _, item := range items {
-        if item.ID == id {
-            component := views.EditItemForm(item)
-            component.Render(r.Context(), w)
-            return
-        }
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
     }
-    
-    w.WriteHeader(http.StatusNotFound)
+
+    component := views.EditItemForm(item, validation.Errors{})
+    component.Render(r.Context(), w)
 }
 
 func UpdateItem(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    r.ParseForm()
-    title := r.FormValue("title")
-    description := r.FormValue("description")
-    
-    for i, item := range items {
-        if item.ID == id {
-            items[i].Title = title
-            items[i].Description = description
-            component := views.ItemDetail(items[i])
-            component.Render(r.Context(), w)
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+    form := readItemForm(r)
+    form.apply(&item)
+    // Save against the version the form was rendered with, not the one
+    // just read, so edits made meanwhile are not overwritten
+    if version, err := strconv.Atoi(r.FormValue("version")); err == nil {
+        item.Version = version
+    }
+    if errs := validation.Struct(form); !errs.Empty() {
+        writeValidationErrors(w, r, errs, views.EditItemForm(item, errs))
+        return
+    }
+
+    updated, err := itemStore.Update(r.Context(), item)
+    if errors.Is(err, store.ErrConflict) {
+        current, err := itemStore.Get(r.Context(), item.ID)
+        if err != nil {
+            writeStoreError(w, r, err)
             return
         }
+        errs := validation.Errors{"version": "Item changed elsewhere; reloaded, try again"}
+        writeConflict(w, r, views.EditItemForm(current, errs))
+        return
+    }
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
     }
-    
-    w.WriteHeader(http.StatusNotFound)
+    bus.Publish(r.Context(), events.ItemUpdated{Item: updated})
+
+    component := views.ItemDetail(updated)
+    component.Render(r.Context(), w)
 }
 
 func DeleteItem(w http.ResponseWriter, r *http.Request) {
     id := chi.URLParam(r, "id")
-    
-    for i, item := range items {
-        if item.ID == id {
-            items = append(items[:i], items[i+1:]...)
-            w.WriteHeader(http.StatusOK)
-            return
-        }
+    item, _ := itemStore.Get(r.Context(), id)
+    if err := itemStore.Delete(r.Context(), id); err != nil {
+        writeStoreError(w, r, err)
+        return
     }
-    
-    w.WriteHeader(http.StatusNotFound)
-}
\ No newline at end of file
+    if item.Attachment != nil {
+        removeBlob(r, item.Attachment.Path)
+    }
+    bus.Publish(r.Context(), events.ItemDeleted{ID: id})
+
+    w.WriteHeader(http.StatusOK)
+}
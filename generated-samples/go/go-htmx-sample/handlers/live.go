@@ -0,0 +1,22 @@
+package handlers
+
+import (
+    "context"
+    "github.com/a-h/templ"
+    "myapp/events"
+    "myapp/views"
+)
+
+// LiveUpdate is the realtime.Renderer for item events: what every open
+// page swaps in when an item changes.
+func LiveUpdate(ctx context.Context, e events.Event) templ.Component {
+    switch e := e.(type) {
+    case events.ItemCreated:
+        return views.LiveItemsChanged()
+    case events.ItemUpdated:
+        return views.LiveItemUpdated(e.Item, listColumns)
+    case events.ItemDeleted:
+        return views.LiveItemDeleted(e.ID)
+    }
+    return nil
+}
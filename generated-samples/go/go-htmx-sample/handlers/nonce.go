@@ -0,0 +1,63 @@
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "html"
+    "net/http"
+    "myapp/logging"
+    "myapp/nonce"
+    "myapp/views"
+)
+
+// Form field carrying the one-time nonce
+const nonceField = "_nonce"
+
+var nonces *nonce.Store
+
+// UseNonces turns on double-submit protection for item forms. With a nil
+// store nonces are neither issued nor checked.
+func UseNonces(s *nonce.Store) {
+    nonces = s
+}
+
+// Fetch a new nonce after the enclosing form submits, so forms that stay on
+// the page (like bulk edit) can be used again. The filter skips the nonce
+// request's own events, which bubble up to the form too.
+const refreshTrigger = "htmx:afterRequest[detail.elt.tagName=='FORM'] from:closest form"
+
+// FormNonce returns a hidden input with a fresh nonce. Forms load it with
+// hx-get so pages that are rendered once and cached still get a unique
+// nonce per visit.
+func FormNonce(w http.ResponseWriter, r *http.Request) {
+    if nonces == nil {
+        return
+    }
+    n, err := nonces.Issue()
+    if err != nil {
+        logging.Error(r.Context(), "issue form nonce", "err", err)
+        writeError(w, r, http.StatusInternalServerError, "Something went wrong")
+        return
+    }
+    fmt.Fprintf(w, `<span hx-get="%s" hx-trigger="%s" hx-swap="outerHTML">`+
+        `<input type="hidden" name="%s" value="%s" /></span>`,
+        html.EscapeString(views.Path("/forms/nonce")), html.EscapeString(refreshTrigger), nonceField, html.EscapeString(n))
+}
+
+// consumeNonce rejects a form submission whose nonce was already used or
+// never issued. Call it after the form is parsed.
+func consumeNonce(w http.ResponseWriter, r *http.Request) bool {
+    if nonces == nil {
+        return true
+    }
+    err := nonces.Consume(r.FormValue(nonceField))
+    switch {
+    case err == nil:
+        return true
+    case errors.Is(err, nonce.ErrReplayed):
+        writeError(w, r, http.StatusConflict, "This form was already submitted")
+    default:
+        writeError(w, r, http.StatusBadRequest, "This form has expired, please reload the page")
+    }
+    return false
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import "net/http"
+
+const themeCookie = "theme"
+
+var themeToggle bool
+
+// UseThemeToggle enables the light/dark switch in the layout.
+func UseThemeToggle(enabled bool) {
+    themeToggle = enabled
+}
+
+// themeFor picks the theme class from the cookie written by the toggle,
+// defaulting to light for unknown values.
+func themeFor(r *http.Request) string {
+    if !themeToggle {
+        return "light"
+    }
+    if c, err := r.Cookie(themeCookie); err == nil && c.Value == "dark" {
+        return "dark"
+    }
+    return "light"
+}
+
+// Theme returns the theme and toggle setting for pages rendered outside
+// this package, such as the login form.
+func Theme(r *http.Request) (string, bool) {
+    return themeFor(r), themeToggle
+}
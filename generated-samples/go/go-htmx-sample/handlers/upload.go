@@ -0,0 +1,116 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "path/filepath"
+    "github.com/go-chi/chi/v5"
+    "myapp/events"
+    "myapp/logging"
+    "myapp/models"
+    "myapp/storage"
+    "myapp/validation"
+    "myapp/views"
+)
+
+// Multipart parts beyond this are spooled to temp files instead of memory
+const uploadMemory = 8 << 20
+
+// parseForm parses the request form, answering 413 when the body exceeded
+// the route's size limit and 400 for anything else malformed.
+func parseForm(w http.ResponseWriter, r *http.Request) bool {
+    if err := r.ParseForm(); err != nil {
+        writeFormError(w, r, err)
+        return false
+    }
+    return true
+}
+
+func writeFormError(w http.ResponseWriter, r *http.Request, err error) {
+    var tooLarge *http.MaxBytesError
+    if errors.As(err, &tooLarge) {
+        writeError(w, r, http.StatusRequestEntityTooLarge, "Request is too large")
+        return
+    }
+    writeError(w, r, http.StatusBadRequest, "Invalid form submission")
+}
+
+// UploadAttachment stores the "file" part of a multipart form and attaches
+// it to the item, replacing any previous attachment. Files over
+// UPLOAD_MAX_SIZE or of a type outside UPLOAD_TYPES (sniffed from the
+// content, not the client's header) answer 422 with the item re-rendered
+// and the reason beside the file input.
+func UploadAttachment(w http.ResponseWriter, r *http.Request) {
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil || !canAccess(r, item) {
+        writeError(w, r, http.StatusNotFound, "Item not found")
+        return
+    }
+
+    if err := r.ParseMultipartForm(uploadMemory); err != nil {
+        writeFormError(w, r, err)
+        return
+    }
+    file, header, err := r.FormFile("file")
+    if err != nil {
+        writeError(w, r, http.StatusBadRequest, "No file uploaded")
+        return
+    }
+    defer file.Close()
+
+    errs := validation.Errors{}
+    if header.Size > uploads.MaxSize {
+        errs.Add("file", "File is too large")
+    }
+    contentType, content, err := storage.Sniff(file)
+    if err != nil {
+        logging.Error(r.Context(), "read upload", "err", err)
+        writeError(w, r, http.StatusInternalServerError, "Something went wrong")
+        return
+    }
+    if errs.Empty() && !storage.Allowed(contentType, uploads.Types) {
+        errs.Add("file", "This type of file is not allowed")
+    }
+    if !errs.Empty() {
+        writeValidationErrors(w, r, errs, views.ItemDetailErrors(item, errs))
+        return
+    }
+
+    key, err := storage.NewKey(header.Filename)
+    if err == nil {
+        err = blobs.Put(r.Context(), key, content, contentType)
+    }
+    if err != nil {
+        logging.Error(r.Context(), "store upload", "err", err)
+        writeError(w, r, http.StatusInternalServerError, "Something went wrong")
+        return
+    }
+
+    previous := item.Attachment
+    item.Attachment = &models.Attachment{
+        Path:        key,
+        Filename:    filepath.Base(header.Filename),
+        ContentType: contentType,
+    }
+    item, err = itemStore.Update(r.Context(), item)
+    if err != nil {
+        removeBlob(r, key)
+        writeStoreError(w, r, err)
+        return
+    }
+    if previous != nil {
+        removeBlob(r, previous.Path)
+    }
+    bus.Publish(r.Context(), events.ItemUpdated{Item: item})
+
+    component := views.ItemDetail(item)
+    component.Render(r.Context(), w)
+}
+
+// removeBlob deletes a blob no item refers to any more. Failures only
+// leave an orphan behind, so they are logged rather than answered.
+func removeBlob(r *http.Request, key string) {
+    if err := blobs.Delete(r.Context(), key); err != nil {
+        logging.Warn(r.Context(), "delete blob", "key", key, "err", err)
+    }
+}
@@ -0,0 +1,181 @@
+package health
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// Dependency is a downstream HTTP service whose health endpoint is polled.
+// A failing critical dependency makes the app unhealthy; a failing
+// non-critical one only marks it degraded.
+type Dependency struct {
+    Name     string
+    URL      string
+    Timeout  time.Duration
+    Critical bool
+}
+
+// ParseDependencies reads a spec such as
+//
+//    payments=http://payments:8080/health;timeout=1s,search=http://search/health;optional
+//
+// Dependencies are critical unless marked optional; timeout defaults to 2s.
+func ParseDependencies(spec string) ([]Dependency, error) {
+    var deps []Dependency
+    for _, entry := range strings.Split(spec, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        parts := strings.Split(entry, ";")
+        name, url, ok := strings.Cut(parts[0], "=")
+        if !ok || name == "" || url == "" {
+            return nil, fmt.Errorf("dependency %q: want name=url", entry)
+        }
+
+        d := Dependency{Name: strings.TrimSpace(name), URL: strings.TrimSpace(url), Timeout: defaultTimeout, Critical: true}
+        for _, opt := range parts[1:] {
+            key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+            switch key {
+            case "optional":
+                d.Critical = false
+            case "timeout":
+                t, err := time.ParseDuration(value)
+                if err != nil {
+                    return nil, fmt.Errorf("dependency %q: %w", d.Name, err)
+                }
+                d.Timeout = t
+            default:
+                return nil, fmt.Errorf("dependency %q: unknown option %q", d.Name, key)
+            }
+        }
+        deps = append(deps, d)
+    }
+    return deps, nil
+}
+
+// Dependencies polls every dependency in the background and keeps the
+// latest result so readiness probes never wait on downstream services.
+type Dependencies struct {
+    deps     []Dependency
+    interval time.Duration
+    client   *http.Client
+
+    mu     sync.RWMutex
+    errors map[string]error
+
+    cancel context.CancelFunc
+    done   chan struct{}
+}
+
+func NewDependencies(deps []Dependency, interval time.Duration) *Dependencies {
+    return &Dependencies{
+        deps:     deps,
+        interval: interval,
+        client:   &http.Client{},
+        errors:   make(map[string]error),
+    }
+}
+
+// Start checks every dependency once, so readiness reflects real state as
+// soon as the gate opens, then keeps polling until Stop.
+func (d *Dependencies) Start(ctx context.Context) error {
+    d.CheckOnce(ctx)
+
+    ctx, d.cancel = context.WithCancel(context.WithoutCancel(ctx))
+    d.done = make(chan struct{})
+
+    go func() {
+        defer close(d.done)
+        ticker := time.NewTicker(d.interval)
+        defer ticker.Stop()
+
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+                d.CheckOnce(ctx)
+            }
+        }
+    }()
+    return nil
+}
+
+func (d *Dependencies) Stop(ctx context.Context) error {
+    if d.cancel == nil {
+        return nil
+    }
+    d.cancel()
+    select {
+    case <-d.done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// CheckOnce probes every dependency concurrently and records the results.
+func (d *Dependencies) CheckOnce(ctx context.Context) {
+    results := make([]error, len(d.deps))
+    var wg sync.WaitGroup
+    for i, dep := range d.deps {
+        wg.Add(1)
+        go func(i int, dep Dependency) {
+            defer wg.Done()
+            results[i] = d.probe(ctx, dep)
+        }(i, dep)
+    }
+    wg.Wait()
+
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    for i, dep := range d.deps {
+        d.errors[dep.Name] = results[i]
+    }
+}
+
+func (d *Dependencies) probe(ctx context.Context, dep Dependency) error {
+    ctx, cancel := context.WithTimeout(ctx, dep.Timeout)
+    defer cancel()
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, dep.URL, nil)
+    if err != nil {
+        return err
+    }
+    resp, err := d.client.Do(req)
+    if err != nil {
+        return err
+    }
+    resp.Body.Close()
+    if resp.StatusCode >= 400 {
+        return fmt.Errorf("status %d", resp.StatusCode)
+    }
+    return nil
+}
+
+// Report folds the latest results into an overall status: unhealthy when
+// a critical dependency is down, degraded when only optional ones are.
+func (d *Dependencies) Report() (string, map[string]CheckStatus) {
+    d.mu.RLock()
+    defer d.mu.RUnlock()
+
+    overall := StatusReady
+    statuses := make(map[string]CheckStatus, len(d.deps))
+    for _, dep := range d.deps {
+        s := CheckStatus{Status: "up", Critical: dep.Critical}
+        if err := d.errors[dep.Name]; err != nil {
+            s.Status = "down"
+            s.Error = err.Error()
+        }
+        statuses[dep.Name] = s
+        overall = worse(overall, s)
+    }
+    return overall, statuses
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+    "net/http"
+    "sync/atomic"
+)
+
+// Gate reports whether the app has finished starting up. It starts closed
+// and is opened once migrations, connections and seeding are done, so load
+// balancers don't route traffic to a half-initialized instance.
+type Gate struct {
+    ready  atomic.Bool
+    checks *Checks
+    deps   *Dependencies
+}
+
+// NewGate returns a closed gate whose readiness response also runs checks.
+func NewGate(checks *Checks) *Gate {
+    return &Gate{checks: checks}
+}
+
+// Watch folds downstream dependency health into the readiness response.
+func (g *Gate) Watch(d *Dependencies) {
+    g.deps = d
+}
+
+func (g *Gate) MarkReady() {
+    g.ready.Store(true)
+}
+
+// MarkNotReady closes the gate again, e.g. while draining on shutdown.
+func (g *Gate) MarkNotReady() {
+    g.ready.Store(false)
+}
+
+func (g *Gate) Ready() bool {
+    return g.ready.Load()
+}
+
+// Handler answers 503 until ready. Once ready it runs the checks and
+// reports them with the latest dependency results: 503 when a critical
+// one is down, 200 with status "degraded" when only optional ones are.
+func (g *Gate) Handler(w http.ResponseWriter, r *http.Request) {
+    if !g.Ready() {
+        writeStatus(w, StatusUnhealthy, map[string]any{"status": "starting"})
+        return
+    }
+
+    body := map[string]any{}
+    status := StatusReady
+    if g.checks != nil {
+        var checks map[string]CheckStatus
+        status, checks = g.checks.Run(r.Context())
+        body["checks"] = checks
+    }
+    if g.deps != nil {
+        depStatus, deps := g.deps.Report()
+        if severity[depStatus] > severity[status] {
+            status = depStatus
+        }
+        body["dependencies"] = deps
+    }
+    body["status"] = status
+    writeStatus(w, status, body)
+}
+
+var severity = map[string]int{StatusReady: 0, StatusDegraded: 1, StatusUnhealthy: 2}
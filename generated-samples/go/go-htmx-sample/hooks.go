@@ -0,0 +1,28 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "myapp/lifecycle"
+    "myapp/storage"
+)
+
+// registerHooks is the place to add startup/shutdown work such as warming
+// caches or flushing buffers. Hooks start in order and stop in reverse.
+func registerHooks(lc *lifecycle.Lifecycle, blobs storage.Blob) {
+    // Example: refuse to boot when attachments can't be stored
+    lc.Append(lifecycle.Hook{
+        Name: "storage",
+        OnStart: func(ctx context.Context) error {
+            if err := blobs.Ping(ctx); err != nil {
+                return fmt.Errorf("attachment storage unusable: %w", err)
+            }
+            return nil
+        },
+        OnStop: func(ctx context.Context) error {
+            log.Println("👋 Shutting down")
+            return nil
+        },
+    })
+}
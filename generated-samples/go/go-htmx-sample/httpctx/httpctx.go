@@ -0,0 +1,30 @@
+package httpctx
+
+import (
+    "context"
+    "time"
+    "myapp/logging"
+    "myapp/reqctx"
+)
+
+// What a handler reads off its request's context, in one place: the
+// values reqctx and logging keep, plus how long the request has left.
+var (
+    // User returns the authenticated user's ID, or "" for anonymous requests.
+    User = reqctx.User
+    // RequestID returns the request ID, or "" outside a request.
+    RequestID = reqctx.RequestID
+    // Logger returns the request's logger, tagged with its ID, method and
+    // path, or the default logger outside a request.
+    Logger = logging.FromContext
+)
+
+// Remaining returns how long until ctx's deadline (REQUEST_TIMEOUT on item
+// routes), floored at zero, and false when it has none.
+func Remaining(ctx context.Context) (time.Duration, bool) {
+    deadline, ok := ctx.Deadline()
+    if !ok {
+        return 0, false
+    }
+    return max(time.Until(deadline), 0), true
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+    "golang.org/x/text/language"
+    "golang.org/x/text/message"
+)
+
+// English strings are the message keys; add a map per supported locale and
+// list the tag in Supported.
+var catalog = map[language.Tag]map[string]string{
+    language.Spanish: {
+        "Add New Item":                                  "Añadir elemento",
+        "Title":                                         "Título",
+        "Description":                                   "Descripción",
+        "Add Item":                                      "Añadir",
+        "Items":                                         "Elementos",
+        "Search items...":                               "Buscar elementos...",
+        "Loading...":                                    "Cargando...",
+        "No items yet":                                  "Todavía no hay elementos",
+        "No matching items":                             "Ningún elemento coincide",
+        "Type to search items":                          "Escribe para buscar elementos",
+        "View":                                          "Ver",
+        "Edit":                                          "Editar",
+        "Delete":                                        "Eliminar",
+        "Are you sure?":                                 "¿Estás seguro?",
+        "Update Item":                                   "Actualizar",
+        "Cancel":                                        "Cancelar",
+        "Item not found":                                "Elemento no encontrado",
+        "Request is too large":                          "La solicitud es demasiado grande",
+        "Invalid form submission":                       "Envío de formulario no válido",
+        "Upload":                                        "Subir",
+        "This form was already submitted":               "Este formulario ya fue enviado",
+        "This form has expired, please reload the page": "Este formulario ha caducado, recarga la página",
+        "No file uploaded":                              "No se ha subido ningún archivo",
+        "File is too large":                             "El archivo es demasiado grande",
+        "This type of file is not allowed":              "Este tipo de archivo no está permitido",
+        "Page not found":                                "Página no encontrada",
+        "Something went wrong":                          "Algo salió mal",
+        "No items selected":                             "No hay elementos seleccionados",
+        "Field cannot be bulk-edited":                   "Este campo no se puede editar en bloque",
+        "New value":                                     "Nuevo valor",
+        "Update selected":                               "Actualizar seleccionados",
+        "Title is too long":                             "El título es demasiado largo",
+        "Description is too long":                       "La descripción es demasiado larga",
+        "Add at least one item":                         "Añade al menos un elemento",
+        "One or more fields are invalid":                "Uno o más campos no son válidos",
+        "Add several items":                             "Añadir varios elementos",
+        "Add Items":                                     "Añadir elementos",
+        "Title is required":                             "El título es obligatorio",
+        "Sign in":                                       "Iniciar sesión",
+        "Sign out":                                      "Cerrar sesión",
+        "Create an account":                             "Crear una cuenta",
+        "Create account":                                "Crear cuenta",
+        "Continue with %s":                              "Continuar con %s",
+        "Email":                                         "Correo electrónico",
+        "Password":                                      "Contraseña",
+        "Invalid email or password":                     "Correo o contraseña incorrectos",
+        "An account with this email already exists":     "Ya existe una cuenta con este correo",
+        "Enter a valid email address":                   "Introduce un correo válido",
+        "Password must be 8 to 72 characters":           "La contraseña debe tener entre 8 y 72 caracteres",
+        "Sign-in with that provider failed":             "No se pudo iniciar sesión con ese proveedor",
+        "Sort by":                                       "Ordenar por",
+        "Best match":                                    "Más relevantes",
+        "Oldest first":                                  "Más antiguos primero",
+        "Newest first":                                  "Más recientes primero",
+        "Title A-Z":                                     "Título A-Z",
+        "Title Z-A":                                     "Título Z-A",
+        "Previous":                                      "Anterior",
+        "Next":                                          "Siguiente",
+        "Page %d of %d":                                 "Página %d de %d",
+        "Items: %d":                                     "Elementos: %d",
+        "Language":                                      "Idioma",
+        "Item changed elsewhere; reloaded, try again":   "El elemento cambió en otro lugar; recargado, inténtalo de nuevo",
+        "Item changed elsewhere, please retry":          "El elemento cambió en otro lugar, inténtalo de nuevo",
+        "Request timed out, please retry":               "La solicitud tardó demasiado, inténtalo de nuevo",
+        "Dashboard":                                     "Panel",
+        "Users":                                         "Usuarios",
+        "Back to the app":                               "Volver a la aplicación",
+        "New this week":                                 "Nuevos esta semana",
+        "With attachments":                              "Con adjuntos",
+        "Deleted":                                       "Eliminados",
+        "Show deleted":                                  "Mostrar eliminados",
+        "Bulk action":                                   "Acción en bloque",
+        "Clear description":                             "Borrar descripción",
+        "Apply to selected":                             "Aplicar a la selección",
+        "Created":                                       "Creado",
+        "Updated":                                       "Actualizado",
+        "Version":                                       "Versión",
+        "Role":                                          "Rol",
+        "Admin":                                         "Administrador",
+        "User":                                          "Usuario",
+        "Forgot your password?":                         "¿Olvidaste tu contraseña?",
+        "Reset your password":                           "Restablece tu contraseña",
+        "Email me a link":                               "Envíame un enlace",
+        "New password":                                  "Nueva contraseña",
+        "Set password":                                  "Guardar contraseña",
+        "Check your email":                              "Revisa tu correo",
+        "If an account uses that address, we've sent it a link to reset the password.": "Si una cuenta usa esa dirección, le hemos enviado un enlace para restablecer la contraseña.",
+        "Email confirmed":                               "Correo confirmado",
+        "Thanks, your email address is confirmed.":      "Gracias, tu dirección de correo está confirmada.",
+        "Email not confirmed":                           "Correo no confirmado",
+        "This link is invalid or has expired.":          "Este enlace no es válido o ha caducado.",
+    },
+}
+
+// Supported lists the locales with catalogs; the first is the fallback.
+var Supported = []language.Tag{language.English, language.Spanish}
+
+func init() {
+    for tag, messages := range catalog {
+        for key, msg := range messages {
+            message.SetString(tag, key, msg)
+        }
+    }
+}
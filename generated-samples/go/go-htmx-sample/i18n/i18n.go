@@ -0,0 +1,62 @@
+package i18n
+
+import (
+    "context"
+    "net/http"
+    "sync"
+    "golang.org/x/text/language"
+    "golang.org/x/text/message"
+)
+
+// LocaleCookie overrides Accept-Language when set.
+const LocaleCookie = "lang"
+
+type printerKey struct{}
+
+type localeKey struct{}
+
+var fallback = message.NewPrinter(Supported[0])
+
+// matcher is built on first use, once Supported is final.
+var matcher = sync.OnceValue(func() language.Matcher {
+    return language.NewMatcher(Supported)
+})
+
+// Middleware negotiates the request locale from the lang cookie, then
+// Accept-Language, and stores it in the context.
+func Middleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        cookie := ""
+        if c, err := r.Cookie(LocaleCookie); err == nil {
+            cookie = c.Value
+        }
+        tag := Negotiate(cookie, r.Header.Get("Accept-Language"))
+        next.ServeHTTP(w, r.WithContext(withLocale(r.Context(), tag)))
+    })
+}
+
+func withLocale(ctx context.Context, tag language.Tag) context.Context {
+    ctx = context.WithValue(ctx, localeKey{}, tag)
+    return context.WithValue(ctx, printerKey{}, message.NewPrinter(tag))
+}
+
+// Printer returns the request's printer, or the fallback locale's.
+func Printer(ctx context.Context) *message.Printer {
+    if p, ok := ctx.Value(printerKey{}).(*message.Printer); ok {
+        return p
+    }
+    return fallback
+}
+
+// Locale returns the request's Supported locale, or the fallback.
+func Locale(ctx context.Context) language.Tag {
+    if tag, ok := ctx.Value(localeKey{}).(language.Tag); ok {
+        return tag
+    }
+    return Supported[0]
+}
+
+// T translates key (an English message) for the request's locale.
+func T(ctx context.Context, key string, args ...any) string {
+    return Printer(ctx).Sprintf(key, args...)
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+    "context"
+    "net/http"
+    "net/url"
+    "time"
+    "github.com/go-chi/chi/v5"
+    "golang.org/x/text/language"
+    "golang.org/x/text/language/display"
+)
+
+// Name is a locale's name in its own language, for the language switcher.
+func Name(tag language.Tag) string {
+    return display.Self.Name(tag)
+}
+
+// Negotiate picks the Supported locale for a lang cookie value and an
+// Accept-Language header, in that order, falling back to the first.
+func Negotiate(cookie, acceptLanguage string) language.Tag {
+    _, index := language.MatchStrings(matcher(), cookie, acceptLanguage)
+    return Supported[index]
+}
+
+// ForLocale returns ctx set up to translate into tag, as Middleware does
+// for each request; used to render pages outside a request.
+func ForLocale(ctx context.Context, tag language.Tag) context.Context {
+    return withLocale(ctx, tag)
+}
+
+// SwitchLocale serves GET /locale/{lang}: it keeps a supported lang in the
+// LocaleCookie for a year and sends the browser back to the page it came
+// from (home when the referrer is another site).
+func SwitchLocale(home string) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        tag, err := language.Parse(chi.URLParam(r, "lang"))
+        if err != nil || Negotiate(tag.String(), "") != tag {
+            http.NotFound(w, r)
+            return
+        }
+        http.SetCookie(w, &http.Cookie{
+            Name:     LocaleCookie,
+            Value:    tag.String(),
+            Path:     "/",
+            MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+            HttpOnly: true,
+            SameSite: http.SameSiteLaxMode,
+        })
+
+        back := home
+        if ref, err := url.Parse(r.Referer()); err == nil && ref.Host == r.Host && ref.Path != "" {
+            back = ref.RequestURI()
+        }
+        http.Redirect(w, r, back, http.StatusSeeOther)
+    }
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+    "context"
+    "myapp/config"
+)
+
+// Client enqueues background jobs for the worker. This project was
+// generated without --jobs, so there is no queue and nothing is
+// enqueued; generate with --jobs asynq|river to get one.
+type Client struct{}
+
+func Open(ctx context.Context, cfg config.Jobs, db config.Database) (*Client, error) {
+    return &Client{}, nil
+}
+
+// SendWelcomeEmail enqueues the welcome email for a new account.
+func (c *Client) SendWelcomeEmail(ctx context.Context, email string) error {
+    return nil
+}
+
+func (c *Client) Close() error {
+    return nil
+}
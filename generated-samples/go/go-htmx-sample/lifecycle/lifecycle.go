@@ -0,0 +1,63 @@
+package lifecycle
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log"
+)
+
+// Hook is a named pair of start/stop callbacks. Either may be nil.
+type Hook struct {
+    Name    string
+    OnStart func(ctx context.Context) error
+    OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle runs start hooks in registration order during boot and stop
+// hooks in reverse order during shutdown.
+type Lifecycle struct {
+    hooks   []Hook
+    started int
+}
+
+func New() *Lifecycle {
+    return &Lifecycle{}
+}
+
+func (l *Lifecycle) Append(h Hook) {
+    l.hooks = append(l.hooks, h)
+}
+
+// Start runs every OnStart hook in order. If one fails, the hooks that
+// already started are stopped in reverse order and the error is returned
+// so the caller can abort boot.
+func (l *Lifecycle) Start(ctx context.Context) error {
+    for _, h := range l.hooks {
+        if h.OnStart != nil {
+            if err := h.OnStart(ctx); err != nil {
+                stopErr := l.Stop(ctx)
+                return errors.Join(fmt.Errorf("lifecycle: start %s: %w", h.Name, err), stopErr)
+            }
+        }
+        l.started++
+    }
+    return nil
+}
+
+// Stop runs the OnStop hooks of every started hook in reverse order. All
+// hooks are given a chance to run; their errors are joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+    var errs []error
+    for ; l.started > 0; l.started-- {
+        h := l.hooks[l.started-1]
+        if h.OnStop == nil {
+            continue
+        }
+        if err := h.OnStop(ctx); err != nil {
+            log.Printf("lifecycle: stop %s: %v", h.Name, err)
+            errs = append(errs, fmt.Errorf("lifecycle: stop %s: %w", h.Name, err))
+        }
+    }
+    return errors.Join(errs...)
+}
@@ -0,0 +1,20 @@
+package mailer
+
+import (
+    "context"
+    "myapp/logging"
+)
+
+// Console writes messages to the log instead of sending them, so links in
+// verification and reset emails can be followed in development.
+type Console struct {
+    From string
+}
+
+func (c *Console) Send(ctx context.Context, msg Message) error {
+    if msg.To == "" {
+        return ErrNoRecipient
+    }
+    logging.Info(ctx, "email", "from", c.From, "to", msg.To, "subject", msg.Subject, "body", msg.Text)
+    return nil
+}
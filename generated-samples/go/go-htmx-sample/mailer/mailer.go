@@ -0,0 +1,27 @@
+// Package mailer sends the app's emails behind the Mailer interface:
+// written to the log by default, or over SMTP when generated with
+// --mailer smtp. Bodies are rendered from templates/ (see Render).
+package mailer
+
+import (
+    "context"
+    "errors"
+)
+
+var ErrNoRecipient = errors.New("mailer: message has no recipient")
+
+// Message is one email, with a plain-text body and an optional HTML one
+// sent as its alternative.
+type Message struct {
+    To      string
+    Subject string
+    Text    string
+    HTML    string
+}
+
+// Mailer delivers messages. Send returns once the message is handed off
+// (to the log or the SMTP server); callers on a request path may want to
+// send from a background job instead.
+type Mailer interface {
+    Send(ctx context.Context, msg Message) error
+}
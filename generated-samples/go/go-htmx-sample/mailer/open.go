@@ -0,0 +1,9 @@
+package mailer
+
+import "myapp/config"
+
+// Open returns the mailer: the log. Generating with --mailer smtp
+// replaces this with an SMTP client.
+func Open(cfg config.Mail) (Mailer, error) {
+    return &Console{From: cfg.From}, nil
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+    "bytes"
+    "embed"
+    "fmt"
+    htmltemplate "html/template"
+    "strings"
+    texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// Subjects of the emails in templates/. Each name has a NAME.txt body
+// and a NAME.html one, rendered inside layout.html.
+var subjects = map[string]string{
+    "verify":  "Confirm your email address",
+    "reset":   "Reset your password",
+    "welcome": "Welcome",
+}
+
+// Render builds the message named name for to, executing both bodies
+// with data.
+func Render(name, to string, data any) (Message, error) {
+    subject, ok := subjects[name]
+    if !ok {
+        return Message{}, fmt.Errorf("mailer: unknown template %q", name)
+    }
+
+    text, err := texttemplate.ParseFS(templateFiles, "templates/"+name+".txt")
+    if err != nil {
+        return Message{}, err
+    }
+    var textBody bytes.Buffer
+    if err := text.Execute(&textBody, data); err != nil {
+        return Message{}, err
+    }
+
+    html, err := htmltemplate.ParseFS(templateFiles, "templates/layout.html", "templates/"+name+".html")
+    if err != nil {
+        return Message{}, err
+    }
+    var htmlBody bytes.Buffer
+    if err := html.ExecuteTemplate(&htmlBody, "layout.html", data); err != nil {
+        return Message{}, err
+    }
+
+    return Message{
+        To:      to,
+        Subject: subject,
+        Text:    strings.TrimSpace(textBody.String()) + "\n",
+        HTML:    htmlBody.String(),
+    }, nil
+}
@@ -1,54 +1,540 @@
 package main
 
 import (
+    "context"
+    "crypto/rand"
+    "embed"
+    "flag"
+    "io/fs"
     "log"
     "net/http"
     "os"
+    "os/signal"
+    "syscall"
+    "time"
     "github.com/go-chi/chi/v5"
     "github.com/go-chi/chi/v5/middleware"
-    "github.com/joho/godotenv"
+    apiv1 "myapp/api/v1"
+    apiv2 "myapp/api/v2"
+    "myapp/admin"
+    "myapp/assets"
+    "myapp/auth"
+    "myapp/cache"
+    "myapp/cleanup"
+    "myapp/clock"
+    "myapp/config"
+    "myapp/events"
+    "myapp/flags"
     "myapp/handlers"
+    "myapp/health"
+    "myapp/i18n"
+    "myapp/jobs"
+    "myapp/lifecycle"
+    "myapp/logging"
+    "myapp/mailer"
+    mw "myapp/middleware"
+    "myapp/models"
+    "myapp/nonce"
+    "myapp/openapi"
+    "myapp/ratelimit"
+    "myapp/realtime"
+    "myapp/reqctx"
+    "myapp/sanitize"
+    "myapp/search"
+    "myapp/seed"
+    "myapp/storage"
+    "myapp/store"
+    "myapp/telemetry"
+    "myapp/views"
 )
 
+// Static assets are compiled into the binary and served by hashed name
+//go:embed static
+var staticFiles embed.FS
+
 const (
     itemDetailRoute = "/items/{id}"
     itemEditRoute   = "/items/{id}/edit"
+    itemFileRoute   = "/items/{id}/download"
+    itemUploadRoute = "/items/{id}/attachment"
 )
 
 func main() {
-    // Load environment variables
-    godotenv.Load()
+    // Settings from .env and the environment, validated up front
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    logging.Setup(cfg.Env, cfg.LogLevel)
+
+    // Traces and metrics (generated with --observability otel; without it
+    // these are no-ops)
+    shutdownTelemetry, err := telemetry.Setup(context.Background(), cfg.Telemetry)
+    if err != nil {
+        log.Fatalf("telemetry: %v", err)
+    }
+
+    // Subpath the app is mounted under behind a reverse proxy (e.g. /app)
+    basePath := views.ParseBasePath(cfg.BasePath)
+    views.UseBasePath(basePath)
+
+    // --seed-fixture (or SEED_FIXTURE=true) seeds the embedded demo dataset
+    seedFixture := flag.Bool("seed-fixture", cfg.SeedFixture, "seed the embedded demo dataset instead of a single sample item")
+    flag.Parse()
+
+    // Clock in the configured default timezone (UTC when APP_TIMEZONE is unset)
+    clk, err := clock.Load(cfg.Timezone)
+    if err != nil {
+        log.Fatalf("APP_TIMEZONE: %v", err)
+    }
+
+    // Readiness checks, run by /readyz; features register theirs here
+    checks := &health.Checks{}
+
+    // Item store (backend chosen at generation time), traced, with slow
+    // operations logged as JSON
+    items, closeStore, err := store.Open(context.Background(), cfg.Database)
+    if err != nil {
+        log.Fatalf("store: %v", err)
+    }
+    if db, ok := items.(store.Pinger); ok {
+        checks.Register("database", db.Ping)
+    }
+    // Hard deletes for the cleanup job, looked up before the store is wrapped
+    purger, _ := items.(store.Purger)
+    items = telemetry.Store(items)
+    var itemStore store.ItemStore = store.NewInstrumentedStore(items, cfg.SlowQueryThreshold, clk)
+
+    // Item reads cached for CACHE_TTL (0 disables it): in process, or in
+    // Redis when generated with --cache redis. Cache hits skip the store.
+    closeCache := func() error { return nil }
+    if cfg.Cache.TTL > 0 {
+        itemCache, err := cache.Open(context.Background(), cfg.Cache)
+        if err != nil {
+            log.Fatalf("cache: %v", err)
+        }
+        itemStore = store.NewCachedStore(itemStore, itemCache, cfg.Cache.TTL)
+        closeCache = itemCache.Close
+        checks.RegisterOptional("cache", itemCache.Ping)
+    }
+    handlers.UseStore(itemStore)
+
+    // Resources added with `create-stack-app generate resource` are wired
+    // in at the stack-app:* markers; keep them in place
+    // stack-app:stores
+
+    // Item change events; features subscribe here instead of hooking handlers
+    bus := events.NewBus(64)
+    if cfg.AuditLog {
+        bus.SubscribeAll(func(ctx context.Context, e events.Event) {
+            logging.Info(ctx, "audit", "event", e.EventName(), "data", e)
+        })
+    }
+    handlers.UseEvents(bus)
+
+    // Item changes pushed to open pages (generated with --realtime)
+    hub := realtime.NewHub(bus, handlers.LiveUpdate)
+
+    // Sanitize user-rendered text (fields default to the strict policy)
+    handlers.UseSanitizer(sanitize.ParseFields(cfg.SanitizeFields))
+
+    // Attachment storage: UPLOAD_DIR, or an S3 bucket when generated with
+    // --storage s3
+    blobs, err := storage.Open(context.Background(), cfg.Storage)
+    if err != nil {
+        log.Fatalf("storage: %v", err)
+    }
+    handlers.UseStorage(blobs, cfg.Storage)
+    views.UseUploadTypes(cfg.Storage.Types)
+    checks.RegisterOptional("uploads", blobs.Ping)
+
+    // Fields shown in the item list (the detail view shows all of them)
+    handlers.UseListColumns(views.ParseListColumns(cfg.ListColumns))
+
+    // Empty search shows all items or none until the user types
+    handlers.UseEmptySearch(search.ParseEmptyQuery(cfg.SearchEmpty))
+
+    // Items per list page (?per_page overrides it, up to 100)
+    handlers.UsePageSize(cfg.PageSize)
+
+    // Error body format: html fragments, {"errors": [...]} or RFC 7807 problem+json
+    handlers.UseErrorFormat(cfg.ErrorFormat)
+
+    // One-time form nonces reject accidental double submits
+    var nonces *nonce.Store
+    if cfg.FormNonce {
+        nonces = nonce.NewStore(cfg.FormNonceTTL, clk)
+        handlers.UseNonces(nonces)
+    }
+
+    // Light/dark theme switch persisted in a cookie
+    handlers.UseThemeToggle(cfg.ThemeToggle)
+
+    // Sign-in (generated with --auth; without it every request is anonymous)
+    authn, err := auth.Open(cfg.Auth, clk)
+    if err != nil {
+        log.Fatalf("auth: %v", err)
+    }
+
+    // Background jobs run by the worker (generated with --jobs; without it
+    // nothing is enqueued). New accounts get a welcome email.
+    queue, err := jobs.Open(context.Background(), cfg.Jobs, cfg.Database)
+    if err != nil {
+        log.Fatalf("jobs: %v", err)
+    }
+    authn.OnRegister(queue.SendWelcomeEmail)
+
+    // Outgoing email: logged, or sent over SMTP when generated with
+    // --mailer smtp. New accounts get a verification link, and the login
+    // page a password reset flow.
+    mail, err := mailer.Open(cfg.Mail)
+    if err != nil {
+        log.Fatalf("mailer: %v", err)
+    }
+    authn.UseMailer(mail)
+
+    // Feature flags from FEATURE_FLAGS, or through the OpenFeature SDK
+    // when generated with --flags openfeature
+    flagProvider, err := flags.Open(cfg.Flags)
+    if err != nil {
+        log.Fatalf("flags: %v", err)
+    }
+
+    // Back office under /admin (generated with --admin; without it no
+    // routes are added)
+    backOffice := admin.New(cfg.Admin, itemStore, bus, authn, clk)
+
+    // Content-hashed asset URLs for cache busting
+    static, err := fs.Sub(staticFiles, "static")
+    if err != nil {
+        log.Fatalf("static assets: %v", err)
+    }
+    manifest, err := assets.Load(static)
+    if err != nil {
+        log.Fatalf("static assets: %v", err)
+    }
+    views.UseAssets(manifest.Lookup)
+
+    // Render static pages once instead of per request. With CSRF on, every
+    // page carries the visitor's token, so nothing is request-independent.
+    if !cfg.Security.CSRF {
+        if err := views.Precompute(cfg.ThemeToggle); err != nil {
+            log.Fatalf("precompute views: %v", err)
+        }
+    }
 
     // Create Chi router
     r := chi.NewRouter()
 
     // Global middleware
-    r.Use(middleware.Logger)
+    r.Use(telemetry.Middleware)
+    r.Use(reqctx.Middleware(clk))
+    r.Use(logging.Middleware(reqctx.RequestID))
+
+    // Reject oversized URLs before routing
+    r.Use(mw.MaxURLLength(cfg.MaxURLLength, cfg.MaxQueryLength))
+
+    // Trailing slashes as chosen by --trailing-slash at generation
+    r.Use(mw.TrailingSlashes(basePath))
+    r.Use(mw.AccessLog(cfg.AccessLogFormat, clk))
     r.Use(middleware.Recoverer)
     r.Use(middleware.SetHeader("Content-Type", "text/html"))
+    r.Use(i18n.Middleware)
+
+    // CSP, X-Frame-Options, nosniff, Referrer-Policy and (with HSTS=true)
+    // Strict-Transport-Security on every response
+    var hsts time.Duration
+    if cfg.Security.HSTS {
+        hsts = cfg.Security.HSTSMaxAge
+    }
+    r.Use(mw.SecureHeaders(mw.SecurityHeaders{
+        CSP:          cfg.Security.CSP,
+        FrameOptions: cfg.Security.FrameOptions,
+        HSTSMaxAge:   hsts,
+    }))
+
+    // CSRF tokens on every form and HTMX request (disable with CSRF=false)
+    if cfg.Security.CSRF {
+        key := []byte(cfg.Security.CSRFKey)
+        if len(key) == 0 {
+            log.Println("CSRF_KEY is not set; using a random key, so open pages stop submitting after a restart")
+            key = make([]byte, 32)
+            if _, err := rand.Read(key); err != nil {
+                log.Fatalf("csrf key: %v", err)
+            }
+        }
+        r.Use(mw.CSRF(key, views.Path("/"), http.HandlerFunc(handlers.CSRFFailure)))
+    }
+    r.Use(authn.Middleware)
+    r.Use(flags.Middleware(flagProvider))
+
+    // Warn about N+1 query patterns in development
+    if cfg.Env == "development" {
+        r.Use(mw.QueryBudget(cfg.QueryCountWarn))
+    }
+
+    // Global concurrency cap (disabled when MAX_IN_FLIGHT is unset or 0)
+    if cfg.MaxInFlight > 0 {
+        r.Use(mw.MaxInFlight(cfg.MaxInFlight, 1))
+    }
+
+    // Rate limiting (disabled when RATE_LIMIT is unset or 0): token
+    // buckets per client and route when generated with --ratelimit,
+    // otherwise a fixed window per client
+    rateLimit, closeRateLimit, err := ratelimit.Open(context.Background(), cfg.RateLimit, cfg.Cache.RedisURL)
+    if err != nil {
+        log.Fatalf("ratelimit: %v", err)
+    }
+    switch {
+    case rateLimit != nil:
+        r.Use(rateLimit)
+    case cfg.RateLimit.Limit > 0:
+        r.Use(mw.RateLimit(mw.NewMemoryLimiter(cfg.RateLimit.Limit, cfg.RateLimit.Window, clk), clk))
+    }
+
+    // Reject requests that don't match openapi/openapi.yaml
+    if cfg.OpenAPIValidate {
+        validate, err := openapi.Validator()
+        if err != nil {
+            log.Fatalf("openapi: %v", err)
+        }
+        r.Use(validate)
+    }
+
+    r.NotFound(handlers.NotFound)
 
     // Static files
-    r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
+    r.Handle("/static/*", http.StripPrefix("/static", manifest.Handler()))
+
+    // Health checks: /healthz is liveness, /readyz opens once startup
+    // completes and then reports every check
+    ready := health.NewGate(checks)
 
-    // Health check
-    r.Get("/health", handlers.HealthCheck)
+    // Downstream services folded into readiness (critical unless ";optional")
+    deps, err := health.ParseDependencies(cfg.ReadyDependencies)
+    if err != nil {
+        log.Fatalf("READY_DEPENDENCIES: %v", err)
+    }
+    var watcher *health.Dependencies
+    if len(deps) > 0 {
+        watcher = health.NewDependencies(deps, cfg.ReadyPollInterval)
+        ready.Watch(watcher)
+    }
+    r.Get("/healthz", health.Live)
+    r.Get("/readyz", ready.Handler)
+    if metrics := telemetry.Metrics(); metrics != nil {
+        r.Handle("/metrics", metrics)
+    }
 
-    // HTMX routes
-    r.Get("/", handlers.HomePage)
-    r.Get("/items", handlers.ListItems)
-    r.Post("/items", handlers.CreateItem)
-    r.Get(itemDetailRoute, handlers.GetItem)
-    r.Put(itemDetailRoute, handlers.UpdateItem)
-    r.Delete(itemDetailRoute, handlers.DeleteItem)
-    r.Get(itemEditRoute, handlers.EditItemForm)
+    // The OpenAPI spec and Swagger UI for it (disable with API_DOCS=false)
+    if cfg.APIDocs {
+        r.Get("/openapi.yaml", openapi.Handler)
+        r.Get("/docs", openapi.Docs)
+        r.Get("/docs/init.js", openapi.DocsScript)
+    }
 
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "3000"
+    // Response caching for item reads (disabled when RESPONSE_CACHE_TTL is unset or 0)
+    var responseCache *mw.ResponseCache
+    if cfg.ResponseCacheTTL > 0 {
+        vary := append([]string{"HX-Request"}, cfg.ResponseCacheVary...)
+        responseCache = mw.NewResponseCache(cfg.ResponseCacheTTL, vary, []string{"session"}, clk)
     }
 
-    log.Println("🚀 Server running on http://localhost:" + port)
-    if err := http.ListenAndServe(":"+port, r); err != nil {
-        log.Panic(err)
+    // Body limits are per route: forms stay small, only uploads may be large
+    formLimit := cfg.FormMaxBody
+    uploadLimit := cfg.UploadMaxBody
+
+    // Login, logout and register pages stay public
+    authn.Routes(r)
+
+    // Language switcher links: keep the chosen locale in the lang cookie
+    r.Get("/locale/{lang}", i18n.SwitchLocale(views.Path("/")))
+
+    // Everything else needs a signed-in user when auth is enabled
+    r.Group(func(r chi.Router) {
+        r.Use(authn.Require)
+
+        // HTMX routes
+        r.Get("/", handlers.HomePage)
+        r.Get("/forms/nonce", handlers.FormNonce)
+        hub.Routes(r)
+        backOffice.Routes(r)
+
+        // Versioned JSON API sharing the item store. Each version owns its
+        // serialization; retire an old one with mw.Deprecated.
+        r.Mount("/v1", apiv1.Routes(itemStore))
+        r.Mount("/v2", apiv2.Routes(itemStore))
+
+        r.Group(func(r chi.Router) {
+            // Deadline for item handlers and their store calls (disabled
+            // when REQUEST_TIMEOUT is 0)
+            if cfg.Server.RequestTimeout > 0 {
+                r.Use(mw.Timeout(cfg.Server.RequestTimeout))
+            }
+            if responseCache != nil {
+                r.Use(responseCache.Handler)
+            }
+
+            r.Group(func(r chi.Router) {
+                r.Use(mw.MaxBodySize(formLimit))
+
+                r.Get("/items", handlers.ListItems)
+                r.Post("/items", handlers.CreateItem)
+                r.Post("/items/bulk-update", handlers.BulkUpdateItems)
+                r.Get("/items/batch", handlers.BatchItemForm)
+                r.Post("/items/batch", handlers.CreateItems)
+                r.Get(itemDetailRoute, handlers.GetItem)
+                r.Put(itemDetailRoute, handlers.UpdateItem)
+                r.Delete(itemDetailRoute, handlers.DeleteItem)
+                r.Get(itemEditRoute, handlers.EditItemForm)
+                r.Get(itemFileRoute, handlers.DownloadItem)
+            })
+
+            r.With(mw.MaxBodySize(uploadLimit)).Post(itemUploadRoute, handlers.UploadAttachment)
+
+            // stack-app:routes
+        })
+    })
+
+    port := cfg.Port
+
+    // Lifecycle hooks (see hooks.go)
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    lc := lifecycle.New()
+    lc.Append(lifecycle.Hook{
+        Name:   "telemetry",
+        OnStop: shutdownTelemetry,
+    })
+    lc.Append(lifecycle.Hook{
+        Name:   "store",
+        OnStop: func(ctx context.Context) error { return closeStore() },
+    })
+    lc.Append(lifecycle.Hook{
+        Name:   "cache",
+        OnStop: func(ctx context.Context) error { return closeCache() },
+    })
+    lc.Append(lifecycle.Hook{
+        Name:   "ratelimit",
+        OnStop: func(ctx context.Context) error { return closeRateLimit() },
+    })
+    lc.Append(lifecycle.Hook{
+        Name: "seed",
+        OnStart: func(ctx context.Context) error {
+            // Persistent stores keep their data across restarts
+            existing, err := items.List(ctx)
+            if err != nil || len(existing) > 0 {
+                return err
+            }
+
+            if !*seedFixture {
+                _, err := items.Create(ctx, models.Item{Title: "Sample Item", Description: "A sample item"})
+                return err
+            }
+
+            fixture, err := seed.Default()
+            if err != nil {
+                return err
+            }
+            for _, rec := range fixture.Items {
+                if _, err := items.Create(ctx, rec.Item()); err != nil {
+                    return err
+                }
+            }
+            log.Printf("🌱 Seeded %d items from fixture", len(fixture.Items))
+            return nil
+        },
+    })
+    registerHooks(lc, blobs)
+    lc.Append(lifecycle.Hook{Name: "events", OnStop: bus.Close})
+    lc.Append(lifecycle.Hook{Name: "jobs", OnStop: func(context.Context) error { return queue.Close() }})
+    if watcher != nil {
+        lc.Append(lifecycle.Hook{Name: "dependencies", OnStart: watcher.Start, OnStop: watcher.Stop})
+    }
+
+    // Periodic purge of stale data (disabled when CLEANUP_INTERVAL is unset;
+    // generated with --cleanup, or the job never runs)
+    if cfg.CleanupInterval > 0 {
+        job := cleanup.NewJob(cfg.CleanupInterval, clk)
+        if purger != nil && cfg.ItemRetention > 0 {
+            job.Add(cleanup.Task{
+                Name: "deleted-items",
+                Run: func(ctx context.Context, now time.Time) (int, error) {
+                    return purger.PurgeDeleted(ctx, now.Add(-cfg.ItemRetention))
+                },
+            })
+        }
+        job.Add(cleanup.Task{
+            Name: "sessions",
+            Run: func(ctx context.Context, now time.Time) (int, error) {
+                return authn.PurgeExpired(now), nil
+            },
+        })
+        if responseCache != nil {
+            job.Add(cleanup.Task{
+                Name: "response-cache",
+                Run: func(ctx context.Context, now time.Time) (int, error) {
+                    return responseCache.PurgeExpired(now), nil
+                },
+            })
+        }
+        if nonces != nil {
+            job.Add(cleanup.Task{
+                Name: "form-nonces",
+                Run: func(ctx context.Context, now time.Time) (int, error) {
+                    return nonces.PurgeExpired(now), nil
+                },
+            })
+        }
+        lc.Append(lifecycle.Hook{Name: "cleanup", OnStart: job.Start, OnStop: job.Stop})
+    }
+
+    // Listen first so probes get "not ready" rather than connection refused
+    // Routes are declared from the root; strip BASE_PATH before routing
+    var handler http.Handler = r
+    if basePath != "" {
+        handler = http.StripPrefix(basePath, r)
+    }
+
+    // Timeouts keep slow or stalled clients from holding connections open
+    srv := &http.Server{
+        Addr:              ":" + port,
+        Handler:           handler,
+        ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+        ReadTimeout:       cfg.Server.ReadTimeout,
+        WriteTimeout:      cfg.Server.WriteTimeout,
+        IdleTimeout:       cfg.Server.IdleTimeout,
+    }
+    // Open event streams never finish on their own
+    srv.RegisterOnShutdown(hub.Close)
+    go func() {
+        log.Println("🚀 Server running on http://localhost:" + port + basePath + "/")
+        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            log.Fatal(err)
+        }
+    }()
+
+    if err := lc.Start(ctx); err != nil {
+        srv.Shutdown(context.Background())
+        log.Fatal(err)
+    }
+    ready.MarkReady()
+
+    <-ctx.Done()
+    stop()
+    log.Println("Shutting down (Ctrl+C again to force)")
+    ready.MarkNotReady()
+
+    // In-flight requests get SHUTDOWN_TIMEOUT to finish; stragglers are
+    // then cut off so the stop hooks can run
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+    defer cancel()
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        log.Printf("shutdown: %v", err)
+        srv.Close()
+    }
+    if err := lc.Stop(context.Background()); err != nil {
+        log.Println(err)
     }
 }
\ No newline at end of file
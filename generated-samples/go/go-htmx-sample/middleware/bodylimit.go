@@ -0,0 +1,46 @@
+package middleware
+
+import (
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+)
+
+// MaxBodySize caps request bodies at limit bytes. Requests that declare a
+// larger Content-Length get 413 straight away; chunked bodies are cut off
+// by http.MaxBytesReader and handlers see *http.MaxBytesError.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if r.ContentLength > limit {
+                http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+                return
+            }
+            r.Body = http.MaxBytesReader(w, r.Body, limit)
+            next.ServeHTTP(w, r)
+        })
+    }
+}
+
+// ParseByteSize reads sizes such as "1048576", "512KB", "1MB" or "2GB".
+// Units are binary multiples.
+func ParseByteSize(s string) (int64, error) {
+    s = strings.ToUpper(strings.TrimSpace(s))
+    multiplier := int64(1)
+    for _, unit := range []struct {
+        suffix string
+        size   int64
+    }{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+        if strings.HasSuffix(s, unit.suffix) {
+            s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+            multiplier = unit.size
+            break
+        }
+    }
+    n, err := strconv.ParseInt(s, 10, 64)
+    if err != nil || n < 0 {
+        return 0, fmt.Errorf("invalid byte size %q", s)
+    }
+    return n * multiplier, nil
+}
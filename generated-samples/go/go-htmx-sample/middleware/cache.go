@@ -0,0 +1,226 @@
+package middleware
+
+import (
+    "bytes"
+    "net/http"
+    "slices"
+    "strings"
+    "sync"
+    "time"
+    "myapp/clock"
+    "myapp/i18n"
+    "myapp/reqctx"
+)
+
+// ResponseCache stores complete GET responses in memory for a fixed TTL.
+// Successful writes (POST/PUT/PATCH/DELETE) invalidate every cached entry
+// under the same top-level path, e.g. a POST /items drops /items/1 too.
+// Responses that set a cookie belong to one browser and are never stored.
+type ResponseCache struct {
+    ttl     time.Duration
+    vary    []string
+    bypass  []string
+    clock   clock.Clock
+
+    mu      sync.RWMutex
+    entries map[string]cachedResponse
+}
+
+// perRequestHeaders describe the response they were sent with, so a cache
+// hit keeps the current request's values instead of replaying them
+var perRequestHeaders = []string{
+    reqctx.RequestIDHeader, "X-Cache",
+    "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After",
+    "Deprecation", "Sunset",
+}
+
+type cachedResponse struct {
+    resource string
+    status   int
+    header   http.Header
+    body     []byte
+    expires  time.Time
+}
+
+// NewResponseCache builds a cache whose keys include the negotiated
+// locale and the values of the vary headers. Requests carrying an
+// Authorization header or any of the bypass cookies are personalized and
+// never cached.
+func NewResponseCache(ttl time.Duration, vary []string, bypassCookies []string, clk clock.Clock) *ResponseCache {
+    return &ResponseCache{
+        ttl:     ttl,
+        vary:    vary,
+        bypass:  bypassCookies,
+        clock:   clk,
+        entries: make(map[string]cachedResponse),
+    }
+}
+
+func (c *ResponseCache) Handler(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodGet && r.Method != http.MethodHead {
+            rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+            next.ServeHTTP(rec, r)
+            if rec.status < 400 {
+                c.Invalidate(resourceOf(r.URL.Path))
+            }
+            return
+        }
+
+        if c.personalized(r) {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        key := c.key(r)
+        if entry, ok := c.get(key); ok {
+            // Headers outer middleware already set belong to this request
+            for k, v := range entry.header {
+                if _, set := w.Header()[k]; !set {
+                    w.Header()[k] = v
+                }
+            }
+            w.Header().Set("X-Cache", "HIT")
+            w.WriteHeader(entry.status)
+            w.Write(entry.body)
+            return
+        }
+
+        rec := &bodyRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+        w.Header().Set("X-Cache", "MISS")
+        outer := w.Header().Clone()
+        next.ServeHTTP(rec, r)
+
+        if rec.status == http.StatusOK && w.Header().Get("Set-Cookie") == "" {
+            header := handlerHeaders(outer, w.Header())
+            for _, h := range perRequestHeaders {
+                header.Del(h)
+            }
+            c.mu.Lock()
+            c.entries[key] = cachedResponse{
+                resource: resourceOf(r.URL.Path),
+                status:   rec.status,
+                header:   header,
+                body:     rec.body.Bytes(),
+                expires:  c.clock.Now().Add(c.ttl),
+            }
+            c.mu.Unlock()
+        }
+    })
+}
+
+// handlerHeaders returns the headers the handler set or changed, leaving
+// out those outer middleware had already set for this request.
+func handlerHeaders(outer, final http.Header) http.Header {
+    header := http.Header{}
+    for k, v := range final {
+        if !slices.Equal(outer[k], v) {
+            header[k] = slices.Clone(v)
+        }
+    }
+    return header
+}
+
+// Invalidate drops every cached response under the given resource root.
+func (c *ResponseCache) Invalidate(resource string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for k, entry := range c.entries {
+        if entry.resource == resource {
+            delete(c.entries, k)
+        }
+    }
+}
+
+// PurgeExpired drops entries whose TTL has passed and returns how many.
+func (c *ResponseCache) PurgeExpired(now time.Time) int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    n := 0
+    for k, entry := range c.entries {
+        if now.After(entry.expires) {
+            delete(c.entries, k)
+            n++
+        }
+    }
+    return n
+}
+
+// get returns the live entry for key, dropping it if it has expired.
+func (c *ResponseCache) get(key string) (cachedResponse, bool) {
+    c.mu.RLock()
+    entry, ok := c.entries[key]
+    c.mu.RUnlock()
+    if !ok {
+        return cachedResponse{}, false
+    }
+    if c.clock.Now().After(entry.expires) {
+        c.mu.Lock()
+        // A fresh entry may have replaced it since the read lock
+        if current, ok := c.entries[key]; ok && current.expires.Equal(entry.expires) {
+            delete(c.entries, key)
+        }
+        c.mu.Unlock()
+        return cachedResponse{}, false
+    }
+    return entry, true
+}
+
+func (c *ResponseCache) key(r *http.Request) string {
+    var b strings.Builder
+    b.WriteString(r.Method)
+    b.WriteByte(' ')
+    b.WriteString(r.URL.Path)
+    b.WriteByte('?')
+    b.WriteString(r.URL.Query().Encode())
+    // Pages are rendered in the locale i18n.Middleware negotiated from the
+    // lang cookie and Accept-Language, so each locale is cached apart
+    b.WriteByte('|')
+    b.WriteString(i18n.Locale(r.Context()).String())
+    for _, h := range c.vary {
+        b.WriteByte('|')
+        b.WriteString(r.Header.Get(h))
+    }
+    return b.String()
+}
+
+func (c *ResponseCache) personalized(r *http.Request) bool {
+    if r.Header.Get("Authorization") != "" {
+        return true
+    }
+    for _, name := range c.bypass {
+        if _, err := r.Cookie(name); err == nil {
+            return true
+        }
+    }
+    return false
+}
+
+// resourceOf returns the first path segment: "/items/3/edit" -> "/items".
+func resourceOf(path string) string {
+    trimmed := strings.TrimPrefix(path, "/")
+    if i := strings.IndexByte(trimmed, '/'); i >= 0 {
+        trimmed = trimmed[:i]
+    }
+    return "/" + trimmed
+}
+
+type statusRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+    r.status = status
+    r.ResponseWriter.WriteHeader(status)
+}
+
+type bodyRecorder struct {
+    statusRecorder
+    body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(p []byte) (int, error) {
+    r.body.Write(p)
+    return r.ResponseWriter.Write(p)
+}
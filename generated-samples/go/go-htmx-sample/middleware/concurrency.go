@@ -0,0 +1,28 @@
+package middleware
+
+import (
+    "net/http"
+    "strconv"
+)
+
+// MaxInFlight caps the number of requests being served at once across all
+// clients. Unlike RateLimit it does not track who is calling: it only
+// protects the backend from doing more work than it can handle. Requests
+// arriving while the limit is reached get 503 with a Retry-After hint
+// instead of queueing behind slow ones.
+func MaxInFlight(limit int, retryAfterSeconds int) func(http.Handler) http.Handler {
+    sem := make(chan struct{}, limit)
+
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            select {
+            case sem <- struct{}{}:
+                defer func() { <-sem }()
+                next.ServeHTTP(w, r)
+            default:
+                w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+                http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+            }
+        })
+    }
+}
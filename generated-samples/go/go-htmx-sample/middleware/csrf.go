@@ -0,0 +1,56 @@
+package middleware
+
+import (
+    "net/http"
+    "github.com/gorilla/csrf"
+    "myapp/reqctx"
+)
+
+// CSRF rejects POST, PUT, PATCH and DELETE requests that don't carry the
+// token tied to the browser's "csrf" cookie (gorilla/csrf). Plain forms
+// send it as the _csrf field (views.CSRFField); HTMX requests send the
+// X-CSRF-Token header, set on <body> by the layout. Every request gets its
+// token in the context (reqctx.CSRFToken) for the views. Failures go to
+// onError; csrf.FailureReason(r) says why.
+//
+// The cookie is Secure on HTTPS requests (directly or behind a proxy that
+// sets X-Forwarded-Proto), matching the session cookie. Requests with an
+// Authorization header skip the check: browsers never add one on their own,
+// so they can't be forged cross-site.
+func CSRF(key []byte, cookiePath string, onError http.Handler) func(http.Handler) http.Handler {
+    options := func(secure bool) []csrf.Option {
+        return []csrf.Option{
+            csrf.CookieName("csrf"),
+            csrf.FieldName("_csrf"),
+            csrf.Path(cookiePath),
+            csrf.Secure(secure),
+            csrf.SameSite(csrf.SameSiteLaxMode),
+            csrf.ErrorHandler(onError),
+        }
+    }
+    overHTTPS := csrf.Protect(key, options(true)...)
+    overHTTP := csrf.Protect(key, options(false)...)
+
+    return func(next http.Handler) http.Handler {
+        withToken := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            next.ServeHTTP(w, r.WithContext(reqctx.WithCSRFToken(r.Context(), csrf.Token(r))))
+        })
+        secure, plain := overHTTPS(withToken), overHTTP(withToken)
+
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if r.Header.Get("Authorization") != "" {
+                r = csrf.UnsafeSkipCheck(r)
+            }
+            if isHTTPS(r) {
+                secure.ServeHTTP(w, r)
+                return
+            }
+            // gorilla/csrf assumes HTTPS when checking Origin/Referer
+            plain.ServeHTTP(w, csrf.PlaintextHTTPRequest(r))
+        })
+    }
+}
+
+func isHTTPS(r *http.Request) bool {
+    return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "os"
+    "strings"
+    "time"
+    chimw "github.com/go-chi/chi/v5/middleware"
+    "myapp/clock"
+    "myapp/reqctx"
+)
+
+// Access log formats selectable via ACCESS_LOG_FORMAT (or --log)
+const (
+    LogFormatText     = "text"
+    LogFormatJSON     = "json"
+    LogFormatCLF      = "clf"
+    LogFormatCombined = "combined"
+)
+
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// StatusClientClosedRequest is the nginx-style status logged when the client
+// went away before the response was complete. It is never sent on the wire.
+const StatusClientClosedRequest = 499
+
+type accessEntry struct {
+    RequestID  string
+    RemoteAddr string
+    User       string
+    Time       time.Time
+    Method     string
+    URI        string
+    Proto      string
+    Status     int
+    Bytes      int
+    Referer    string
+    UserAgent  string
+    Duration   time.Duration
+}
+
+// AccessLog returns a request logger writing one line per request to stdout
+// in the given format, timed by clk. Unknown formats fall back to text.
+func AccessLog(format string, clk clock.Clock) func(http.Handler) http.Handler {
+    return AccessLogTo(os.Stdout, format, clk)
+}
+
+func AccessLogTo(out io.Writer, format string, clk clock.Clock) func(http.Handler) http.Handler {
+    write := formatter(format)
+
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            start := clk.Now()
+            ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+            next.ServeHTTP(ww, r)
+
+            status := statusOrOK(ww.Status())
+            if errors.Is(r.Context().Err(), context.Canceled) {
+                status = StatusClientClosedRequest
+            }
+
+            user := "-"
+            if u, _, ok := r.BasicAuth(); ok && u != "" {
+                user = u
+            }
+
+            write(out, accessEntry{
+                RequestID:  reqctx.RequestID(r.Context()),
+                RemoteAddr: remoteHost(r),
+                User:       user,
+                Time:       start,
+                Method:     r.Method,
+                URI:        r.RequestURI,
+                Proto:      r.Proto,
+                Status:     status,
+                Bytes:      ww.BytesWritten(),
+                Referer:    r.Referer(),
+                UserAgent:  r.UserAgent(),
+                Duration:   clk.Now().Sub(start),
+            })
+        })
+    }
+}
+
+func formatter(format string) func(io.Writer, accessEntry) {
+    switch strings.ToLower(format) {
+    case LogFormatJSON:
+        return writeJSON
+    case LogFormatCLF:
+        return writeCLF
+    case LogFormatCombined:
+        return writeCombined
+    default:
+        return writeText
+    }
+}
+
+func writeText(out io.Writer, e accessEntry) {
+    note := ""
+    if e.Status == StatusClientClosedRequest {
+        note = " (client disconnected)"
+    }
+    fmt.Fprintf(out, "%s %s %s %d %dB %s%s\n",
+        e.Time.Format(time.RFC3339), e.Method, e.URI, e.Status, e.Bytes, e.Duration, note)
+}
+
+func writeJSON(out io.Writer, e accessEntry) {
+    level := "info"
+    switch {
+    case e.Status == StatusClientClosedRequest:
+        level = "debug"
+    case e.Status >= 500:
+        level = "error"
+    }
+
+    json.NewEncoder(out).Encode(map[string]any{
+        "level":       level,
+        "time":        e.Time.Format(time.RFC3339Nano),
+        "request_id":  e.RequestID,
+        "remote_addr": e.RemoteAddr,
+        "method":      e.Method,
+        "uri":         e.URI,
+        "proto":       e.Proto,
+        "status":      e.Status,
+        "bytes":       e.Bytes,
+        "referer":     e.Referer,
+        "user_agent":  e.UserAgent,
+        "duration_ms": float64(e.Duration.Microseconds()) / 1000,
+    })
+}
+
+// writeCLF emits the Common Log Format:
+// host ident authuser [date] "request" status bytes
+func writeCLF(out io.Writer, e accessEntry) {
+    fmt.Fprintln(out, clfLine(e))
+}
+
+// writeCombined emits the Apache Combined Log Format, which is CLF
+// followed by the quoted referer and user agent.
+func writeCombined(out io.Writer, e accessEntry) {
+    fmt.Fprintf(out, "%s %s %s\n", clfLine(e), quoteOrDash(e.Referer), quoteOrDash(e.UserAgent))
+}
+
+func clfLine(e accessEntry) string {
+    size := "-"
+    if e.Bytes > 0 {
+        size = fmt.Sprintf("%d", e.Bytes)
+    }
+    return fmt.Sprintf(`%s - %s [%s] "%s %s %s" %d %s`,
+        e.RemoteAddr, e.User, e.Time.Format(clfTimeLayout), e.Method, e.URI, e.Proto, e.Status, size)
+}
+
+func quoteOrDash(s string) string {
+    if s == "" {
+        return `"-"`
+    }
+    return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func remoteHost(r *http.Request) string {
+    host, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return host
+}
+
+func statusOrOK(status int) int {
+    if status == 0 {
+        return http.StatusOK
+    }
+    return status
+}
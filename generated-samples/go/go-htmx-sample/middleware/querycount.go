@@ -0,0 +1,34 @@
+package middleware
+
+import (
+    "context"
+    "net/http"
+    "github.com/go-chi/chi/v5"
+    "myapp/logging"
+    "myapp/reqctx"
+)
+
+// QueryBudget counts store operations per request and logs a warning when
+// a single request issues more than threshold of them, which usually means
+// an N+1 query pattern. Meant for development.
+func QueryBudget(threshold int64) func(http.Handler) http.Handler {
+    return queryBudget(threshold, logging.Warn)
+}
+
+// queryBudget reports through warn, which tests replace.
+func queryBudget(threshold int64, warn func(ctx context.Context, msg string, args ...any)) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            ctx, queries := reqctx.WithQueryCounter(r.Context())
+            next.ServeHTTP(w, r.WithContext(ctx))
+
+            if n := queries.Load(); n > threshold {
+                route := r.URL.Path
+                if rc := chi.RouteContext(ctx); rc != nil && rc.RoutePattern() != "" {
+                    route = rc.RoutePattern()
+                }
+                warn(ctx, "too many store queries", "route", route, "queries", n, "threshold", threshold)
+            }
+        })
+    }
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+    "myapp/clock"
+)
+
+// Quota describes a client's standing in the current rate-limit window.
+type Quota struct {
+    Limit     int
+    Remaining int
+    Reset     time.Time
+    Allowed   bool
+}
+
+// Limiter decides whether a request identified by key may proceed.
+// Implementations must be safe for concurrent use.
+type Limiter interface {
+    Take(key string) Quota
+}
+
+// MemoryLimiter is a fixed-window limiter kept in process memory.
+type MemoryLimiter struct {
+    limit  int
+    window time.Duration
+    clock  clock.Clock
+
+    mu      sync.Mutex
+    windows map[string]*rateWindow
+    sweep   time.Time
+}
+
+type rateWindow struct {
+    count int
+    reset time.Time
+}
+
+func NewMemoryLimiter(limit int, window time.Duration, clk clock.Clock) *MemoryLimiter {
+    return &MemoryLimiter{
+        limit:   limit,
+        window:  window,
+        clock:   clk,
+        windows: make(map[string]*rateWindow),
+    }
+}
+
+func (l *MemoryLimiter) Take(key string) Quota {
+    now := l.clock.Now()
+
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    if now.After(l.sweep) {
+        for k, w := range l.windows {
+            if now.After(w.reset) {
+                delete(l.windows, k)
+            }
+        }
+        l.sweep = now.Add(l.window)
+    }
+
+    w, ok := l.windows[key]
+    if !ok || now.After(w.reset) {
+        w = &rateWindow{reset: now.Add(l.window)}
+        l.windows[key] = w
+    }
+
+    allowed := w.count < l.limit
+    if allowed {
+        w.count++
+    }
+
+    return Quota{
+        Limit:     l.limit,
+        Remaining: max(l.limit-w.count, 0),
+        Reset:     w.reset,
+        Allowed:   allowed,
+    }
+}
+
+// RateLimit advertises the client's quota on every response through the
+// X-RateLimit-* headers so well-behaved clients can throttle themselves,
+// and answers 429 with Retry-After once the quota is spent.
+func RateLimit(l Limiter, clk clock.Clock) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            q := l.Take(remoteHost(r))
+
+            h := w.Header()
+            h.Set("X-RateLimit-Limit", strconv.Itoa(q.Limit))
+            h.Set("X-RateLimit-Remaining", strconv.Itoa(q.Remaining))
+            h.Set("X-RateLimit-Reset", strconv.FormatInt(q.Reset.Unix(), 10))
+
+            if !q.Allowed {
+                retry := int(q.Reset.Sub(clk.Now()).Seconds()) + 1
+                h.Set("Retry-After", strconv.Itoa(retry))
+                http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+                return
+            }
+
+            next.ServeHTTP(w, r)
+        })
+    }
+}
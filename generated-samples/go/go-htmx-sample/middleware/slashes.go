@@ -0,0 +1,13 @@
+package middleware
+
+import (
+    "net/http"
+    "github.com/go-chi/chi/v5/middleware"
+)
+
+// TrailingSlashes is the trailing-slash handling picked at generation
+// (--trailing-slash strip): /items/ is routed as /items. prefix is the
+// app's base path, which stripping doesn't need.
+func TrailingSlashes(prefix string) func(http.Handler) http.Handler {
+    return middleware.StripSlashes
+}
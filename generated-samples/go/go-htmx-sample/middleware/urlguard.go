@@ -0,0 +1,17 @@
+package middleware
+
+import "net/http"
+
+// MaxURLLength rejects requests whose request URI or raw query string is
+// longer than the given limits with 414. A limit of 0 disables that check.
+func MaxURLLength(maxURI, maxQuery int) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if (maxURI > 0 && len(r.RequestURI) > maxURI) || (maxQuery > 0 && len(r.URL.RawQuery) > maxQuery) {
+                http.Error(w, "URI Too Long", http.StatusRequestURITooLong)
+                return
+            }
+            next.ServeHTTP(w, r)
+        })
+    }
+}
@@ -1,12 +1,53 @@
 package models
 
+import (
+    "errors"
+    "strings"
+    "time"
+)
+
+var ErrTitleRequired = errors.New("title is required")
+
 type Item struct {
     ID          string
     Title       string
     Description string
+    OwnerID     string
+    Attachment  *Attachment
+
+    // Set by the store: CreatedAt once, UpdatedAt on every write. Delete
+    // only stamps DeletedAt, and deleted items drop out of every read
+    // except Find with IncludeDeleted.
+    CreatedAt time.Time
+    UpdatedAt time.Time
+    DeletedAt *time.Time
+
+    // Version counts writes, starting at 1. An update carries the version
+    // it was read at and is refused if another write got there first.
+    Version int
+}
+
+// Deleted reports whether the item has been soft-deleted.
+func (i Item) Deleted() bool {
+    return i.DeletedAt != nil
+}
+
+// Validate checks the fields every stored item must satisfy.
+func (i Item) Validate() error {
+    if strings.TrimSpace(i.Title) == "" {
+        return ErrTitleRequired
+    }
+    return nil
+}
+
+// Attachment is a file stored on disk alongside an item.
+type Attachment struct {
+    Path        string
+    Filename    string
+    ContentType string
 }
 
 type ItemRequest struct {
     Title       string
     Description string
-}
\ No newline at end of file
+}
@@ -0,0 +1,95 @@
+package nonce
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
+    "sync"
+    "time"
+    "myapp/clock"
+)
+
+var (
+    // ErrReplayed means the nonce was already used by an earlier submission.
+    ErrReplayed = errors.New("nonce already used")
+    // ErrUnknown means the nonce is missing, expired or was never issued.
+    ErrUnknown = errors.New("nonce unknown or expired")
+)
+
+// Store issues one-time form nonces and remembers them for ttl. Used
+// nonces are kept until they expire so a replay can be told apart from a
+// stale form.
+type Store struct {
+    ttl   time.Duration
+    clock clock.Clock
+
+    mu      sync.Mutex
+    entries map[string]*entry
+    sweep   time.Time
+}
+
+type entry struct {
+    expires time.Time
+    used    bool
+}
+
+func NewStore(ttl time.Duration, clk clock.Clock) *Store {
+    return &Store{ttl: ttl, clock: clk, entries: make(map[string]*entry)}
+}
+
+// Issue returns a fresh nonce to embed in a form.
+func (s *Store) Issue() (string, error) {
+    buf := make([]byte, 16)
+    if _, err := rand.Read(buf); err != nil {
+        return "", err
+    }
+    n := hex.EncodeToString(buf)
+    now := s.clock.Now()
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if now.After(s.sweep) {
+        s.purge(now)
+        s.sweep = now.Add(s.ttl)
+    }
+    s.entries[n] = &entry{expires: now.Add(s.ttl)}
+    return n, nil
+}
+
+// Consume marks n as used. Only the first call for an issued, unexpired
+// nonce succeeds.
+func (s *Store) Consume(n string) error {
+    now := s.clock.Now()
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    e, ok := s.entries[n]
+    switch {
+    case !ok || now.After(e.expires):
+        return ErrUnknown
+    case e.used:
+        return ErrReplayed
+    }
+    e.used = true
+    return nil
+}
+
+// PurgeExpired drops expired nonces and reports how many were removed.
+func (s *Store) PurgeExpired(now time.Time) int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.purge(now)
+}
+
+func (s *Store) purge(now time.Time) int {
+    n := 0
+    for k, e := range s.entries {
+        if now.After(e.expires) {
+            delete(s.entries, k)
+            n++
+        }
+    }
+    return n
+}
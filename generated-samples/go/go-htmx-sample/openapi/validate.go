@@ -0,0 +1,74 @@
+package openapi
+
+import (
+    _ "embed"
+    "context"
+    "errors"
+    "net/http"
+    "github.com/getkin/kin-openapi/openapi3"
+    "github.com/getkin/kin-openapi/openapi3filter"
+    "github.com/getkin/kin-openapi/routers"
+    "github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Spec returns the raw OpenAPI document describing the app's routes.
+func Spec() []byte {
+    return spec
+}
+
+// Handler serves the OpenAPI document.
+func Handler(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/yaml")
+    w.Write(spec)
+}
+
+// Validator rejects requests that do not conform to the embedded spec with
+// 400 before they reach a handler. Paths the spec does not describe (static
+// assets, the home page) pass through untouched.
+func Validator() (func(http.Handler) http.Handler, error) {
+    loader := openapi3.NewLoader()
+    doc, err := loader.LoadFromData(spec)
+    if err != nil {
+        return nil, err
+    }
+    if err := doc.Validate(context.Background()); err != nil {
+        return nil, err
+    }
+
+    router, err := gorillamux.NewRouter(doc)
+    if err != nil {
+        return nil, err
+    }
+
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            route, params, err := router.FindRoute(r)
+            if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+                next.ServeHTTP(w, r)
+                return
+            }
+            if err != nil {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+
+            input := &openapi3filter.RequestValidationInput{
+                Request:    r,
+                PathParams: params,
+                Route:      route,
+                Options: &openapi3filter.Options{
+                    AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+                },
+            }
+            if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+                http.Error(w, err.Error(), http.StatusBadRequest)
+                return
+            }
+
+            next.ServeHTTP(w, r)
+        })
+    }, nil
+}
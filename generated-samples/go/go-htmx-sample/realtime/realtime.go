@@ -0,0 +1,28 @@
+package realtime
+
+import (
+    "context"
+    "github.com/a-h/templ"
+    "github.com/go-chi/chi/v5"
+    "myapp/events"
+)
+
+// Renderer turns an event into the fragment pushed to a page, rendered
+// with that page's request context (locale, CSRF token). A nil component
+// means the page has nothing to update.
+type Renderer func(ctx context.Context, e events.Event) templ.Component
+
+// Hub pushes item changes to open pages. This project was generated
+// without --realtime, so nothing is pushed; generate with --realtime
+// sse|websocket to get one.
+type Hub struct{}
+
+func NewHub(bus *events.Bus, render Renderer) *Hub {
+    return &Hub{}
+}
+
+// Routes mounts the /events stream.
+func (h *Hub) Routes(r chi.Router) {}
+
+// Close ends every open stream so the server can shut down.
+func (h *Hub) Close() {}
@@ -0,0 +1,112 @@
+package reqctx
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "net/http"
+    "sync/atomic"
+    "time"
+    "myapp/clock"
+)
+
+// Each value gets its own unexported key type so keys can never collide
+// with another package's context values.
+type (
+    requestIDKey struct{}
+    userKey      struct{}
+    tenantKey    struct{}
+    startKey     struct{}
+    queriesKey   struct{}
+    csrfKey      struct{}
+)
+
+const RequestIDHeader = "X-Request-Id"
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+    return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID, or "" outside a request.
+func RequestID(ctx context.Context) string {
+    id, _ := ctx.Value(requestIDKey{}).(string)
+    return id
+}
+
+func WithUser(ctx context.Context, user string) context.Context {
+    return context.WithValue(ctx, userKey{}, user)
+}
+
+// User returns the authenticated user's ID, or "" for anonymous requests.
+func User(ctx context.Context) string {
+    user, _ := ctx.Value(userKey{}).(string)
+    return user
+}
+
+func WithTenant(ctx context.Context, tenant string) context.Context {
+    return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant returns the tenant the request belongs to, or "".
+func Tenant(ctx context.Context) string {
+    tenant, _ := ctx.Value(tenantKey{}).(string)
+    return tenant
+}
+
+func WithStartTime(ctx context.Context, t time.Time) context.Context {
+    return context.WithValue(ctx, startKey{}, t)
+}
+
+// StartTime returns when the request started, or the zero time.
+func StartTime(ctx context.Context) time.Time {
+    t, _ := ctx.Value(startKey{}).(time.Time)
+    return t
+}
+
+func WithCSRFToken(ctx context.Context, token string) context.Context {
+    return context.WithValue(ctx, csrfKey{}, token)
+}
+
+// CSRFToken returns the token forms must send back, or "" when CSRF
+// protection is off.
+func CSRFToken(ctx context.Context) string {
+    token, _ := ctx.Value(csrfKey{}).(string)
+    return token
+}
+
+// WithQueryCounter attaches a fresh store-operation counter to ctx.
+func WithQueryCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+    n := new(atomic.Int64)
+    return context.WithValue(ctx, queriesKey{}, n), n
+}
+
+// CountQuery increments the request's query counter, if it has one.
+func CountQuery(ctx context.Context) {
+    if n, ok := ctx.Value(queriesKey{}).(*atomic.Int64); ok {
+        n.Add(1)
+    }
+}
+
+// Middleware seeds every request with its start time and a request ID,
+// reusing an incoming X-Request-Id and echoing it on the response.
+func Middleware(clk clock.Clock) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            id := r.Header.Get(RequestIDHeader)
+            if id == "" {
+                id = newID()
+            }
+            w.Header().Set(RequestIDHeader, id)
+
+            ctx := WithRequestID(r.Context(), id)
+            ctx = WithStartTime(ctx, clk.Now())
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
+
+func newID() string {
+    b := make([]byte, 8)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
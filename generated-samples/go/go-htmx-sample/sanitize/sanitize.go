@@ -0,0 +1,78 @@
+package sanitize
+
+import (
+    "html"
+    "strings"
+    "github.com/microcosm-cc/bluemonday"
+)
+
+// Policy names accepted in SANITIZE_FIELDS
+const (
+    PolicyStrict = "strict"
+    PolicyBasic  = "basic"
+    PolicyUGC    = "ugc"
+)
+
+// bluemonday policies are safe for concurrent use once built
+var policies = map[string]*bluemonday.Policy{
+    PolicyStrict: bluemonday.StrictPolicy(),
+    PolicyBasic:  basicPolicy(),
+    PolicyUGC:    bluemonday.UGCPolicy(),
+}
+
+// basicPolicy keeps inline formatting and links but nothing structural.
+func basicPolicy() *bluemonday.Policy {
+    p := bluemonday.NewPolicy()
+    p.AllowElements("b", "strong", "i", "em", "u", "p", "br", "ul", "ol", "li", "code")
+    p.AllowAttrs("href").OnElements("a")
+    p.AllowStandardURLs()
+    p.RequireNoFollowOnLinks(true)
+    return p
+}
+
+// Fields maps a form field name to the policy applied to it. Fields without
+// an entry use the strict policy, which strips all markup.
+type Fields map[string]string
+
+// ParseFields reads a spec such as "description=basic,bio=ugc".
+// Entries naming an unknown policy are ignored.
+func ParseFields(spec string) Fields {
+    fields := Fields{}
+    for _, pair := range strings.Split(spec, ",") {
+        name, policy, ok := strings.Cut(strings.TrimSpace(pair), "=")
+        if !ok {
+            continue
+        }
+        policy = strings.ToLower(strings.TrimSpace(policy))
+        if _, known := policies[policy]; known {
+            fields[strings.TrimSpace(name)] = policy
+        }
+    }
+    return fields
+}
+
+// Clean sanitizes value using the policy configured for field. Only what
+// is stored changes: views render every field as escaped text, so the
+// markup basic and ugc keep is shown literally, not as HTML.
+func (f Fields) Clean(field, value string) string {
+    p, ok := policies[f[field]]
+    if !ok || p == policies[PolicyStrict] {
+        return plainText(value)
+    }
+    return p.Sanitize(value)
+}
+
+// plainText strips all markup and leaves the text unescaped, since templ
+// escapes it on render. Unescaping can turn encoded markup such as
+// "&lt;img&gt;" into real tags, so strip again until nothing changes;
+// every pass that changes the value shortens it.
+func plainText(value string) string {
+    strict := policies[PolicyStrict]
+    for {
+        next := html.UnescapeString(strict.Sanitize(value))
+        if next == value {
+            return value
+        }
+        value = next
+    }
+}
@@ -0,0 +1,18 @@
+package search
+
+// EmptyQuery decides what an empty search returns. Matching itself is up
+// to the store (store.ListQuery.Search).
+type EmptyQuery string
+
+const (
+    EmptyAll  EmptyQuery = "all"
+    EmptyNone EmptyQuery = "none"
+)
+
+// ParseEmptyQuery accepts "all" or "none", defaulting to all.
+func ParseEmptyQuery(s string) EmptyQuery {
+    if EmptyQuery(s) == EmptyNone {
+        return EmptyNone
+    }
+    return EmptyAll
+}
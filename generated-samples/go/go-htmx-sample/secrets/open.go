@@ -0,0 +1,17 @@
+package secrets
+
+import (
+    "context"
+    "errors"
+)
+
+// Open returns the secret store at location, or nil for none. Without
+// --secrets there is no store client, so every setting comes from the
+// environment and .env, and a SECRETS_PATH is an error rather than being
+// silently ignored.
+func Open(ctx context.Context, location string) (Source, error) {
+    if location != "" {
+        return nil, errors.New("SECRETS_PATH is set, but the app was generated without --secrets")
+    }
+    return nil, nil
+}
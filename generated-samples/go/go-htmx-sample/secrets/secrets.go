@@ -0,0 +1,43 @@
+// Package secrets loads credentials (the database password, session and
+// CSRF keys, OAuth client secrets) from a secret store at startup. Each
+// secret is named after the environment variable it sets, so config reads
+// it like any other setting: from Vault, AWS Secrets Manager or a SOPS
+// file when generated with --secrets, and from .env otherwise.
+package secrets
+
+import (
+    "context"
+    "fmt"
+    "os"
+)
+
+// Source is a secret store. Fetch returns its secrets keyed by
+// environment variable name.
+type Source interface {
+    Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// Load fetches the secrets at location (SECRETS_PATH) and sets them in
+// the environment, replacing any value .env gave them. Without a location,
+// as in local development, the environment and .env are left as they are.
+// config.Load calls it before reading any setting.
+func Load(ctx context.Context, location string) error {
+    src, err := Open(ctx, location)
+    if err != nil {
+        return fmt.Errorf("secrets: %w", err)
+    }
+    if src == nil {
+        return nil
+    }
+
+    values, err := src.Fetch(ctx)
+    if err != nil {
+        return fmt.Errorf("secrets: fetch %s: %w", location, err)
+    }
+    for name, value := range values {
+        if err := os.Setenv(name, value); err != nil {
+            return fmt.Errorf("secrets: set %s: %w", name, err)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,49 @@
+package seed
+
+import (
+    "bytes"
+    _ "embed"
+    "encoding/json"
+    "fmt"
+    "myapp/models"
+)
+
+//go:embed items.json
+var defaultFixture []byte
+
+// Fixture is the seed dataset, keyed by resource.
+type Fixture struct {
+    Items []ItemRecord `json:"items"`
+}
+
+type ItemRecord struct {
+    Title       string `json:"title"`
+    Description string `json:"description"`
+}
+
+// Default returns the embedded fixture.
+func Default() (Fixture, error) {
+    return Parse(defaultFixture)
+}
+
+// Parse decodes a fixture and validates every record against the model.
+// Unknown fields are rejected so typos don't silently drop data.
+func Parse(data []byte) (Fixture, error) {
+    var f Fixture
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.DisallowUnknownFields()
+    if err := dec.Decode(&f); err != nil {
+        return Fixture{}, fmt.Errorf("seed fixture: %w", err)
+    }
+
+    for i, rec := range f.Items {
+        if err := rec.Item().Validate(); err != nil {
+            return Fixture{}, fmt.Errorf("seed fixture: items[%d]: %w", i, err)
+        }
+    }
+    return f, nil
+}
+
+func (r ItemRecord) Item() models.Item {
+    return models.Item{Title: r.Title, Description: r.Description}
+}
@@ -0,0 +1,10 @@
+// Light/dark toggle for the button rendered by the layout (THEME_TOGGLE).
+// The choice is kept in a cookie so the server renders the right class.
+(function () {
+    var cookiePath = document.currentScript.dataset.path;
+    document.querySelector(".theme-toggle").addEventListener("click", function () {
+        var next = document.documentElement.classList.contains("dark") ? "light" : "dark";
+        document.documentElement.className = next;
+        document.cookie = "theme=" + next + "; path=" + cookiePath + "; max-age=31536000; samesite=lax";
+    });
+})();
@@ -0,0 +1,27 @@
+// Upload progress for forms with a <progress> bar (item attachments).
+// htmx reports the request body being sent as htmx:xhr:progress events.
+(function () {
+    // The event fires on the element that made the request: the form
+    function bar(evt) {
+        return evt.target.querySelector("progress");
+    }
+    document.addEventListener("htmx:xhr:loadstart", function (evt) {
+        var progress = bar(evt);
+        if (progress) {
+            progress.value = 0;
+            progress.hidden = false;
+        }
+    });
+    document.addEventListener("htmx:xhr:progress", function (evt) {
+        var progress = bar(evt);
+        if (progress && evt.detail.lengthComputable) {
+            progress.value = evt.detail.loaded / evt.detail.total * 100;
+        }
+    });
+    document.addEventListener("htmx:xhr:loadend", function (evt) {
+        var progress = bar(evt);
+        if (progress) {
+            progress.hidden = true;
+        }
+    });
+})();
@@ -0,0 +1,84 @@
+package storage
+
+import (
+    "context"
+    "errors"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// Local keeps blobs as files in Dir.
+type Local struct {
+    Dir string
+}
+
+// path confines key to Dir
+func (l *Local) path(key string) string {
+    return filepath.Join(l.Dir, filepath.Base(key))
+}
+
+// Put writes to a temporary file first, so a failed upload never leaves
+// a partial blob behind.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+    if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+        return err
+    }
+    tmp, err := os.CreateTemp(l.Dir, ".upload-*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+
+    if _, err := io.Copy(tmp, r); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), l.path(key))
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, time.Time, error) {
+    f, err := os.Open(l.path(key))
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil, time.Time{}, ErrNotFound
+    }
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    info, err := f.Stat()
+    if err != nil {
+        f.Close()
+        return nil, time.Time{}, err
+    }
+    return f, info.ModTime(), nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+    err := os.Remove(l.path(key))
+    if errors.Is(err, fs.ErrNotExist) {
+        return nil
+    }
+    return err
+}
+
+// URL is always "": local blobs are streamed by the download handler.
+func (l *Local) URL(ctx context.Context, key, disposition string) (string, error) {
+    return "", nil
+}
+
+func (l *Local) Ping(ctx context.Context) error {
+    if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+        return err
+    }
+    f, err := os.CreateTemp(l.Dir, ".ping-*")
+    if err != nil {
+        return err
+    }
+    f.Close()
+    return os.Remove(f.Name())
+}
@@ -0,0 +1,12 @@
+package storage
+
+import (
+    "context"
+    "myapp/config"
+)
+
+// Open returns the attachment storage: files in cfg.Dir. Generating with
+// --storage s3 replaces this with an S3 bucket.
+func Open(ctx context.Context, cfg config.Storage) (Blob, error) {
+    return &Local{Dir: cfg.Dir}, nil
+}
@@ -0,0 +1,43 @@
+// Package storage keeps uploaded files (item attachments) behind the Blob
+// interface: on local disk by default, or in S3 when generated with
+// --storage s3.
+package storage
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
+    "io"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+var ErrNotFound = errors.New("storage: blob not found")
+
+// Blob stores files under keys chosen by the caller (see NewKey).
+type Blob interface {
+    // Put stores r under key, replacing any blob already there.
+    Put(ctx context.Context, key string, r io.Reader, contentType string) error
+    // Open reads a blob back, with its modification time. The reader is
+    // an io.ReadSeeker when the backend supports ranges.
+    Open(ctx context.Context, key string) (io.ReadCloser, time.Time, error)
+    Delete(ctx context.Context, key string) error
+    // URL returns a short-lived link the browser can fetch the blob from
+    // directly, served with the given Content-Disposition, or "" when
+    // blobs can only be streamed through the app.
+    URL(ctx context.Context, key, disposition string) (string, error)
+    // Ping checks the storage can be written to, for /readyz.
+    Ping(ctx context.Context) error
+}
+
+// NewKey returns a random key ending in filename's extension, so
+// client-supplied names never reach the filesystem or bucket.
+func NewKey(filename string) (string, error) {
+    buf := make([]byte, 16)
+    if _, err := rand.Read(buf); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(buf) + strings.ToLower(filepath.Ext(filepath.Base(filename))), nil
+}
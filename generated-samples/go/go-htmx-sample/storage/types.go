@@ -0,0 +1,34 @@
+package storage
+
+import (
+    "bufio"
+    "io"
+    "mime"
+    "net/http"
+    "strings"
+)
+
+// Sniff detects the content type of r from its first bytes (ignoring the
+// client's Content-Type, which is trivially forged) and returns a reader
+// that still yields the whole content.
+func Sniff(r io.Reader) (string, io.Reader, error) {
+    br := bufio.NewReaderSize(r, 512)
+    head, err := br.Peek(512)
+    if err != nil && err != io.EOF {
+        return "", nil, err
+    }
+    contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+    return contentType, br, nil
+}
+
+// Allowed reports whether contentType matches one of types: an exact
+// type, a family such as "image/*", or "*/*" for anything.
+func Allowed(contentType string, types []string) bool {
+    family, _, _ := strings.Cut(contentType, "/")
+    for _, t := range types {
+        if t == contentType || t == "*/*" || t == family+"/*" {
+            return true
+        }
+    }
+    return false
+}
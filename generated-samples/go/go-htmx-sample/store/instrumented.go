@@ -0,0 +1,78 @@
+package store
+
+import (
+    "context"
+    "log/slog"
+    "os"
+    "time"
+    "myapp/clock"
+    "myapp/models"
+    "myapp/reqctx"
+)
+
+// InstrumentedStore wraps any ItemStore and logs operations slower than
+// the threshold as JSON, whatever the underlying backend.
+type InstrumentedStore struct {
+    next      ItemStore
+    threshold time.Duration
+    clock     clock.Clock
+    logger    *slog.Logger
+}
+
+func NewInstrumentedStore(next ItemStore, threshold time.Duration, clk clock.Clock) *InstrumentedStore {
+    return &InstrumentedStore{
+        next:      next,
+        threshold: threshold,
+        clock:     clk,
+        logger:    slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+    }
+}
+
+func (s *InstrumentedStore) observe(ctx context.Context, op string, start time.Time) {
+    reqctx.CountQuery(ctx)
+
+    elapsed := s.clock.Now().Sub(start)
+    if elapsed < s.threshold {
+        return
+    }
+    s.logger.WarnContext(ctx, "slow query",
+        slog.String("op", op),
+        slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+        slog.Float64("threshold_ms", float64(s.threshold.Microseconds())/1000),
+    )
+}
+
+func (s *InstrumentedStore) List(ctx context.Context) ([]models.Item, error) {
+    defer s.observe(ctx, "List", s.clock.Now())
+    return s.next.List(ctx)
+}
+
+func (s *InstrumentedStore) Find(ctx context.Context, q ListQuery) (Page, error) {
+    defer s.observe(ctx, "Find", s.clock.Now())
+    return s.next.Find(ctx, q)
+}
+
+func (s *InstrumentedStore) Get(ctx context.Context, id string) (models.Item, error) {
+    defer s.observe(ctx, "Get", s.clock.Now())
+    return s.next.Get(ctx, id)
+}
+
+func (s *InstrumentedStore) Create(ctx context.Context, item models.Item) (models.Item, error) {
+    defer s.observe(ctx, "Create", s.clock.Now())
+    return s.next.Create(ctx, item)
+}
+
+func (s *InstrumentedStore) Update(ctx context.Context, item models.Item) (models.Item, error) {
+    defer s.observe(ctx, "Update", s.clock.Now())
+    return s.next.Update(ctx, item)
+}
+
+func (s *InstrumentedStore) Delete(ctx context.Context, id string) error {
+    defer s.observe(ctx, "Delete", s.clock.Now())
+    return s.next.Delete(ctx, id)
+}
+
+func (s *InstrumentedStore) UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) ([]models.Item, error) {
+    defer s.observe(ctx, "UpdateMany", s.clock.Now())
+    return s.next.UpdateMany(ctx, ids, fn)
+}
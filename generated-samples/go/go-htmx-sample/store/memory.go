@@ -0,0 +1,249 @@
+package store
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+    "myapp/models"
+)
+
+// MemoryStore keeps items in process memory (replace with database in production).
+// Deleted items stay in the slice with DeletedAt set. Every operation
+// returns ctx.Err() once the request is canceled or past its deadline,
+// the same as the database backends.
+type MemoryStore struct {
+    mu     sync.RWMutex
+    items  []models.Item
+    nextID int
+}
+
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{nextID: 1}
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]models.Item, error) {
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    items := make([]models.Item, 0, len(s.items))
+    for _, item := range s.items {
+        if !item.Deleted() {
+            items = append(items, item)
+        }
+    }
+    return items, nil
+}
+
+func (s *MemoryStore) Find(ctx context.Context, q ListQuery) (Page, error) {
+    if err := ctx.Err(); err != nil {
+        return Page{}, err
+    }
+    terms := q.Terms()
+
+    s.mu.RLock()
+    var matches []models.Item
+    for _, item := range s.items {
+        if (q.IncludeDeleted || !item.Deleted()) && matchesAll(item, terms) {
+            matches = append(matches, item)
+        }
+    }
+    s.mu.RUnlock()
+
+    field, desc := q.order()
+    var scores map[string]int
+    if field == SortRelevance {
+        scores = make(map[string]int, len(matches))
+        for _, item := range matches {
+            scores[item.ID] = score(item, terms)
+        }
+    }
+    sort.SliceStable(matches, func(i, j int) bool {
+        a, b := matches[i], matches[j]
+        if desc {
+            a, b = b, a
+        }
+        if field == SortRelevance {
+            if sa, sb := scores[a.ID], scores[b.ID]; sa != sb {
+                return sa > sb
+            }
+        }
+        if field == "title" {
+            if ta, tb := strings.ToLower(a.Title), strings.ToLower(b.Title); ta != tb {
+                return ta < tb
+            }
+        }
+        return lessID(a.ID, b.ID)
+    })
+
+    page := Page{Total: len(matches)}
+    if q.Limit <= 0 {
+        page.Items = matches
+        return page, nil
+    }
+    start := min(max(q.Offset, 0), len(matches))
+    end := min(start+q.Limit, len(matches))
+    page.Items = matches[start:end]
+    return page, nil
+}
+
+func matchesAll(item models.Item, terms []string) bool {
+    title := strings.ToLower(item.Title)
+    description := strings.ToLower(item.Description)
+    for _, term := range terms {
+        if !strings.Contains(title, term) && !strings.Contains(description, term) {
+            return false
+        }
+    }
+    return true
+}
+
+// lessID orders the store's numeric string IDs numerically.
+func lessID(a, b string) bool {
+    if len(a) != len(b) {
+        return len(a) < len(b)
+    }
+    return a < b
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (models.Item, error) {
+    if err := ctx.Err(); err != nil {
+        return models.Item{}, err
+    }
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    if i := s.index(id); i >= 0 {
+        return s.items[i], nil
+    }
+    return models.Item{}, ErrNotFound
+}
+
+// index returns the position of the live item with id, or -1. Callers
+// hold the lock.
+func (s *MemoryStore) index(id string) int {
+    for i, item := range s.items {
+        if item.ID == id && !item.Deleted() {
+            return i
+        }
+    }
+    return -1
+}
+
+func (s *MemoryStore) Create(ctx context.Context, item models.Item) (models.Item, error) {
+    if err := ctx.Err(); err != nil {
+        return models.Item{}, err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    item.ID = fmt.Sprintf("%d", s.nextID)
+    s.nextID++
+    item.CreatedAt = now()
+    item.UpdatedAt = item.CreatedAt
+    item.DeletedAt = nil
+    item.Version = 1
+    s.items = append(s.items, item)
+    return item, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, item models.Item) (models.Item, error) {
+    if err := ctx.Err(); err != nil {
+        return models.Item{}, err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    i := s.index(item.ID)
+    if i < 0 {
+        return models.Item{}, ErrNotFound
+    }
+    if item.Version != s.items[i].Version {
+        return models.Item{}, ErrConflict
+    }
+    s.items[i] = touch(s.items[i], item)
+    return s.items[i], nil
+}
+
+// touch returns item as an update of stored: the store's timestamps are
+// kept, UpdatedAt moves to now and Version to the next one.
+func touch(stored, item models.Item) models.Item {
+    item.CreatedAt = stored.CreatedAt
+    item.DeletedAt = stored.DeletedAt
+    item.UpdatedAt = now()
+    item.Version = stored.Version + 1
+    return item
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    i := s.index(id)
+    if i < 0 {
+        return ErrNotFound
+    }
+    deleted := now()
+    s.items[i].DeletedAt = &deleted
+    s.items[i].UpdatedAt = deleted
+    s.items[i].Version++
+    return nil
+}
+
+func (s *MemoryStore) PurgeDeleted(ctx context.Context, cutoff time.Time) (int, error) {
+    if err := ctx.Err(); err != nil {
+        return 0, err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    kept := s.items[:0]
+    for _, item := range s.items {
+        if item.Deleted() && item.DeletedAt.Before(cutoff) {
+            continue
+        }
+        kept = append(kept, item)
+    }
+    purged := len(s.items) - len(kept)
+    clear(s.items[len(kept):])
+    s.items = kept
+    return purged, nil
+}
+
+func (s *MemoryStore) UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) ([]models.Item, error) {
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    index := make(map[string]int, len(s.items))
+    for i, item := range s.items {
+        if !item.Deleted() {
+            index[item.ID] = i
+        }
+    }
+    for _, id := range ids {
+        if _, ok := index[id]; !ok {
+            return nil, ErrNotFound
+        }
+    }
+
+    updated := make([]models.Item, 0, len(ids))
+    for _, id := range ids {
+        stored := s.items[index[id]]
+        item := stored
+        fn(&item)
+        s.items[index[id]] = touch(stored, item)
+        updated = append(updated, s.items[index[id]])
+    }
+    return updated, nil
+}
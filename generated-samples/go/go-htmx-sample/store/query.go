@@ -0,0 +1,96 @@
+package store
+
+import (
+    "strings"
+    "unicode"
+    "myapp/models"
+)
+
+// ListQuery selects a page of items for Find. The zero value is every item
+// not deleted, in creation order.
+type ListQuery struct {
+    // Search keeps items whose title or description contains every word
+    // of it, ignoring case
+    Search string
+    // Sort is a field from SortFields, prefixed with "-" for descending
+    // order, or SortRelevance; empty means relevance when searching and
+    // "id" otherwise
+    Sort string
+    // Offset skips that many matches; Limit caps the page (0 returns every
+    // match and ignores Offset)
+    Offset int
+    Limit  int
+    // IncludeDeleted also returns soft-deleted items, for admin views
+    IncludeDeleted bool
+}
+
+// Page is one page of Find results and the number of matches overall.
+type Page struct {
+    Items []models.Item
+    Total int
+}
+
+// SortFields are the fields Find can order by.
+var SortFields = []string{"id", "title"}
+
+// SortRelevance orders search results best match first: by how often the
+// terms occur, a title hit counting double (see score). It has no
+// descending form.
+const SortRelevance = "relevance"
+
+// ValidSort reports whether s is a usable ListQuery.Sort value.
+func ValidSort(s string) bool {
+    if s == "" || s == SortRelevance {
+        return true
+    }
+    field := strings.TrimPrefix(s, "-")
+    for _, f := range SortFields {
+        if field == f {
+            return true
+        }
+    }
+    return false
+}
+
+// Terms splits Search into lower-case words. Only letters and digits are
+// kept, so terms are safe to use in a LIKE pattern.
+func (q ListQuery) Terms() []string {
+    return strings.FieldsFunc(strings.ToLower(q.Search), func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+    })
+}
+
+// order returns the sort field and direction. Searches default to
+// relevance; without search terms relevance, like an invalid Sort, falls
+// back to id order.
+func (q ListQuery) order() (field string, desc bool) {
+    switch {
+    case !ValidSort(q.Sort):
+        return "id", false
+    case q.Sort == "" || q.Sort == SortRelevance:
+        if len(q.Terms()) > 0 {
+            return SortRelevance, false
+        }
+        return "id", false
+    }
+    return strings.TrimPrefix(q.Sort, "-"), strings.HasPrefix(q.Sort, "-")
+}
+
+// Relevance weights: a term in the title counts double one in the
+// description
+const (
+    titleWeight       = 2
+    descriptionWeight = 1
+)
+
+// score rates how well item matches terms for SortRelevance: each
+// occurrence of a term counts, weighted by the field it is in.
+func score(item models.Item, terms []string) int {
+    title := strings.ToLower(item.Title)
+    description := strings.ToLower(item.Description)
+    n := 0
+    for _, term := range terms {
+        n += titleWeight*strings.Count(title, term) + descriptionWeight*strings.Count(description, term)
+    }
+    return n
+}
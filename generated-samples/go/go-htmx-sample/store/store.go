@@ -0,0 +1,57 @@
+package store
+
+import (
+    "context"
+    "errors"
+    "time"
+    "myapp/models"
+)
+
+var (
+    ErrNotFound = errors.New("item not found")
+    // ErrConflict means the item was written since the caller read it:
+    // its Version is no longer the stored one
+    ErrConflict = errors.New("item was changed since it was read")
+)
+
+// ItemStore is the persistence boundary used by the handlers.
+type ItemStore interface {
+    List(ctx context.Context) ([]models.Item, error)
+    // Find returns the page of items selected by q (search, sort, offset
+    // and limit) along with the total number of matches.
+    Find(ctx context.Context, q ListQuery) (Page, error)
+    // Get, Update and Delete treat a soft-deleted item as missing
+    Get(ctx context.Context, id string) (models.Item, error)
+    Create(ctx context.Context, item models.Item) (models.Item, error)
+    // Update writes item if item.Version is still the stored version,
+    // else returns ErrConflict; the result carries the next version
+    Update(ctx context.Context, item models.Item) (models.Item, error)
+    // Delete soft-deletes: the row stays, stamped with DeletedAt
+    Delete(ctx context.Context, id string) error
+
+    // UpdateMany applies fn to every listed item atomically: if any ID is
+    // missing nothing is changed and ErrNotFound is returned.
+    UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) ([]models.Item, error)
+}
+
+// Pinger is implemented by stores with a connection to check, which
+// main registers as the "database" readiness check.
+type Pinger interface {
+    Ping(ctx context.Context) error
+}
+
+// Purger is implemented by stores that can remove soft-deleted items for
+// good, which the cleanup job does once they are older than the retention
+// period.
+type Purger interface {
+    // PurgeDeleted hard-deletes the items soft-deleted before cutoff and
+    // returns how many it removed.
+    PurgeDeleted(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// now is the time stores stamp on items, in UTC and kept to the
+// microsecond Postgres and MySQL store, so an item reads back exactly as
+// it was returned.
+func now() time.Time {
+    return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,116 @@
+package validation
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "strings"
+    "github.com/go-playground/validator/v10"
+)
+
+// Errors maps a field path such as "title" or "items[1].title" to an
+// English message key, so views can show each message beside its input.
+type Errors map[string]string
+
+// Add records msg for path, keeping the first message per field.
+func (e Errors) Add(path, msg string) {
+    if _, ok := e[path]; !ok {
+        e[path] = msg
+    }
+}
+
+// Nest adds a child record's errors under prefix, e.g. "items[1]".
+func (e Errors) Nest(prefix string, child Errors) {
+    for path, msg := range child {
+        e.Add(prefix+"."+path, msg)
+    }
+}
+
+// Get returns the message for path, or "".
+func (e Errors) Get(path string) string {
+    return e[path]
+}
+
+func (e Errors) Empty() bool {
+    return len(e) == 0
+}
+
+// Index builds the path of the i-th element of a repeated field: "items[1]".
+func Index(name string, i int) string {
+    return fmt.Sprintf("%s[%d]", name, i)
+}
+
+// Path builds the path of a field on the i-th child: "items[1].title".
+func Path(name string, i int, field string) string {
+    return Index(name, i) + "." + field
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+    v := validator.New(validator.WithRequiredStructEnabled())
+    // Report fields by the name the client sent, not the Go field name
+    v.RegisterTagNameFunc(func(f reflect.StructField) string {
+        for _, key := range []string{"form", "json"} {
+            if name, _, _ := strings.Cut(f.Tag.Get(key), ","); name != "" && name != "-" {
+                return name
+            }
+        }
+        return f.Name
+    })
+    // "required" accepts "   "; notblank doesn't
+    v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+        return strings.TrimSpace(fl.Field().String()) != ""
+    })
+    return v
+}
+
+// Struct checks v against its `validate` struct tags. Errors are keyed by
+// each field's form (or json) name, nested fields joined with ".":
+//
+//  type itemForm struct {
+//      Title string `form:"title" validate:"notblank,max=200"`
+//  }
+//
+// v must be a struct or a pointer to one.
+func Struct(v any) Errors {
+    errs := Errors{}
+    var failed validator.ValidationErrors
+    if err := validate.Struct(v); errors.As(err, &failed) {
+        for _, fe := range failed {
+            // Namespace starts with the struct's type name
+            _, path, _ := strings.Cut(fe.Namespace(), ".")
+            errs.Add(path, message(fe))
+        }
+    } else if err != nil {
+        panic(err)
+    }
+    return errs
+}
+
+// message turns a failed tag into an English message key such as "Title
+// is required"; i18n/catalog.go translates the ones the app uses.
+func message(fe validator.FieldError) string {
+    label := strings.ReplaceAll(fe.Field(), "_", " ")
+    label = strings.ToUpper(label[:1]) + label[1:]
+    text := fe.Kind() == reflect.String
+
+    switch {
+    case fe.Tag() == "required" || fe.Tag() == "notblank":
+        return label + " is required"
+    case fe.Tag() == "max" && text:
+        return label + " is too long"
+    case fe.Tag() == "min" && text:
+        return label + " is too short"
+    case fe.Tag() == "max" || fe.Tag() == "lte":
+        return label + " is too large"
+    case fe.Tag() == "min" || fe.Tag() == "gte":
+        return label + " is too small"
+    case fe.Tag() == "oneof":
+        return label + " is not an allowed value"
+    case fe.Tag() == "email":
+        return label + " must be an email address"
+    default:
+        return label + " is invalid"
+    }
+}
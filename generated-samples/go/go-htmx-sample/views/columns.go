@@ -0,0 +1,31 @@
+package views
+
+import "strings"
+
+// ListColumns selects which item fields the list fragment renders. The
+// detail view always shows every field.
+type ListColumns map[string]bool
+
+// AllColumns is the default: every field is listed.
+var AllColumns = ListColumns{"title": true, "description": true}
+
+// ParseListColumns reads a comma-separated field list such as
+// "title,description". Unknown fields are ignored; an empty or entirely
+// unknown list falls back to AllColumns.
+func ParseListColumns(spec string) ListColumns {
+    cols := ListColumns{}
+    for _, name := range strings.Split(spec, ",") {
+        name = strings.ToLower(strings.TrimSpace(name))
+        if AllColumns[name] {
+            cols[name] = true
+        }
+    }
+    if len(cols) == 0 {
+        return AllColumns
+    }
+    return cols
+}
+
+func (c ListColumns) Show(field string) bool {
+    return c[field]
+}
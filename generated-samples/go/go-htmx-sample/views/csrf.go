@@ -0,0 +1,23 @@
+package views
+
+import (
+    "context"
+    "encoding/json"
+    "myapp/reqctx"
+)
+
+// CSRFToken returns the request's CSRF token, or "" when protection is off.
+func CSRFToken(ctx context.Context) string {
+    return reqctx.CSRFToken(ctx)
+}
+
+// csrfHeaders is the layout's hx-headers value: every HTMX request from
+// the page sends the token as X-CSRF-Token.
+func csrfHeaders(ctx context.Context) string {
+    token := CSRFToken(ctx)
+    if token == "" {
+        return "{}"
+    }
+    b, _ := json.Marshal(map[string]string{"X-CSRF-Token": token})
+    return string(b)
+}
@@ -0,0 +1,59 @@
+package views
+
+import (
+    "net/url"
+    "strconv"
+)
+
+// Pager describes the page of items on screen. Its links keep the current
+// search and sort so paging through results doesn't lose them.
+type Pager struct {
+    Page    int
+    PerPage int
+    Total   int
+    Query   string
+    Sort    string
+}
+
+// Pages is the number of pages, at least 1.
+func (p Pager) Pages() int {
+    if p.PerPage <= 0 || p.Total <= p.PerPage {
+        return 1
+    }
+    return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+func (p Pager) HasPrev() bool {
+    return p.Page > 1
+}
+
+func (p Pager) HasNext() bool {
+    return p.Page < p.Pages()
+}
+
+// URL links to page n of the list fragment.
+func (p Pager) URL(n int) string {
+    v := url.Values{}
+    if p.Query != "" {
+        v.Set("q", p.Query)
+    }
+    if p.Sort != "" {
+        v.Set("sort", p.Sort)
+    }
+    v.Set("page", strconv.Itoa(n))
+    v.Set("per_page", strconv.Itoa(p.PerPage))
+    return Path("/items") + "?" + v.Encode()
+}
+
+// SortOptions are the list orderings offered in the sort menu, as
+// store.ListQuery.Sort values and labels.
+var SortOptions = []struct {
+    Value string
+    Label string
+}{
+    {"", "Best match"},
+    {"id", "Oldest first"},
+    {"-id", "Newest first"},
+    {"title", "Title A-Z"},
+    {"-title", "Title Z-A"},
+}
@@ -0,0 +1,43 @@
+package views
+
+import "strings"
+
+var basePath string
+
+// UseBasePath prefixes every URL the views and handlers generate, for apps
+// mounted under a subpath such as /app behind a reverse proxy.
+func UseBasePath(p string) {
+    basePath = p
+}
+
+// ParseBasePath normalizes BASE_PATH: "app/" and "/app" both become
+// "/app", and "" or "/" mean the app is served from the root.
+func ParseBasePath(s string) string {
+    s = strings.Trim(strings.TrimSpace(s), "/")
+    if s == "" {
+        return ""
+    }
+    return "/" + s
+}
+
+// Path returns the app-relative path p under the configured base path.
+func Path(p string) string {
+    return basePath + p
+}
+
+// Hashed asset names; identity until main wires in the asset manifest
+var assetName = func(name string) string { return name }
+
+func UseAssets(lookup func(name string) string) {
+    assetName = lookup
+}
+
+// Asset returns the cache-busting URL of a file in static/.
+func Asset(name string) string {
+    return Path("/static/" + assetName(name))
+}
+
+// BasePath returns the configured prefix, or "" at the root.
+func BasePath() string {
+    return basePath
+}
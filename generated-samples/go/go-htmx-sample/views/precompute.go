@@ -0,0 +1,48 @@
+package views
+
+import (
+    "bytes"
+    "context"
+    "io"
+    "golang.org/x/text/language"
+    "myapp/i18n"
+)
+
+var themes = []string{"light", "dark"}
+
+type pageKey struct {
+    locale language.Tag
+    theme  string
+}
+
+// homePages holds the home page rendered once per locale and theme. The
+// page has no per-request data, so rendering it for every request only
+// repeats the same translation lookups and string building.
+var homePages map[pageKey][]byte
+
+// Precompute renders request-independent pages ahead of time. Call it from
+// main once configuration is applied; until then pages render on demand.
+func Precompute(showToggle bool) error {
+    pages := make(map[pageKey][]byte, len(i18n.Supported)*len(themes))
+    for _, tag := range i18n.Supported {
+        ctx := i18n.ForLocale(context.Background(), tag)
+        for _, theme := range themes {
+            var buf bytes.Buffer
+            if err := Home(theme, showToggle).Render(ctx, &buf); err != nil {
+                return err
+            }
+            pages[pageKey{tag, theme}] = buf.Bytes()
+        }
+    }
+    homePages = pages
+    return nil
+}
+
+// RenderHome writes the home page, from the precomputed copy when there is one.
+func RenderHome(ctx context.Context, w io.Writer, theme string, showToggle bool) error {
+    if page, ok := homePages[pageKey{i18n.Locale(ctx), theme}]; ok {
+        _, err := w.Write(page)
+        return err
+    }
+    return Home(theme, showToggle).Render(ctx, w)
+}
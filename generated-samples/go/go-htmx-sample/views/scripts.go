@@ -0,0 +1,7 @@
+package views
+
+// scripts are the URLs of the scripts every page loads. htmx comes from
+// unpkg; projects generated with --bundler esbuild load their bundle.
+func scripts() []string {
+    return []string{"https://unpkg.com/htmx.org"}
+}
@@ -0,0 +1,8 @@
+package views
+
+// Whether pages show a sign-out button (set when the app has a login flow)
+var signOut bool
+
+func UseSignOut(enabled bool) {
+    signOut = enabled
+}
@@ -0,0 +1,15 @@
+package views
+
+import "strings"
+
+// Types the upload form's file picker offers (UPLOAD_TYPES); the server
+// checks them again against the file's content
+var uploadTypes []string
+
+func UseUploadTypes(types []string) {
+    uploadTypes = types
+}
+
+func uploadAccept() string {
+    return strings.Join(uploadTypes, ",")
+}
@@ -0,0 +1,43 @@
+// Package api holds what every versioned API package (api/v1, api/v2, ...)
+// shares.
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+    "myapp/logging"
+)
+
+// Deprecation describes a route or API version that is being retired.
+type Deprecation struct {
+    // Since is when it was deprecated.
+    Since time.Time
+    // Sunset is when it stops working; zero omits the Sunset header.
+    Sunset time.Time
+    // Link points at migration docs or the replacement; optional.
+    Link string
+}
+
+// Deprecated marks the routes it wraps as deprecated. Responses carry the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and every call is
+// logged so remaining clients can be found before the routes are removed.
+//
+//    r.With(api.Deprecated(api.Deprecation{Since: since, Sunset: sunset})).Mount("/api/v1", apiv1.Routes())
+func Deprecated(d Deprecation) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            h := w.Header()
+            h.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+            if !d.Sunset.IsZero() {
+                h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+            }
+            if d.Link != "" {
+                h.Add("Link", "<"+d.Link+`>; rel="deprecation"`)
+            }
+
+            logging.Warn(r.Context(), "deprecated route used", "user_agent", r.UserAgent())
+            next.ServeHTTP(w, r)
+        })
+    }
+}
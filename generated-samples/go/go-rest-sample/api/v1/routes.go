@@ -0,0 +1,24 @@
+// Package v1 is the first version of the items API, served under /api/v1.
+// Clients depend on its paths and payloads: make breaking changes in a
+// newer version instead (npx create-stack-app api-version .).
+package v1
+
+import (
+    "github.com/go-chi/chi/v5"
+    "myapp/handlers"
+)
+
+const itemRoute = "/items/{id}"
+
+// Routes serves the item CRUD endpoints relative to where it is mounted.
+func Routes() chi.Router {
+    r := chi.NewRouter()
+
+    r.Get("/items", handlers.ListItems)
+    r.Post("/items", handlers.CreateItem)
+    r.Get(itemRoute, handlers.GetItem)
+    r.Patch(itemRoute, handlers.UpdateItem)
+    r.Delete(itemRoute, handlers.DeleteItem)
+
+    return r
+}
@@ -0,0 +1,13 @@
+package cache
+
+import (
+    "context"
+    "myapp/config"
+)
+
+// Open returns the cache chosen when the project was generated. This
+// build caches in process; generate with --cache redis to share one
+// cache between replicas.
+func Open(ctx context.Context, cfg config.Cache) (Cache, error) {
+    return NewMemory(), nil
+}
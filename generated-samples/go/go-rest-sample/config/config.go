@@ -0,0 +1,262 @@
+package config
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log/slog"
+    "os"
+    "slices"
+    "strconv"
+    "strings"
+    "time"
+    "github.com/joho/godotenv"
+    "myapp/database"
+    "myapp/secrets"
+)
+
+// Config is every setting the API reads from the environment. Load fills
+// it once at startup; nothing else should call os.Getenv.
+type Config struct {
+    Port      string
+    Env       string
+    LogLevel  slog.Level
+    Server    Server
+    Database  Database
+    Cache     Cache
+    Telemetry Telemetry
+    RateLimit RateLimit
+    CORS      CORS
+}
+
+// Server holds the http.Server timeouts. Requests slower than
+// ReadTimeout/WriteTimeout are cut off; ShutdownTimeout bounds how long
+// in-flight requests get to finish after SIGINT/SIGTERM. RequestTimeout
+// is the deadline item handlers pass to the store (0 disables it); keep
+// it below WriteTimeout so a timed-out request can still be answered.
+type Server struct {
+    ReadHeaderTimeout time.Duration
+    ReadTimeout       time.Duration
+    WriteTimeout      time.Duration
+    IdleTimeout       time.Duration
+    ShutdownTimeout   time.Duration
+    RequestTimeout    time.Duration
+}
+
+// Database is handed to store.Open. URL is the connection string from
+// database.BuildDSN: DATABASE_URL, or with Postgres the DB_* variables.
+type Database struct {
+    URL           string
+    RunMigrations bool
+}
+
+// Cache is handed to cache.Open. Item reads are cached for TTL (0
+// disables the cache); RedisURL is used with --cache redis and, when
+// empty, leaves the cache in process.
+type Cache struct {
+    TTL      time.Duration
+    RedisURL string
+}
+
+// RateLimit is handed to ratelimit.Open (generated with --ratelimit
+// token-bucket). Each client gets a token bucket holding up to Burst
+// requests (default Limit), refilled at Limit per Window; 0 disables
+// limiting. Routes adds limits for single routes, e.g. "POST /login=5/1m".
+type RateLimit struct {
+    Limit  int
+    Window time.Duration
+    Burst  int
+    Routes []string
+}
+
+// CORS is handed to cors.Middleware. Pages on Origins (e.g. a frontend
+// on another host, or "*" for any) may call the API from the browser with
+// Methods and Headers, and read ExposeHeaders from the response; empty
+// Origins allows same-origin requests only. Preflight answers are cached
+// by the browser for MaxAge. AllowCredentials lets those pages send
+// cookies and Authorization, and needs explicit origins.
+type CORS struct {
+    Origins          []string
+    Methods          []string
+    Headers          []string
+    ExposeHeaders    []string
+    MaxAge           time.Duration
+    AllowCredentials bool
+}
+
+// Telemetry is handed to telemetry.Setup (generated with --observability
+// otel). Traces, and metrics when OTLPMetrics is set, are pushed to
+// OTLPEndpoint over OTLP/HTTP; without an endpoint nothing is exported
+// and metrics are only served at /metrics. The OTEL_EXPORTER_OTLP_*
+// variables (headers, per-signal endpoints) are read by the exporters.
+type Telemetry struct {
+    ServiceName  string
+    OTLPEndpoint string
+    OTLPMetrics  bool
+}
+
+// secretsTimeout bounds the secret store fetch, so an unreachable store
+// fails startup instead of hanging it.
+const secretsTimeout = 10 * time.Second
+
+// Load reads .env (when present) and the environment, with secrets from
+// the store named by SECRETS_PATH replacing their .env values (see
+// secrets.Load). Unset variables take their defaults; malformed ones are
+// errors, all reported together so a bad deploy shows every problem at
+// once.
+func Load() (*Config, error) {
+    godotenv.Load()
+
+    ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+    defer cancel()
+    if err := secrets.Load(ctx, os.Getenv("SECRETS_PATH")); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+
+    var l loader
+    c := &Config{
+        Port:     l.port("PORT", "8080"),
+        Env:      os.Getenv("NODE_ENV"),
+        LogLevel: l.level("LOG_LEVEL", slog.LevelInfo),
+        Server: Server{
+            ReadHeaderTimeout: l.duration("READ_HEADER_TIMEOUT", 5*time.Second),
+            ReadTimeout:       l.duration("READ_TIMEOUT", 15*time.Second),
+            WriteTimeout:      l.duration("WRITE_TIMEOUT", 15*time.Second),
+            IdleTimeout:       l.duration("IDLE_TIMEOUT", 60*time.Second),
+            ShutdownTimeout:   l.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+            RequestTimeout:    l.duration("REQUEST_TIMEOUT", 10*time.Second),
+        },
+        Database: Database{
+            RunMigrations: l.boolean("RUN_MIGRATIONS", true),
+        },
+        Cache: Cache{
+            TTL:      l.duration("CACHE_TTL", 0),
+            RedisURL: os.Getenv("CACHE_REDIS_URL"),
+        },
+        Telemetry: Telemetry{
+            ServiceName:  os.Getenv("OTEL_SERVICE_NAME"),
+            OTLPEndpoint: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+            OTLPMetrics:  l.boolean("OTEL_METRICS_OTLP", false),
+        },
+        RateLimit: RateLimit{
+            Limit:  l.integer("RATE_LIMIT", 0),
+            Window: l.duration("RATE_LIMIT_WINDOW", time.Minute),
+            Burst:  l.integer("RATE_LIMIT_BURST", 0),
+            Routes: l.list("RATE_LIMIT_ROUTES"),
+        },
+        CORS: CORS{
+            Origins:          l.list("CORS_ORIGINS"),
+            Methods:          l.list("CORS_METHODS", "GET", "POST", "PATCH", "DELETE"),
+            Headers:          l.list("CORS_HEADERS", "Content-Type", "Authorization", "X-Request-ID", "If-Match"),
+            ExposeHeaders:    l.list("CORS_EXPOSE_HEADERS", "Location", "ETag"),
+            MaxAge:           l.duration("CORS_MAX_AGE", 10*time.Minute),
+            AllowCredentials: l.boolean("CORS_ALLOW_CREDENTIALS", false),
+        },
+    }
+
+    // The connection string is built once, here, so invalid database or
+    // TLS settings fail at startup rather than on first use
+    dsn, err := database.BuildDSN(os.Getenv)
+    if err != nil {
+        l.errs = append(l.errs, err)
+    }
+    c.Database.URL = dsn
+
+    // Browsers refuse credentialed responses to a wildcard origin
+    if c.CORS.AllowCredentials && slices.Contains(c.CORS.Origins, "*") {
+        l.errs = append(l.errs, errors.New(`CORS_ALLOW_CREDENTIALS=true: list the allowed origins instead of CORS_ORIGINS="*"`))
+    }
+
+    if err := errors.Join(l.errs...); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+    return c, nil
+}
+
+// loader parses variables, collecting an error for each malformed one
+// and returning the default in its place.
+type loader struct {
+    errs []error
+}
+
+func (l *loader) fail(key, value, want string) {
+    l.errs = append(l.errs, fmt.Errorf("%s=%q: want %s", key, value, want))
+}
+
+// list splits a comma-separated variable, or returns def when it is unset
+func (l *loader) list(key string, def ...string) []string {
+    var items []string
+    for _, item := range strings.Split(os.Getenv(key), ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    if items == nil {
+        return def
+    }
+    return items
+}
+
+func (l *loader) boolean(key string, def bool) bool {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    b, err := strconv.ParseBool(v)
+    if err != nil {
+        l.fail(key, v, "true or false")
+        return def
+    }
+    return b
+}
+
+func (l *loader) integer(key string, def int) int {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    n, err := strconv.Atoi(v)
+    if err != nil || n < 0 {
+        l.fail(key, v, "a whole number, 0 or more")
+        return def
+    }
+    return n
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil || d < 0 {
+        l.fail(key, v, "a duration such as 30s or 10m")
+        return def
+    }
+    return d
+}
+
+func (l *loader) port(key, def string) string {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+        l.fail(key, v, "a port between 1 and 65535")
+        return def
+    }
+    return v
+}
+
+func (l *loader) level(key string, def slog.Level) slog.Level {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    var level slog.Level
+    if err := level.UnmarshalText([]byte(v)); err != nil {
+        l.fail(key, v, "debug, info, warn or error")
+        return def
+    }
+    return level
+}
@@ -0,0 +1,125 @@
+// Package cors lets pages on other origins call the API from the browser.
+package cors
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+    "myapp/config"
+)
+
+// policy is config.CORS prepared for lookups and response headers.
+type policy struct {
+    anyOrigin   bool
+    origins     map[string]bool
+    methods     map[string]bool
+    headers     map[string]bool // lower case
+    credentials bool
+
+    allowMethods  string
+    allowHeaders  string
+    exposeHeaders string
+    maxAge        string
+}
+
+// Middleware returns middleware that lets pages on cfg.Origins ("*" for
+// any) call the API, answering their preflight requests itself. It
+// returns nil when no origins are configured, since same-origin pages
+// need no CORS.
+func Middleware(cfg config.CORS) func(http.Handler) http.Handler {
+    if len(cfg.Origins) == 0 {
+        return nil
+    }
+    p := &policy{
+        origins:       make(map[string]bool, len(cfg.Origins)),
+        methods:       make(map[string]bool, len(cfg.Methods)),
+        headers:       make(map[string]bool, len(cfg.Headers)),
+        credentials:   cfg.AllowCredentials,
+        allowMethods:  strings.ToUpper(strings.Join(cfg.Methods, ", ")),
+        allowHeaders:  strings.Join(cfg.Headers, ", "),
+        exposeHeaders: strings.Join(cfg.ExposeHeaders, ", "),
+    }
+    for _, origin := range cfg.Origins {
+        if origin == "*" {
+            p.anyOrigin = true
+        }
+        p.origins[strings.TrimSuffix(origin, "/")] = true
+    }
+    for _, method := range cfg.Methods {
+        p.methods[strings.ToUpper(method)] = true
+    }
+    for _, header := range cfg.Headers {
+        p.headers[strings.ToLower(header)] = true
+    }
+    if cfg.MaxAge > 0 {
+        p.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+    }
+
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            p.serve(w, r, next)
+        })
+    }
+}
+
+func (p *policy) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+    h := w.Header()
+    h.Add("Vary", "Origin")
+    origin := r.Header.Get("Origin")
+    if origin == "" || !(p.anyOrigin || p.origins[origin]) {
+        next.ServeHTTP(w, r)
+        return
+    }
+
+    if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+        h.Add("Vary", "Access-Control-Request-Method")
+        h.Add("Vary", "Access-Control-Request-Headers")
+        // A method or header the API doesn't accept gets no CORS headers,
+        // so the browser refuses the request it asked about
+        if p.permits(r) {
+            p.allowOrigin(h, origin)
+            h.Set("Access-Control-Allow-Methods", p.allowMethods)
+            if p.allowHeaders != "" {
+                h.Set("Access-Control-Allow-Headers", p.allowHeaders)
+            }
+            if p.maxAge != "" {
+                h.Set("Access-Control-Max-Age", p.maxAge)
+            }
+        }
+        w.WriteHeader(http.StatusNoContent)
+        return
+    }
+
+    p.allowOrigin(h, origin)
+    if p.exposeHeaders != "" {
+        h.Set("Access-Control-Expose-Headers", p.exposeHeaders)
+    }
+    next.ServeHTTP(w, r)
+}
+
+// permits reports whether a preflight asks for an allowed method and
+// only allowed headers.
+func (p *policy) permits(r *http.Request) bool {
+    if !p.methods[r.Header.Get("Access-Control-Request-Method")] {
+        return false
+    }
+    for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+        if header = strings.TrimSpace(header); header != "" && !p.headers[strings.ToLower(header)] {
+            return false
+        }
+    }
+    return true
+}
+
+// allowOrigin names the origin the response is shared with. A wildcard
+// can't carry credentials, so those responses name the caller instead.
+func (p *policy) allowOrigin(h http.Header, origin string) {
+    if p.anyOrigin && !p.credentials {
+        h.Set("Access-Control-Allow-Origin", "*")
+        return
+    }
+    h.Set("Access-Control-Allow-Origin", origin)
+    if p.credentials {
+        h.Set("Access-Control-Allow-Credentials", "true")
+    }
+}
@@ -0,0 +1,162 @@
+package dto
+
+import (
+    "fmt"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+    "myapp/models"
+    "myapp/store"
+    "myapp/validation"
+)
+
+// Page sizes for GET /api/v1/items, matching its parameters in openapi.yaml
+const (
+    DefaultPerPage = 20
+    MaxPerPage     = 100
+)
+
+// CreateItem is the body of POST /api/v1/items. Limits match ItemInput in
+// openapi.yaml.
+type CreateItem struct {
+    Title       string `json:"title" validate:"notblank,max=200"`
+    Description string `json:"description" validate:"max=5000"`
+}
+
+// UpdateItem is the body of PATCH /api/v1/items/{id}. Omitted fields are left
+// unchanged. Version, like an If-Match header, makes the update fail with
+// 409 if the item has been written since that version.
+type UpdateItem struct {
+    Title       *string `json:"title" validate:"omitnil,notblank,max=200"`
+    Description *string `json:"description" validate:"omitnil,max=5000"`
+    Version     *int    `json:"version" validate:"omitnil,min=1"`
+}
+
+// Item is the response representation of an item. DeletedAt is only set
+// on soft-deleted items, which are listed with ?include_deleted=true.
+type Item struct {
+    ID          string     `json:"id"`
+    Title       string     `json:"title"`
+    Description string     `json:"description"`
+    CreatedAt   time.Time  `json:"created_at"`
+    UpdatedAt   time.Time  `json:"updated_at"`
+    DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+    Version     int        `json:"version"`
+}
+
+// ItemList is one page of GET /api/v1/items: Count items out of Total matches.
+type ItemList struct {
+    Data    []Item `json:"data"`
+    Count   int    `json:"count"`
+    Total   int    `json:"total"`
+    Page    int    `json:"page"`
+    PerPage int    `json:"per_page"`
+}
+
+// ListParams are the query parameters of GET /api/v1/items.
+type ListParams struct {
+    Query          string
+    Sort           string
+    Page           int
+    PerPage        int
+    IncludeDeleted bool
+}
+
+// ParseListParams reads ?q, ?sort, ?page, ?per_page and ?include_deleted,
+// defaulting the page to 1 and its size to DefaultPerPage. Errors are
+// keyed by parameter.
+func ParseListParams(v url.Values) (ListParams, validation.Errors) {
+    errs := validation.Errors{}
+    p := ListParams{
+        Query:          v.Get("q"),
+        Sort:           v.Get("sort"),
+        Page:           queryInt(errs, v, "page", 1, 0),
+        PerPage:        queryInt(errs, v, "per_page", DefaultPerPage, MaxPerPage),
+        IncludeDeleted: queryBool(errs, v, "include_deleted"),
+    }
+    if !store.ValidSort(p.Sort) {
+        errs["sort"] = "must be one of " + strings.Join(sortValues(), ", ")
+    }
+    return p, errs
+}
+
+// ListQuery is the store query for the requested page.
+func (p ListParams) ListQuery() store.ListQuery {
+    return store.ListQuery{
+        Search: p.Query,
+        Sort:   p.Sort,
+        Offset:         (p.Page - 1) * p.PerPage,
+        Limit:          p.PerPage,
+        IncludeDeleted: p.IncludeDeleted,
+    }
+}
+
+// queryInt reads a positive whole number up to max (0 for no limit).
+func queryInt(errs validation.Errors, v url.Values, key string, def, max int) int {
+    s := v.Get(key)
+    if s == "" {
+        return def
+    }
+    n, err := strconv.Atoi(s)
+    switch {
+    case err != nil || n < 1:
+        errs[key] = "must be a whole number, 1 or more"
+    case max > 0 && n > max:
+        errs[key] = fmt.Sprintf("must be at most %d", max)
+    default:
+        return n
+    }
+    return def
+}
+
+// queryBool reads true or false (or 1/0); absent means false.
+func queryBool(errs validation.Errors, v url.Values, key string) bool {
+    s := v.Get(key)
+    if s == "" {
+        return false
+    }
+    b, err := strconv.ParseBool(s)
+    if err != nil {
+        errs[key] = "must be true or false"
+    }
+    return b
+}
+
+func sortValues() []string {
+    values := []string{store.SortRelevance}
+    for _, f := range store.SortFields {
+        values = append(values, f, "-"+f)
+    }
+    return values
+}
+
+// Model builds a new item from the request.
+func (c CreateItem) Model() models.Item {
+    return models.Item{Title: strings.TrimSpace(c.Title), Description: c.Description}
+}
+
+// Apply copies the fields present in the request onto item.
+func (u UpdateItem) Apply(item *models.Item) {
+    if u.Title != nil {
+        item.Title = strings.TrimSpace(*u.Title)
+    }
+    if u.Description != nil {
+        item.Description = *u.Description
+    }
+    if u.Version != nil {
+        item.Version = *u.Version
+    }
+}
+
+func FromModel(m models.Item) Item {
+    return Item{
+        ID:          m.ID,
+        Title:       m.Title,
+        Description: m.Description,
+        CreatedAt:   m.CreatedAt,
+        UpdatedAt:   m.UpdatedAt,
+        DeletedAt:   m.DeletedAt,
+        Version:     m.Version,
+    }
+}
@@ -0,0 +1,9 @@
+module myapp
+
+go 1.21
+
+require (
+    github.com/go-chi/chi/v5 v5.0.11
+    github.com/go-playground/validator/v10 v10.22.0
+    github.com/joho/godotenv v1.5.1
+)
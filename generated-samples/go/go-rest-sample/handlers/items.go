@@ -0,0 +1,125 @@
+package handlers
+
+import (
+    "net/http"
+    "path"
+    "github.com/go-chi/chi/v5"
+    "myapp/dto"
+    "myapp/store"
+)
+
+// Item persistence (in-memory unless main wires another backend)
+var itemStore store.ItemStore = store.NewMemoryStore()
+
+func UseStore(s store.ItemStore) {
+    itemStore = s
+}
+
+// ListItems answers one page of items. ?q keeps items whose title or
+// description contains every word, ?sort orders by id or title ("-" for
+// descending) or by relevance, the default with ?q, and ?page/?per_page
+// pick the page; bad values are a 400.
+// ?include_deleted=true adds soft-deleted items, for an admin view.
+func ListItems(w http.ResponseWriter, r *http.Request) {
+    params, errs := dto.ParseListParams(r.URL.Query())
+    if len(errs) > 0 {
+        writeProblem(w, r, http.StatusBadRequest, "invalid query parameters", errs)
+        return
+    }
+
+    page, err := itemStore.Find(r.Context(), params.ListQuery())
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    out := dto.ItemList{
+        Data:    make([]dto.Item, len(page.Items)),
+        Count:   len(page.Items),
+        Total:   page.Total,
+        Page:    params.Page,
+        PerPage: params.PerPage,
+    }
+    for i, item := range page.Items {
+        out.Data[i] = dto.FromModel(item)
+    }
+    writeJSON(w, http.StatusOK, out)
+}
+
+func GetItem(w http.ResponseWriter, r *http.Request) {
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    writeItem(w, http.StatusOK, item)
+}
+
+// CreateItem answers 201 with the new item. Its Location is resolved
+// against the request path, so it stays under the API version called.
+func CreateItem(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreateItem
+    if !decode(w, r, &req) || !validated(w, r, req) {
+        return
+    }
+
+    item, err := itemStore.Create(r.Context(), req.Model())
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    w.Header().Set("Location", path.Join(r.URL.Path, item.ID))
+    writeItem(w, http.StatusCreated, item)
+}
+
+// UpdateItem applies a partial update. With an If-Match header or a
+// version in the body it only succeeds if the item is still at that
+// version, else 409; without one it updates whatever is current, though a
+// write landing between its read and update is still a 409.
+func UpdateItem(w http.ResponseWriter, r *http.Request) {
+    version, ok := ifMatch(w, r)
+    if !ok {
+        return
+    }
+    var req dto.UpdateItem
+    if !decode(w, r, &req) || !validated(w, r, req) {
+        return
+    }
+
+    item, err := itemStore.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    req.Apply(&item)
+    if version > 0 {
+        item.Version = version
+    }
+
+    item, err = itemStore.Update(r.Context(), item)
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    writeItem(w, http.StatusOK, item)
+}
+
+// DeleteItem soft-deletes: the item answers 404 from then on but is
+// still listed with ?include_deleted=true.
+func DeleteItem(w http.ResponseWriter, r *http.Request) {
+    if err := itemStore.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// NotFound and MethodNotAllowed keep error bodies JSON for unknown routes.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+    writeError(w, r, http.StatusNotFound, "route not found")
+}
+
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+    writeError(w, r, http.StatusMethodNotAllowed, "method not allowed")
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    "myapp/dto"
+    "myapp/logging"
+    "myapp/models"
+    "myapp/store"
+    "myapp/validation"
+)
+
+// Problem is the body of every error response: RFC 7807 problem details.
+type Problem struct {
+    Type     string `json:"type"`
+    Title    string `json:"title"`
+    Status   int    `json:"status"`
+    Detail   string `json:"detail,omitempty"`
+    Instance string `json:"instance,omitempty"`
+    // Errors is an extension member with per-field messages keyed by JSON
+    // field or query parameter name
+    Errors validation.Errors `json:"errors,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string, errs validation.Errors) {
+    w.Header().Set("Content-Type", "application/problem+json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(Problem{
+        Type:     "about:blank",
+        Title:    http.StatusText(status),
+        Status:   status,
+        Detail:   detail,
+        Instance: r.URL.Path,
+        Errors:   errs,
+    })
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, detail string) {
+    writeProblem(w, r, status, detail, nil)
+}
+
+func writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
+    switch {
+    case errors.Is(err, context.Canceled):
+    case errors.Is(err, store.ErrNotFound):
+        writeError(w, r, http.StatusNotFound, "item not found")
+    case errors.Is(err, store.ErrConflict):
+        writeError(w, r, http.StatusConflict, "item was changed since it was read; fetch it and retry")
+    case errors.Is(err, context.DeadlineExceeded):
+        logging.Warn(r.Context(), "store operation timed out", "err", err)
+        writeError(w, r, http.StatusServiceUnavailable, "request timed out; retry later")
+    default:
+        logging.Error(r.Context(), "store operation failed", "err", err)
+        writeError(w, r, http.StatusInternalServerError, "internal server error")
+    }
+}
+
+// writeItem answers with an item and its ETag.
+func writeItem(w http.ResponseWriter, status int, item models.Item) {
+    w.Header().Set("ETag", `"`+strconv.Itoa(item.Version)+`"`)
+    writeJSON(w, status, dto.FromModel(item))
+}
+
+// ifMatch reads the version named by an If-Match header: 0 when there is
+// none or it is "*". A malformed header is answered with 400 and reports
+// false.
+func ifMatch(w http.ResponseWriter, r *http.Request) (int, bool) {
+    tag := r.Header.Get("If-Match")
+    if tag == "" || tag == "*" {
+        return 0, true
+    }
+    version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(tag, "W/"), `"`))
+    if err != nil || version < 1 {
+        writeError(w, r, http.StatusBadRequest, "invalid If-Match header: send the item's ETag")
+        return 0, false
+    }
+    return version, true
+}
+
+// decode reads a JSON body into v, rejecting unknown fields and trailing
+// data. It answers 400 itself and reports false on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+    dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+    dec.DisallowUnknownFields()
+    if err := dec.Decode(v); err != nil {
+        writeError(w, r, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+        return false
+    }
+    if dec.More() {
+        writeError(w, r, http.StatusBadRequest, "invalid JSON body: unexpected data after object")
+        return false
+    }
+    return true
+}
+
+// validated checks v's struct tags, answering 422 with per-field messages
+// when any fail.
+func validated(w http.ResponseWriter, r *http.Request, v any) bool {
+    errs := validation.Struct(v)
+    if len(errs) == 0 {
+        return true
+    }
+    writeProblem(w, r, http.StatusUnprocessableEntity, "validation failed", errs)
+    return false
+}
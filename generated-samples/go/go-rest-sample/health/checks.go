@@ -0,0 +1,148 @@
+package health
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "os"
+    "sync"
+    "time"
+)
+
+// Overall readiness states
+const (
+    StatusReady     = "ready"
+    StatusDegraded  = "degraded"
+    StatusUnhealthy = "unhealthy"
+)
+
+// checkTimeout bounds each check, so a hung connection answers "down"
+// well within a probe's own timeout
+const checkTimeout = 2 * time.Second
+
+// Checker reports whether one thing the app needs works: nil when it does.
+type Checker func(ctx context.Context) error
+
+// Checks are the readiness checks, run on every readiness request. A
+// failing critical check makes the app unhealthy; a failing optional one
+// only marks it degraded.
+type Checks struct {
+    mu     sync.RWMutex
+    checks []check
+}
+
+type check struct {
+    name     string
+    fn       Checker
+    critical bool
+}
+
+// Register adds a critical check, e.g. the database ping.
+func (c *Checks) Register(name string, fn Checker) {
+    c.add(check{name: name, fn: fn, critical: true})
+}
+
+// RegisterOptional adds a check the app can run without, e.g. a cache.
+func (c *Checks) RegisterOptional(name string, fn Checker) {
+    c.add(check{name: name, fn: fn})
+}
+
+func (c *Checks) add(ch check) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.checks = append(c.checks, ch)
+}
+
+// CheckStatus is one check's entry in the readiness response.
+type CheckStatus struct {
+    Status   string `json:"status"`
+    Critical bool   `json:"critical"`
+    Duration string `json:"duration,omitempty"`
+    Error    string `json:"error,omitempty"`
+}
+
+// Run runs every check concurrently and folds the results into an
+// overall status.
+func (c *Checks) Run(ctx context.Context) (string, map[string]CheckStatus) {
+    c.mu.RLock()
+    checks := c.checks
+    c.mu.RUnlock()
+
+    results := make([]CheckStatus, len(checks))
+    var wg sync.WaitGroup
+    for i, ch := range checks {
+        wg.Add(1)
+        go func(i int, ch check) {
+            defer wg.Done()
+            ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+            defer cancel()
+
+            start := time.Now()
+            err := ch.fn(ctx)
+            results[i] = CheckStatus{Status: "up", Critical: ch.critical, Duration: time.Since(start).Round(time.Microsecond).String()}
+            if err != nil {
+                results[i].Status = "down"
+                results[i].Error = err.Error()
+            }
+        }(i, ch)
+    }
+    wg.Wait()
+
+    overall := StatusReady
+    statuses := make(map[string]CheckStatus, len(checks))
+    for i, ch := range checks {
+        statuses[ch.name] = results[i]
+        overall = worse(overall, results[i])
+    }
+    return overall, statuses
+}
+
+// worse folds one result into an overall status.
+func worse(overall string, s CheckStatus) string {
+    switch {
+    case s.Status == "up" || overall == StatusUnhealthy:
+        return overall
+    case s.Critical:
+        return StatusUnhealthy
+    default:
+        return StatusDegraded
+    }
+}
+
+// Handler serves readiness: 200 while every critical check passes (status
+// "ready", or "degraded" when an optional one fails), 503 otherwise.
+func (c *Checks) Handler(w http.ResponseWriter, r *http.Request) {
+    status, checks := c.Run(r.Context())
+    writeStatus(w, status, map[string]any{"status": status, "checks": checks})
+}
+
+// Live serves liveness: 200 whenever the process can answer. It checks
+// nothing downstream, so an outage there never gets the app restarted.
+func Live(w http.ResponseWriter, r *http.Request) {
+    writeStatus(w, "ok", map[string]any{"status": "ok"})
+}
+
+func writeStatus(w http.ResponseWriter, status string, body map[string]any) {
+    w.Header().Set("Content-Type", "application/json")
+    w.Header().Set("Cache-Control", "no-store")
+    if status == StatusUnhealthy {
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }
+    json.NewEncoder(w).Encode(body)
+}
+
+// DiskWritable checks that files can be created in dir (created if
+// missing), e.g. the upload directory on a mounted volume.
+func DiskWritable(dir string) Checker {
+    return func(ctx context.Context) error {
+        if err := os.MkdirAll(dir, 0o755); err != nil {
+            return err
+        }
+        f, err := os.CreateTemp(dir, ".readyz-*")
+        if err != nil {
+            return err
+        }
+        f.Close()
+        return os.Remove(f.Name())
+    }
+}
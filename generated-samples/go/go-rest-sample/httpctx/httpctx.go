@@ -0,0 +1,42 @@
+package httpctx
+
+import (
+    "context"
+    "time"
+    "github.com/go-chi/chi/v5/middleware"
+    "myapp/logging"
+)
+
+type userKey struct{}
+
+// What a handler reads off its request's context, in one place: its ID
+// (chi's RequestID middleware), logger, user and how long it has left.
+var (
+    // RequestID returns the request ID, or "" outside a request.
+    RequestID = middleware.GetReqID
+    // Logger returns the request's logger, tagged with its ID, method and
+    // path, or the default logger outside a request.
+    Logger = logging.FromContext
+)
+
+// WithUser records the authenticated user's ID; the API authenticates no
+// one itself, so this is for middleware you add in front of it.
+func WithUser(ctx context.Context, user string) context.Context {
+    return context.WithValue(ctx, userKey{}, user)
+}
+
+// User returns the authenticated user's ID, or "" for anonymous requests.
+func User(ctx context.Context) string {
+    user, _ := ctx.Value(userKey{}).(string)
+    return user
+}
+
+// Remaining returns how long until ctx's deadline (REQUEST_TIMEOUT on item
+// routes), floored at zero, and false when it has none.
+func Remaining(ctx context.Context) (time.Duration, bool) {
+    deadline, ok := ctx.Deadline()
+    if !ok {
+        return 0, false
+    }
+    return max(time.Until(deadline), 0), true
+}
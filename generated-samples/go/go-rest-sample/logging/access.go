@@ -0,0 +1,27 @@
+package logging
+
+import (
+    "net/http"
+    "time"
+    "github.com/go-chi/chi/v5/middleware"
+)
+
+// AccessLog logs one entry per request through the request's logger, so
+// it carries the request ID. It must run after Middleware.
+func AccessLog(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+        next.ServeHTTP(ww, r)
+
+        status := ww.Status()
+        if status == 0 {
+            status = http.StatusOK
+        }
+        Info(r.Context(), "request",
+            "status", status,
+            "bytes", ww.BytesWritten(),
+            "duration_ms", float64(time.Since(start).Microseconds())/1000,
+        )
+    })
+}
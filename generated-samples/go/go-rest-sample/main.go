@@ -0,0 +1,158 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "log"
+    "net/http"
+    "os/signal"
+    "syscall"
+    "github.com/go-chi/chi/v5"
+    "github.com/go-chi/chi/v5/middleware"
+    apiv1 "myapp/api/v1"
+    "myapp/cache"
+    "myapp/config"
+    "myapp/cors"
+    "myapp/handlers"
+    "myapp/health"
+    "myapp/httpctx"
+    "myapp/logging"
+    mw "myapp/middleware"
+    "myapp/openapi"
+    "myapp/ratelimit"
+    "myapp/store"
+    "myapp/telemetry"
+    "myapp/web"
+)
+
+func main() {
+    // Settings from .env and the environment, validated up front
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    logging.Setup(cfg.Env, cfg.LogLevel)
+
+    // Traces and metrics (generated with --observability otel; without it
+    // these are no-ops)
+    shutdownTelemetry, err := telemetry.Setup(context.Background(), cfg.Telemetry)
+    if err != nil {
+        log.Fatalf("telemetry: %v", err)
+    }
+
+    // Readiness checks, run by /readyz
+    checks := &health.Checks{}
+
+    // Item store (backend chosen at generation time)
+    items, closeStore, err := store.Open(context.Background(), cfg.Database)
+    if err != nil {
+        log.Fatalf("store: %v", err)
+    }
+    defer closeStore()
+    if db, ok := items.(store.Pinger); ok {
+        checks.Register("database", db.Ping)
+    }
+    items = telemetry.Store(items)
+
+    // Item reads cached for CACHE_TTL (0 disables it): in process, or in
+    // Redis when generated with --cache redis
+    if cfg.Cache.TTL > 0 {
+        itemCache, err := cache.Open(context.Background(), cfg.Cache)
+        if err != nil {
+            log.Fatalf("cache: %v", err)
+        }
+        defer itemCache.Close()
+        items = store.NewCachedStore(items, itemCache, cfg.Cache.TTL)
+        checks.RegisterOptional("cache", itemCache.Ping)
+    }
+    handlers.UseStore(items)
+
+    r := chi.NewRouter()
+    r.Use(telemetry.Middleware)
+    r.Use(middleware.RequestID)
+    r.Use(logging.Middleware(httpctx.RequestID))
+    r.Use(logging.AccessLog)
+    r.Use(middleware.Recoverer)
+
+    // Browser calls from the origins in CORS_ORIGINS, preflights included
+    // (CORS_ORIGINS unset keeps the API same-origin)
+    if allowCORS := cors.Middleware(cfg.CORS); allowCORS != nil {
+        r.Use(allowCORS)
+    }
+
+    // Rate limiting per client and route (generated with --ratelimit;
+    // RATE_LIMIT and RATE_LIMIT_ROUTES unset disable it)
+    rateLimit, closeRateLimit, err := ratelimit.Open(context.Background(), cfg.RateLimit, cfg.Cache.RedisURL)
+    if err != nil {
+        log.Fatalf("ratelimit: %v", err)
+    }
+    defer closeRateLimit()
+    if rateLimit != nil {
+        r.Use(rateLimit)
+    }
+
+    // Unmatched paths fall through to the embedded frontend, when there is one
+    r.NotFound(web.Fallback(handlers.NotFound))
+    r.MethodNotAllowed(handlers.MethodNotAllowed)
+
+    // /healthz is liveness; /readyz runs the checks (503 when a critical
+    // one fails)
+    r.Get("/healthz", health.Live)
+    r.Get("/readyz", checks.Handler)
+    if metrics := telemetry.Metrics(); metrics != nil {
+        r.Handle("/metrics", metrics)
+    }
+    r.Get("/openapi.yaml", openapi.Handler)
+    r.Get("/docs", openapi.Docs)
+    r.Get("/docs/init.js", openapi.DocsScript)
+
+    // Versioned items API: each version is a package under api/ that owns
+    // its routes, so a new one can change them without breaking clients of
+    // the old. Retire a version by wrapping its mount in api.Deprecated.
+    r.Group(func(r chi.Router) {
+        // Deadline for item handlers and their store calls (disabled when
+        // REQUEST_TIMEOUT is 0)
+        if cfg.Server.RequestTimeout > 0 {
+            r.Use(mw.Timeout(cfg.Server.RequestTimeout))
+        }
+
+        r.Mount("/api/v1", apiv1.Routes())
+    })
+
+    port := cfg.Port
+    // Timeouts keep slow or stalled clients from holding connections open
+    srv := &http.Server{
+        Addr:              ":" + port,
+        Handler:           r,
+        ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+        ReadTimeout:       cfg.Server.ReadTimeout,
+        WriteTimeout:      cfg.Server.WriteTimeout,
+        IdleTimeout:       cfg.Server.IdleTimeout,
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    go func() {
+        log.Println("🚀 API listening on http://localhost:" + port)
+        if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            log.Fatal(err)
+        }
+    }()
+
+    <-ctx.Done()
+    stop()
+    log.Println("Shutting down (Ctrl+C again to force)")
+
+    // In-flight requests get SHUTDOWN_TIMEOUT to finish, then are cut off
+    shutdown, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+    defer cancel()
+    if err := srv.Shutdown(shutdown); err != nil {
+        log.Printf("shutdown: %v", err)
+        srv.Close()
+    }
+    // Flush the spans and metrics still buffered
+    if err := shutdownTelemetry(shutdown); err != nil {
+        log.Printf("telemetry: %v", err)
+    }
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+    "context"
+    "net/http"
+    "time"
+)
+
+// Timeout gives each request a deadline d from now. It does not write a
+// response itself: store calls made with r.Context() fail with
+// context.DeadlineExceeded once it passes, and the handler decides how to
+// answer. Long-lived streams (SSE, websockets) must not sit behind it.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            ctx, cancel := context.WithTimeout(r.Context(), d)
+            defer cancel()
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
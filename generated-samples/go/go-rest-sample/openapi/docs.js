@@ -0,0 +1,5 @@
+// Relative, so the spec is found under BASE_PATH too
+window.ui = SwaggerUIBundle({
+    url: 'openapi.yaml',
+    dom_id: '#swagger-ui'
+});
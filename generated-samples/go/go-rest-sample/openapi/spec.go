@@ -0,0 +1,15 @@
+package openapi
+
+import (
+    _ "embed"
+    "net/http"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Handler serves the OpenAPI document.
+func Handler(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/yaml")
+    w.Write(spec)
+}
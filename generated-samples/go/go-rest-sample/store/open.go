@@ -0,0 +1,13 @@
+package store
+
+import (
+    "context"
+    "myapp/config"
+)
+
+// Open returns the backend chosen when the project was generated, plus a
+// function that releases it. This build keeps items in memory; generate
+// with --database postgres|sqlite|mysql for a SQL backend.
+func Open(ctx context.Context, cfg config.Database) (ItemStore, func() error, error) {
+    return NewMemoryStore(), func() error { return nil }, nil
+}
@@ -0,0 +1,74 @@
+package validation
+
+import (
+    "errors"
+    "reflect"
+    "strings"
+    "github.com/go-playground/validator/v10"
+)
+
+// Errors maps a JSON field name (nested fields joined with ".") to what is
+// wrong with it.
+type Errors map[string]string
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+    v := validator.New(validator.WithRequiredStructEnabled())
+    // Report fields by their JSON name, not the Go field name
+    v.RegisterTagNameFunc(func(f reflect.StructField) string {
+        if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+            return name
+        }
+        return f.Name
+    })
+    // "required" accepts "   "; notblank doesn't
+    v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+        return strings.TrimSpace(fl.Field().String()) != ""
+    })
+    return v
+}
+
+// Struct checks v against its `validate` struct tags, e.g.
+//
+//  Title string `json:"title" validate:"notblank,max=200"`
+//
+// v must be a struct or a pointer to one.
+func Struct(v any) Errors {
+    errs := Errors{}
+    var failed validator.ValidationErrors
+    if err := validate.Struct(v); errors.As(err, &failed) {
+        for _, fe := range failed {
+            // Namespace starts with the struct's type name
+            _, path, _ := strings.Cut(fe.Namespace(), ".")
+            if _, ok := errs[path]; !ok {
+                errs[path] = message(fe)
+            }
+        }
+    } else if err != nil {
+        panic(err)
+    }
+    return errs
+}
+
+func message(fe validator.FieldError) string {
+    text := fe.Kind() == reflect.String
+    switch {
+    case fe.Tag() == "required" || fe.Tag() == "notblank":
+        return "is required"
+    case fe.Tag() == "max" && text:
+        return "must be at most " + fe.Param() + " characters"
+    case fe.Tag() == "min" && text:
+        return "must be at least " + fe.Param() + " characters"
+    case fe.Tag() == "max" || fe.Tag() == "lte":
+        return "must be at most " + fe.Param()
+    case fe.Tag() == "min" || fe.Tag() == "gte":
+        return "must be at least " + fe.Param()
+    case fe.Tag() == "oneof":
+        return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+    case fe.Tag() == "email":
+        return "must be an email address"
+    default:
+        return "is invalid"
+    }
+}
@@ -0,0 +1,10 @@
+package web
+
+import "net/http"
+
+// Fallback handles requests no route matched. This build has no frontend
+// and returns notFound; the fullstack-react-go template replaces it with
+// one that serves the embedded React build.
+func Fallback(notFound http.HandlerFunc) http.HandlerFunc {
+    return notFound
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "log/slog"
+    "os"
+    "strconv"
+    "time"
+    "github.com/joho/godotenv"
+)
+
+// Config is every setting the worker reads from the environment. Load
+// fills it once at startup; nothing else should call os.Getenv.
+type Config struct {
+    Port            string
+    Env             string
+    LogLevel        slog.Level
+    APIURL          string
+    ReportInterval  time.Duration
+    ShutdownTimeout time.Duration
+}
+
+// Load reads .env (when present) and the environment. Unset variables
+// take their defaults; malformed ones are errors, all reported together
+// so a bad deploy shows every problem at once.
+func Load() (*Config, error) {
+    godotenv.Load()
+
+    var l loader
+    c := &Config{
+        Port:            l.port("PORT", "8081"),
+        Env:             os.Getenv("NODE_ENV"),
+        LogLevel:        l.level("LOG_LEVEL", slog.LevelInfo),
+        APIURL:          os.Getenv("API_URL"),
+        ReportInterval:  l.duration("REPORT_INTERVAL", time.Minute),
+        ShutdownTimeout: l.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+    }
+    if c.ReportInterval == 0 {
+        l.fail("REPORT_INTERVAL", "0", "a positive duration")
+    }
+
+    if err := errors.Join(l.errs...); err != nil {
+        return nil, fmt.Errorf("config: %w", err)
+    }
+    return c, nil
+}
+
+// loader parses variables, collecting an error for each malformed one
+// and returning the default in its place.
+type loader struct {
+    errs []error
+}
+
+func (l *loader) fail(key, value, want string) {
+    l.errs = append(l.errs, fmt.Errorf("%s=%q: want %s", key, value, want))
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil || d < 0 {
+        l.fail(key, v, "a duration such as 30s or 10m")
+        return def
+    }
+    return d
+}
+
+func (l *loader) port(key, def string) string {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+        l.fail(key, v, "a port between 1 and 65535")
+        return def
+    }
+    return v
+}
+
+func (l *loader) level(key string, def slog.Level) slog.Level {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    var level slog.Level
+    if err := level.UnmarshalText([]byte(v)); err != nil {
+        l.fail(key, v, "debug, info, warn or error")
+        return def
+    }
+    return level
+}
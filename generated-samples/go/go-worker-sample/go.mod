@@ -0,0 +1,7 @@
+module myapp
+
+go 1.21
+
+require (
+    github.com/joho/godotenv v1.5.1
+)
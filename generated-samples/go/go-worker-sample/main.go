@@ -0,0 +1,71 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "log"
+    "net/http"
+    "os/signal"
+    "syscall"
+    "time"
+    "myapp/config"
+    "myapp/logging"
+    "myapp/tasks"
+    "myapp/worker"
+)
+
+func main() {
+    // Settings from .env and the environment, validated up front
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    logging.Setup(cfg.Env, cfg.LogLevel)
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    // Register periodic tasks here
+    runner := worker.New()
+    client := &http.Client{Timeout: 10 * time.Second}
+    if cfg.APIURL != "" {
+        runner.Every("items-report", cfg.ReportInterval, tasks.ItemsReport(client, cfg.APIURL))
+    }
+
+    // /health is for container healthchecks and shows each task's last run
+    mux := http.NewServeMux()
+    mux.HandleFunc("/health", runner.Health)
+    port := cfg.Port
+    srv := &http.Server{
+        Addr:              ":" + port,
+        Handler:           mux,
+        ReadHeaderTimeout: 5 * time.Second,
+    }
+
+    go func() {
+        if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            log.Fatal(err)
+        }
+    }()
+
+    done := make(chan struct{})
+    go func() {
+        runner.Run(ctx)
+        close(done)
+    }()
+    log.Println("🚀 Worker running, health on http://localhost:" + port + "/health")
+
+    <-ctx.Done()
+    stop()
+    log.Println("Shutting down (Ctrl+C again to force)")
+
+    // Running tasks see the cancelled context and get SHUTDOWN_TIMEOUT to return
+    shutdown, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+    defer cancel()
+    srv.Shutdown(shutdown)
+    select {
+    case <-done:
+    case <-shutdown.Done():
+        log.Println("shutdown: tasks still running, exiting anyway")
+    }
+}
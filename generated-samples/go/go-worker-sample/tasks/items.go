@@ -0,0 +1,41 @@
+package tasks
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "myapp/logging"
+    "myapp/worker"
+)
+
+// ItemsReport logs how many items the API at apiURL holds. It asks for a
+// single-item page and reads the total, so it stays cheap however many
+// items there are.
+func ItemsReport(client *http.Client, apiURL string) worker.Task {
+    url := strings.TrimSuffix(apiURL, "/") + "/api/v1/items?per_page=1"
+    return func(ctx context.Context) error {
+        req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+        if err != nil {
+            return err
+        }
+        resp, err := client.Do(req)
+        if err != nil {
+            return err
+        }
+        defer resp.Body.Close()
+        if resp.StatusCode != http.StatusOK {
+            return fmt.Errorf("GET %s: %s", url, resp.Status)
+        }
+
+        var page struct {
+            Total int `json:"total"`
+        }
+        if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+            return fmt.Errorf("GET %s: %w", url, err)
+        }
+        logging.Info(ctx, "items report", "total", page.Total)
+        return nil
+    }
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "sync"
+    "time"
+    "myapp/logging"
+)
+
+// Task is one unit of periodic work. A failed run is logged and the task
+// runs again at its next tick.
+type Task func(ctx context.Context) error
+
+type job struct {
+    name  string
+    every time.Duration
+    run   Task
+}
+
+// Status is the outcome of a task's latest run.
+type Status struct {
+    LastRun  time.Time `json:"last_run"`
+    Duration string    `json:"duration"`
+    Error    string    `json:"error,omitempty"`
+}
+
+// Runner runs registered tasks, each on its own interval, until its
+// context is cancelled.
+type Runner struct {
+    jobs []job
+
+    mu     sync.Mutex
+    status map[string]Status
+}
+
+func New() *Runner {
+    return &Runner{status: map[string]Status{}}
+}
+
+// Every registers task to run once at startup and then every interval.
+// Runs of one task never overlap: a slow run delays the next tick.
+func (r *Runner) Every(name string, every time.Duration, task Task) {
+    r.jobs = append(r.jobs, job{name: name, every: every, run: task})
+}
+
+// Run starts every task and blocks until ctx is cancelled and the runs in
+// flight have returned. Tasks get ctx, so cancellation also aborts them.
+func (r *Runner) Run(ctx context.Context) {
+    var wg sync.WaitGroup
+    for _, j := range r.jobs {
+        wg.Add(1)
+        go func(j job) {
+            defer wg.Done()
+            r.loop(ctx, j)
+        }(j)
+    }
+    wg.Wait()
+}
+
+func (r *Runner) loop(ctx context.Context, j job) {
+    ticker := time.NewTicker(j.every)
+    defer ticker.Stop()
+    for {
+        r.runOnce(ctx, j)
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (r *Runner) runOnce(ctx context.Context, j job) {
+    start := time.Now()
+    err := j.run(ctx)
+    status := Status{LastRun: start, Duration: time.Since(start).String()}
+    if err != nil && ctx.Err() == nil {
+        status.Error = err.Error()
+        logging.Error(ctx, "task failed", "task", j.name, "err", err)
+    }
+
+    r.mu.Lock()
+    r.status[j.name] = status
+    r.mu.Unlock()
+}
+
+// Health serves GET /health: the worker is up, with each task's latest
+// run (tasks that have not run yet are omitted).
+func (r *Runner) Health(w http.ResponseWriter, _ *http.Request) {
+    r.mu.Lock()
+    tasks := make(map[string]Status, len(r.status))
+    for name, status := range r.status {
+        tasks[name] = status
+    }
+    r.mu.Unlock()
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(map[string]any{"status": "ok", "tasks": tasks})
+}
@@ -0,0 +1,8 @@
+"""python -m app: serve with uvicorn on PORT, reloading in development."""
+
+import uvicorn
+
+from app.config import get_settings
+
+settings = get_settings()
+uvicorn.run("app.main:app", host="0.0.0.0", port=settings.port, reload=settings.development)
@@ -0,0 +1,28 @@
+"""Settings from the environment and .env (see .env.example)."""
+
+from functools import lru_cache
+
+from pydantic_settings import BaseSettings, SettingsConfigDict
+
+
+class Settings(BaseSettings):
+    model_config = SettingsConfigDict(env_file=".env", extra="ignore")
+
+    app_name: str = "python-fastapi-app"
+    port: int = 8000
+    environment: str = "production"
+
+    database_url: str = "sqlite:///./app.db"
+    create_tables: bool = True
+
+    page_size: int = 20
+    max_page_size: int = 100
+
+    @property
+    def development(self) -> bool:
+        return self.environment == "development"
+
+
+@lru_cache
+def get_settings() -> Settings:
+    return Settings()
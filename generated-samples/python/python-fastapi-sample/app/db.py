@@ -0,0 +1,35 @@
+"""The database engine and the per-request session."""
+
+from collections.abc import Iterator
+
+from fastapi import Request
+from sqlalchemy import Engine
+from sqlalchemy.pool import StaticPool
+from sqlmodel import Session, SQLModel, create_engine
+
+from app.config import Settings
+
+
+def make_engine(settings: Settings) -> Engine:
+    url = settings.database_url
+    kwargs = {"echo": settings.development, "pool_pre_ping": True}
+    if url.startswith("sqlite"):
+        # SQLite connections are used from FastAPI's threadpool; an
+        # in-memory database (sqlite://) must also stay on one connection
+        kwargs["connect_args"] = {"check_same_thread": False}
+        if url in ("sqlite://", "sqlite:///:memory:"):
+            kwargs["poolclass"] = StaticPool
+    return create_engine(url, **kwargs)
+
+
+def create_tables(engine: Engine) -> None:
+    # Importing the models registers their tables on the metadata
+    import app.models  # noqa: F401
+
+    SQLModel.metadata.create_all(engine)
+
+
+def get_session(request: Request) -> Iterator[Session]:
+    """FastAPI dependency: one session per request on the app's engine."""
+    with Session(request.app.state.engine) as session:
+        yield session
@@ -0,0 +1,32 @@
+"""The FastAPI application. uvicorn serves app (uvicorn app.main:app);
+tests build their own with create_app and a test database."""
+
+from contextlib import asynccontextmanager
+
+from fastapi import FastAPI
+
+from app.config import Settings, get_settings
+from app.db import create_tables, make_engine
+from app.routes import health, items
+
+
+def create_app(settings: Settings | None = None) -> FastAPI:
+    settings = settings or get_settings()
+    engine = make_engine(settings)
+
+    @asynccontextmanager
+    async def lifespan(app: FastAPI):
+        if settings.create_tables:
+            create_tables(engine)
+        yield
+        engine.dispose()
+
+    app = FastAPI(title=settings.app_name, lifespan=lifespan)
+    app.state.settings = settings
+    app.state.engine = engine
+    app.include_router(health.router)
+    app.include_router(items.router)
+    return app
+
+
+app = create_app()
@@ -0,0 +1,54 @@
+"""The item table and the request/response shapes of the API."""
+
+import uuid
+from datetime import datetime, timezone
+
+from pydantic import field_validator
+from sqlmodel import Field, SQLModel
+
+
+class ItemBase(SQLModel):
+    title: str = Field(min_length=1, max_length=200)
+    description: str = Field(default="", max_length=5000)
+
+    @field_validator("title", "description", mode="before")
+    @classmethod
+    def strip(cls, value):
+        return value.strip() if isinstance(value, str) else value
+
+
+class Item(ItemBase, table=True):
+    __tablename__ = "items"
+
+    id: uuid.UUID = Field(default_factory=uuid.uuid4, primary_key=True)
+    created_at: datetime = Field(default_factory=lambda: datetime.now(timezone.utc), index=True)
+    updated_at: datetime = Field(default_factory=lambda: datetime.now(timezone.utc))
+
+
+class ItemCreate(ItemBase):
+    pass
+
+
+class ItemUpdate(SQLModel):
+    """A partial update: only the fields sent are changed."""
+
+    title: str | None = Field(default=None, min_length=1, max_length=200)
+    description: str | None = Field(default=None, max_length=5000)
+
+    @field_validator("title", "description", mode="before")
+    @classmethod
+    def strip(cls, value):
+        return value.strip() if isinstance(value, str) else value
+
+
+class ItemRead(ItemBase):
+    id: uuid.UUID
+    created_at: datetime
+    updated_at: datetime
+
+
+class ItemPage(SQLModel):
+    items: list[ItemRead]
+    total: int
+    offset: int
+    limit: int
@@ -0,0 +1,25 @@
+from fastapi import APIRouter, Request, Response, status
+from sqlalchemy import text
+
+router = APIRouter(tags=["health"])
+
+
+@router.get("/healthz")
+def live(response: Response):
+    """Liveness: answers whenever the process can. It checks nothing
+    downstream, so a database outage never gets the app restarted."""
+    response.headers["Cache-Control"] = "no-store"
+    return {"status": "ok"}
+
+
+@router.get("/readyz")
+def ready(request: Request, response: Response):
+    """Readiness: 503 while the database is unreachable."""
+    response.headers["Cache-Control"] = "no-store"
+    try:
+        with request.app.state.engine.connect() as connection:
+            connection.execute(text("SELECT 1"))
+    except Exception as error:  # noqa: BLE001 - any failure means not ready
+        response.status_code = status.HTTP_503_SERVICE_UNAVAILABLE
+        return {"status": "unhealthy", "checks": {"database": {"status": "down", "error": str(error)}}}
+    return {"status": "ready", "checks": {"database": {"status": "up"}}}
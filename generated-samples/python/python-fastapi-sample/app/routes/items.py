@@ -0,0 +1,63 @@
+import uuid
+from typing import Annotated
+
+from fastapi import APIRouter, Depends, HTTPException, Query, Request, Response, status
+from sqlmodel import Session
+
+from app.db import get_session
+from app.models import ItemCreate, ItemPage, ItemRead, ItemUpdate
+from app.store import ItemStore, NotFoundError
+
+router = APIRouter(prefix="/api/items", tags=["items"])
+
+
+def get_store(session: Annotated[Session, Depends(get_session)]) -> ItemStore:
+    return ItemStore(session)
+
+
+Store = Annotated[ItemStore, Depends(get_store)]
+
+
+def find(store: ItemStore, item_id: uuid.UUID):
+    try:
+        return store.get(item_id)
+    except NotFoundError:
+        raise HTTPException(status.HTTP_404_NOT_FOUND, "Item not found") from None
+
+
+@router.get("", response_model=ItemPage)
+def list_items(
+    store: Store,
+    request: Request,
+    q: Annotated[str, Query(max_length=200)] = "",
+    offset: Annotated[int, Query(ge=0)] = 0,
+    limit: Annotated[int | None, Query(ge=1)] = None,
+):
+    settings = request.app.state.settings
+    limit = min(limit or settings.page_size, settings.max_page_size)
+    items, total = store.list(q=q, offset=offset, limit=limit)
+    return ItemPage(items=[ItemRead.model_validate(item) for item in items], total=total, offset=offset, limit=limit)
+
+
+@router.post("", response_model=ItemRead, status_code=status.HTTP_201_CREATED)
+def create_item(data: ItemCreate, store: Store, response: Response):
+    item = store.create(data)
+    response.headers["Location"] = f"/api/items/{item.id}"
+    return item
+
+
+@router.get("/{item_id}", response_model=ItemRead)
+def get_item(item_id: uuid.UUID, store: Store):
+    return find(store, item_id)
+
+
+@router.patch("/{item_id}", response_model=ItemRead)
+def update_item(item_id: uuid.UUID, data: ItemUpdate, store: Store):
+    find(store, item_id)
+    return store.update(item_id, data)
+
+
+@router.delete("/{item_id}", status_code=status.HTTP_204_NO_CONTENT)
+def delete_item(item_id: uuid.UUID, store: Store):
+    find(store, item_id)
+    store.delete(item_id)
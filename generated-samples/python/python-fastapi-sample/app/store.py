@@ -0,0 +1,58 @@
+"""Item persistence. Routes go through ItemStore rather than the session,
+so queries live in one place."""
+
+import uuid
+from datetime import datetime, timezone
+
+from sqlalchemy import func, or_
+from sqlmodel import Session, col, select
+
+from app.models import Item, ItemCreate, ItemUpdate
+
+
+class NotFoundError(Exception):
+    def __init__(self, item_id: uuid.UUID):
+        super().__init__(f"item {item_id} not found")
+        self.item_id = item_id
+
+
+class ItemStore:
+    def __init__(self, session: Session):
+        self.session = session
+
+    def list(self, q: str = "", offset: int = 0, limit: int = 20) -> tuple[list[Item], int]:
+        """One page of items, newest first, whose title or description
+        contains q (case-insensitive), and the number of matches."""
+        query = select(Item)
+        if q.strip():
+            pattern = f"%{q.strip()}%"
+            query = query.where(or_(col(Item.title).ilike(pattern), col(Item.description).ilike(pattern)))
+        total = self.session.exec(select(func.count()).select_from(query.subquery())).one()
+        items = self.session.exec(query.order_by(col(Item.created_at).desc()).offset(offset).limit(limit)).all()
+        return list(items), total
+
+    def get(self, item_id: uuid.UUID) -> Item:
+        item = self.session.get(Item, item_id)
+        if item is None:
+            raise NotFoundError(item_id)
+        return item
+
+    def create(self, data: ItemCreate) -> Item:
+        item = Item.model_validate(data)
+        self.session.add(item)
+        self.session.commit()
+        self.session.refresh(item)
+        return item
+
+    def update(self, item_id: uuid.UUID, data: ItemUpdate) -> Item:
+        item = self.get(item_id)
+        item.sqlmodel_update(data.model_dump(exclude_unset=True, exclude_none=True))
+        item.updated_at = datetime.now(timezone.utc)
+        self.session.add(item)
+        self.session.commit()
+        self.session.refresh(item)
+        return item
+
+    def delete(self, item_id: uuid.UUID) -> None:
+        self.session.delete(self.get(item_id))
+        self.session.commit()
@@ -0,0 +1,58 @@
+import express from 'express';
+import nunjucks from 'nunjucks';
+import path from 'node:path';
+import { fileURLToPath } from 'node:url';
+import { itemRoutes } from './routes/items.js';
+import { healthRoutes } from './routes/health.js';
+
+const root = path.join(path.dirname(fileURLToPath(import.meta.url)), '..');
+
+// Build the Express app. server.js listens on it; tests call it with their
+// own config and store.
+export function createApp({ config, store }) {
+  const app = express();
+  app.disable('x-powered-by');
+
+  const views = nunjucks.configure(path.join(root, 'views'), {
+    autoescape: true,
+    express: app,
+    watch: false,
+    noCache: config.env === 'development'
+  });
+  views.addGlobal('basePath', config.basePath);
+  app.set('view engine', 'njk');
+
+  app.use((req, res, next) => {
+    res.set({ 'X-Content-Type-Options': 'nosniff', 'X-Frame-Options': 'DENY' });
+    // Links and hx-* URLs go through path() so BASE_PATH is honoured
+    res.locals.path = url => `${config.basePath}${url}`;
+    next();
+  });
+
+  const routes = express.Router();
+  routes.use('/static', express.static(path.join(root, 'public'), { maxAge: config.env === 'production' ? '1h' : 0 }));
+  routes.use(express.urlencoded({ extended: false, limit: config.formMaxBody }));
+  routes.use(healthRoutes(store));
+  routes.use(itemRoutes(store, config));
+  app.use(config.basePath || '/', routes);
+
+  app.use((req, res) => {
+    res.status(404).render('error.njk', { message: 'Page not found' });
+  });
+
+  // Errors thrown by handlers: logged in full, shown to the user only in
+  // development
+  app.use((err, req, res, next) => {
+    const status = err.status || err.statusCode || 500;
+    if (status >= 500) {
+      console.error(`${req.method} ${req.originalUrl}:`, err);
+    }
+    if (res.headersSent) {
+      return next(err);
+    }
+    const message = status < 500 || config.env === 'development' ? err.message : 'Something went wrong';
+    res.status(status).render('error.njk', { message });
+  });
+
+  return app;
+}
@@ -0,0 +1,29 @@
+// Settings from the environment (.env is loaded by server.js). Invalid
+// values fail startup rather than falling back silently.
+export function loadConfig(env = process.env) {
+  return {
+    port: integer(env, 'PORT', 3000),
+    env: env.NODE_ENV || 'production',
+    basePath: basePath(env.BASE_PATH || ''),
+    shutdownTimeout: integer(env, 'SHUTDOWN_TIMEOUT', 10000),
+    formMaxBody: env.FORM_MAX_BODY || '1mb',
+    pageSize: integer(env, 'PAGE_SIZE', 20)
+  };
+}
+
+function integer(env, name, fallback) {
+  const value = env[name];
+  if (value === undefined || value === '') {
+    return fallback;
+  }
+  if (!/^\d+$/.test(value)) {
+    throw new Error(`${name} must be a whole number, got "${value}"`);
+  }
+  return Number(value);
+}
+
+// "/app/" and "app" both mean "/app"; "/" means the root
+function basePath(value) {
+  const trimmed = value.replace(/^\/+|\/+$/g, '');
+  return trimmed ? `/${trimmed}` : '';
+}
@@ -0,0 +1,23 @@
+import express from 'express';
+
+// /healthz answers whenever the process can (liveness); /readyz also
+// checks the store and answers 503 while it is unreachable (readiness).
+export function healthRoutes(store) {
+  const router = express.Router();
+
+  router.get('/healthz', (req, res) => {
+    res.set('Cache-Control', 'no-store').json({ status: 'ok' });
+  });
+
+  router.get('/readyz', async (req, res) => {
+    res.set('Cache-Control', 'no-store');
+    try {
+      await store.ping();
+      res.json({ status: 'ready', checks: { store: { status: 'up' } } });
+    } catch (error) {
+      res.status(503).json({ status: 'unhealthy', checks: { store: { status: 'down', error: error.message } } });
+    }
+  });
+
+  return router;
+}
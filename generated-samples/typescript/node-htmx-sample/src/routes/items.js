@@ -0,0 +1,94 @@
+import express from 'express';
+import { NotFoundError, validateItem } from '../store.js';
+
+// The item pages and the fragments htmx swaps into them. Requests from
+// htmx (HX-Request) get the fragment; the same URL opened directly gets
+// the full page, so every view can be bookmarked. Express 5 hands
+// rejected handlers to the error middleware below.
+export function itemRoutes(store, config) {
+  const router = express.Router();
+  const fragment = req => req.get('HX-Request') === 'true';
+
+  router.get('/', (req, res) => {
+    res.render('index.njk');
+  });
+
+  router.get('/items', async (req, res) => {
+    const list = await listItems(store, config, req.query);
+    if (!fragment(req)) {
+      return res.render('index.njk', { ...list, listed: true });
+    }
+    res.render('items/_list.njk', list);
+  });
+
+  router.post('/items', async (req, res) => {
+    const fields = itemFields(req.body);
+    const errors = validateItem(fields);
+    if (Object.keys(errors).length > 0) {
+      if (!fragment(req)) {
+        return res.status(422).render('index.njk', { item: fields, errors });
+      }
+      // The form targets the list; swap the form itself instead
+      res.set({ 'HX-Retarget': '#new-item', 'HX-Reswap': 'outerHTML' });
+      return res.status(422).render('items/_form.njk', { item: fields, errors });
+    }
+
+    await store.create(fields);
+    if (!fragment(req)) {
+      return res.redirect(303, res.locals.path('/'));
+    }
+    res.status(201).render('items/_list.njk', await listItems(store, config, {}));
+  });
+
+  router.get('/items/:id', async (req, res) => {
+    const item = await store.get(req.params.id);
+    res.render(fragment(req) ? 'items/_detail.njk' : 'items/detail.njk', { item });
+  });
+
+  router.get('/items/:id/edit', async (req, res) => {
+    const item = await store.get(req.params.id);
+    res.render('items/_edit.njk', { item, errors: {} });
+  });
+
+  router.put('/items/:id', async (req, res) => {
+    const fields = itemFields(req.body);
+    const errors = validateItem(fields);
+    if (Object.keys(errors).length > 0) {
+      return res.status(422).render('items/_edit.njk', { item: { ...fields, id: req.params.id }, errors });
+    }
+    const item = await store.update(req.params.id, fields);
+    res.render('items/_item.njk', { item });
+  });
+
+  router.delete('/items/:id', async (req, res) => {
+    await store.delete(req.params.id);
+    // An empty 200 makes htmx swap the row away
+    res.send('');
+  });
+
+  // Unknown items are 404s; anything else goes to the app's error handler
+  router.use((err, req, res, next) => {
+    if (!(err instanceof NotFoundError)) {
+      return next(err);
+    }
+    res.status(404).render('error.njk', { message: 'Item not found' });
+  });
+
+  return router;
+}
+
+function itemFields(body = {}) {
+  return {
+    title: String(body.title ?? ''),
+    description: String(body.description ?? '')
+  };
+}
+
+// One page of items matching q, with what the pager needs
+async function listItems(store, config, { q = '', page = '1' }) {
+  const perPage = config.pageSize;
+  const current = Math.max(1, Number.parseInt(page, 10) || 1);
+  const { items, total } = await store.list({ q: String(q), offset: (current - 1) * perPage, limit: perPage });
+  const pages = perPage > 0 ? Math.max(1, Math.ceil(total / perPage)) : 1;
+  return { items, q, page: current, pages };
+}
@@ -0,0 +1,28 @@
+import 'dotenv/config';
+import { loadConfig } from './config.js';
+import { createApp } from './app.js';
+import { MemoryStore } from './store.js';
+
+const config = loadConfig();
+const store = new MemoryStore();
+await store.create({ title: 'Welcome', description: 'Edit or delete this item, or add your own.' });
+
+const app = createApp({ config, store });
+const server = app.listen(config.port, () => {
+  console.log(`Listening on http://localhost:${config.port}${config.basePath}/`);
+});
+
+// Stop accepting connections on SIGINT/SIGTERM and let open requests
+// finish, up to SHUTDOWN_TIMEOUT
+function shutdown(signal) {
+  console.log(`${signal} received, shutting down`);
+  setTimeout(() => {
+    console.error('Shutdown timed out, exiting');
+    process.exit(1);
+  }, config.shutdownTimeout).unref();
+  server.close(error => process.exit(error ? 1 : 0));
+  server.closeIdleConnections();
+}
+
+process.once('SIGINT', shutdown);
+process.once('SIGTERM', shutdown);
@@ -0,0 +1,69 @@
+import { randomUUID } from 'node:crypto';
+
+export class NotFoundError extends Error {
+  constructor(id) {
+    super(`item ${id} not found`);
+    this.name = 'NotFoundError';
+  }
+}
+
+// Check the fields of a new or edited item. Returns a map of field name
+// to message, empty when the item is valid.
+export function validateItem({ title = '', description = '' }) {
+  const errors = {};
+  if (title.trim() === '') {
+    errors.title = 'Title is required';
+  } else if (title.length > 200) {
+    errors.title = 'Title must be at most 200 characters';
+  }
+  if (description.length > 5000) {
+    errors.description = 'Description must be at most 5000 characters';
+  }
+  return errors;
+}
+
+// MemoryStore keeps items in process, newest first. Its methods are async
+// so a database-backed store can replace it without touching the routes.
+export class MemoryStore {
+  #items = new Map();
+
+  async list({ q = '', offset = 0, limit = 0 } = {}) {
+    const needle = q.trim().toLowerCase();
+    const matches = [...this.#items.values()]
+      .reverse()
+      .filter(item => !needle || `${item.title}\n${item.description}`.toLowerCase().includes(needle));
+    return {
+      items: limit > 0 ? matches.slice(offset, offset + limit) : matches,
+      total: matches.length
+    };
+  }
+
+  async get(id) {
+    const item = this.#items.get(id);
+    if (!item) {
+      throw new NotFoundError(id);
+    }
+    return item;
+  }
+
+  async create({ title, description = '' }) {
+    const item = { id: randomUUID(), title: title.trim(), description: description.trim(), createdAt: new Date() };
+    this.#items.set(item.id, item);
+    return item;
+  }
+
+  async update(id, { title, description = '' }) {
+    const item = { ...await this.get(id), title: title.trim(), description: description.trim() };
+    this.#items.set(id, item);
+    return item;
+  }
+
+  async delete(id) {
+    if (!this.#items.delete(id)) {
+      throw new NotFoundError(id);
+    }
+  }
+
+  // Readiness check; a database store would query its connection here
+  async ping() {}
+}
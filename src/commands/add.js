@@ -0,0 +1,213 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { featureChoices, stackFlags } from './create.js';
+import { printSteps, hasUncommittedChanges } from './upgrade.js';
+import { readProjectManifest, saveProjectManifest, templateVersion, projectManifestFile } from '../utils/project-manifest.js';
+import { manifestTemplate, regenerate, planUpgrade, applyUpgrade } from '../utils/upgrade.js';
+import { isPluginRef, addPluginFeature } from '../utils/plugins.js';
+
+// Turn on a feature (docker, ci, ...) or a stack option (auth,
+// observability, ...) in a generated project. The project is regenerated
+// from .stackapp.yaml twice, as recorded and with the feature, and the
+// difference is merged into it the way upgrade merges a new template
+// version, so changes made since generation are kept.
+export async function addFeature(feature, value, options = {}) {
+  const root = path.resolve(options.dir || '.');
+  const fail = message => {
+    console.log(chalk.red(`\n❌ ${message}`));
+    process.exit(1);
+  };
+
+  if (isPluginRef(feature)) {
+    await addFromPlugin(root, feature, value, options, fail);
+    return;
+  }
+
+  let manifest;
+  let templateConfig;
+  let addition;
+  try {
+    manifest = await readProjectManifest(root);
+    templateConfig = await manifestTemplate(manifest, manifest.source && manifest.source.ref);
+    addition = withFeature(manifest, templateConfig, feature, value);
+  } catch (error) {
+    fail(error.message);
+  }
+
+  if (addition.already) {
+    console.log(chalk.green(`\n✅ ${manifest.name} already has ${addition.label}.`));
+    return;
+  }
+  if (!options.force && !options.dryRun && await hasUncommittedChanges(root)) {
+    fail('The project has uncommitted changes. Commit or stash them first so the change can be reviewed and undone (or pass --force).');
+  }
+
+  // The merge base is the recorded version regenerated, so it has to be
+  // the version the template is at now
+  const version = await templateVersion(templateConfig);
+  if (version !== manifest.version) {
+    fail(`${manifest.name} was generated from ${manifest.template} ${manifest.version}, which is now at ${version}. Run "create-stack-app upgrade" first.`);
+  }
+
+  const temps = [];
+  try {
+    const spinner = ora(`Regenerating ${manifest.name} with ${addition.label}...`).start();
+    let base;
+    let next;
+    try {
+      base = await regenerate(manifest, templateConfig);
+      temps.push(base.tempRoot);
+      next = await regenerate({ ...manifest, features: addition.features, options: addition.options }, templateConfig);
+      temps.push(next.tempRoot);
+    } catch (error) {
+      spinner.fail(chalk.red(error.message));
+      process.exit(1);
+    }
+    spinner.stop();
+
+    const { steps, files } = await planUpgrade(root, manifest, next.projectPath, base.projectPath);
+
+    // go mod tidy rewrites the require block, so line merges of go.mod
+    // would conflict; the requirements the feature brings are added instead
+    const modules = steps.filter(step => step.action === 'merge' && path.basename(step.file) === 'go.mod');
+    modules.forEach(step => { step.reason = 'requirements added'; });
+
+    console.log(chalk.bold(`\nAdding ${addition.label} to ${manifest.name}`));
+    if (steps.length === 0) {
+      console.log(chalk.dim('   No file changes.'));
+    }
+
+    if (options.dryRun) {
+      printSteps(steps);
+      console.log(chalk.dim('\nDry run: nothing was written.'));
+      return;
+    }
+
+    await applyUpgrade(root, steps.filter(step => !modules.includes(step)), next.projectPath, {
+      ours: 'project',
+      base: `${manifest.template} ${manifest.version}`,
+      theirs: `${manifest.template} ${manifest.version} with ${addition.label}`
+    });
+    for (const step of modules) {
+      await addRequires(path.join(root, step.file), path.join(base.projectPath, step.file), path.join(next.projectPath, step.file));
+    }
+    printSteps(steps);
+
+    // The regenerated output is the base of the next upgrade or addition
+    await saveProjectManifest(root, { ...manifest, features: addition.features, options: addition.options, files });
+
+    const conflicts = steps.filter(step => step.action === 'conflict');
+    if (conflicts.length > 0) {
+      console.log(chalk.yellow(`\n⚠️  ${conflicts.length} file${conflicts.length === 1 ? '' : 's'} need manual resolution:`));
+      conflicts.forEach(step => console.log(`   ${chalk.white(step.file)} ${chalk.dim(`(${step.reason})`)}`));
+    } else {
+      console.log(chalk.green(`\n✅ Added ${addition.label}.`));
+    }
+    console.log(chalk.dim(`\nReview the changes (git diff), then commit them with ${projectManifestFile}.`));
+    if (next.hooks.length > 0) {
+      console.log(chalk.dim('Then re-run the template\'s setup to fetch new dependencies:'));
+      next.hooks.forEach(hook => console.log(`  ${chalk.cyan('$')} ${hook.run}`));
+    }
+  } finally {
+    for (const dir of temps) {
+      await fs.remove(dir);
+    }
+  }
+}
+
+// Plugin features (acme:audit-log) are applied by their plugin, which
+// edits the project in place. The changes aren't recorded in
+// .stackapp.yaml, so upgrade treats them like edits of your own.
+async function addFromPlugin(root, feature, value, options, fail) {
+  try {
+    await readProjectManifest(root);
+  } catch (error) {
+    fail(error.message);
+  }
+  if (!options.force && !options.dryRun && await hasUncommittedChanges(root)) {
+    fail('The project has uncommitted changes. Commit or stash them first so the change can be reviewed and undone (or pass --force).');
+  }
+
+  console.log(chalk.bold(`\nAdding ${feature} to ${path.basename(root)}`));
+  try {
+    await addPluginFeature(feature, value, root, options);
+  } catch (error) {
+    fail(error.message);
+  }
+  if (options.dryRun) {
+    console.log(chalk.dim('\nDry run: nothing was written.'));
+    return;
+  }
+  console.log(chalk.green(`\n✅ Added ${feature}.`));
+  console.log(chalk.dim('\nReview the changes (git diff), then commit them.'));
+}
+
+// The manifest's features and options with feature turned on. Features
+// are the --features values; stack options take a value, defaulting to
+// the option's enabled choice or, failing that, its only non-default one.
+// Options already set to something else are left to be changed by hand.
+function withFeature(manifest, templateConfig, feature, value) {
+  // ci is both: a bare feature, or the stack option (add ci gitlab)
+  const key = stackFlags[feature];
+  const option = key && templateConfig.options && templateConfig.options[key];
+  if (featureChoices.some(choice => choice.value === feature) && !(value && option)) {
+    if (value) {
+      throw new Error(`${feature} takes no value`);
+    }
+    return {
+      label: feature,
+      features: manifest.features.includes(feature) ? manifest.features : [...manifest.features, feature],
+      options: manifest.options,
+      already: manifest.features.includes(feature)
+    };
+  }
+
+  if (!option) {
+    const available = new Set([
+      ...featureChoices.map(choice => choice.value),
+      ...Object.entries(stackFlags).filter(([, k]) => templateConfig.options && templateConfig.options[k]).map(([flag]) => flag)
+    ]);
+    throw new Error(`Unknown feature "${feature}" for ${templateConfig.name} (expected ${[...available].join(', ')})`);
+  }
+
+  const others = option.choices.filter(choice => choice !== option.default);
+  const choice = value || option.enabled || (others.length === 1 ? others[0] : undefined);
+  if (!choice) {
+    throw new Error(`Choose one: create-stack-app add ${feature} <${others.join('|')}>`);
+  }
+  if (!option.choices.includes(choice)) {
+    throw new Error(`Invalid ${feature} "${choice}" (expected ${option.choices.join(', ')})`);
+  }
+
+  const current = manifest.options[feature] || option.default;
+  if (current !== option.default && current !== choice) {
+    throw new Error(`The project already uses --${feature} ${current}; add only turns on options still at their default (${option.default}).`);
+  }
+  return {
+    label: `--${feature} ${choice}`,
+    features: manifest.features,
+    options: { ...manifest.options, [feature]: choice },
+    already: current === choice
+  };
+}
+
+// Add the requirements nextFile has over baseFile (both regenerated
+// go.mod files) to the project's go.mod, skipping modules it already
+// requires, directly or not.
+async function addRequires(projectFile, baseFile, nextFile) {
+  const requires = source => new Map([...source.matchAll(/^\s+(\S+)\s+(v\S+)/gm)].map(match => [match[1], `${match[1]} ${match[2]}`]));
+  let goMod = await fs.readFile(projectFile, 'utf8');
+  const base = requires(await fs.readFile(baseFile, 'utf8'));
+  const ours = requires(goMod);
+  const added = [...requires(await fs.readFile(nextFile, 'utf8'))]
+    .filter(([module]) => !base.has(module) && !ours.has(module))
+    .map(([, line]) => `    ${line}`);
+  if (added.length === 0) return;
+
+  goMod = /require \(\n/.test(goMod)
+    ? goMod.replace(/require \(\n/, match => `${match}${added.join('\n')}\n`)
+    : `${goMod.trimEnd()}\n\nrequire (\n${added.join('\n')}\n)\n`;
+  await fs.writeFile(projectFile, goMod);
+}
@@ -0,0 +1,53 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+
+// Scaffold api/vN+1 in a generated Go project by copying the latest version.
+// The copy starts with the same serialization and can then diverge freely.
+export async function scaffoldApiVersion(projectDir = '.') {
+  const root = path.resolve(projectDir);
+  const apiDir = path.join(root, 'api');
+
+  const versions = (await fs.pathExists(apiDir) ? await fs.readdir(apiDir) : [])
+    .map(name => /^v(\d+)$/.exec(name))
+    .filter(Boolean)
+    .map(match => Number(match[1]));
+
+  if (versions.length === 0) {
+    console.log(chalk.red(`\n❌ No api/vN packages found in ${root}.`));
+    process.exit(1);
+  }
+
+  const latest = Math.max(...versions);
+  const next = latest + 1;
+  const from = path.join(apiDir, `v${latest}`);
+  const to = path.join(apiDir, `v${next}`);
+
+  await fs.copy(from, to);
+
+  // Rename the package clause (and its doc comment) and the vN segment of
+  // route paths such as "/v2/items/". Nothing else: imports like
+  // github.com/go-chi/chi/v5 can carry the same vN.
+  const packageClause = new RegExp(`^(// Package |package )v${latest}\\b`, 'gm');
+  const routePath = new RegExp(`("/(?:[^"\\s]*/)?)v${latest}(?=[/"])`, 'g');
+  for (const file of await fs.readdir(to)) {
+    if (!file.endsWith('.go')) continue;
+    const filePath = path.join(to, file);
+    const source = await fs.readFile(filePath, 'utf8');
+    await fs.writeFile(filePath, source.replace(packageClause, `$1v${next}`).replace(routePath, `$1v${next}`));
+  }
+
+  const goMod = await fs.readFile(path.join(root, 'go.mod'), 'utf8').catch(() => '');
+  const modulePath = (/^module\s+(\S+)/m.exec(goMod) || [])[1] || 'myapp';
+
+  // Base the mount on the latest version's: /v2 in go-htmx, /api/v1 in go-rest
+  const mainGo = await fs.readFile(path.join(root, 'main.go'), 'utf8').catch(() => '');
+  const mount = new RegExp(`r\\.Mount\\("([^"]*)v${latest}", apiv${latest}\\.Routes\\(([^)]*)\\)\\)`).exec(mainGo);
+  const [prefix, args] = mount ? [mount[1], mount[2]] : ['/', 'itemStore'];
+  const mountLine = `r.Mount("${prefix}v${next}", apiv${next}.Routes(${args}))`;
+
+  console.log(chalk.green(`\n✅ Created api/v${next} from api/v${latest}`));
+  console.log(chalk.white('\nMount it in main.go:'));
+  console.log(chalk.cyan(`  import apiv${next} "${modulePath}/api/v${next}"`));
+  console.log(chalk.cyan(`  ${mountLine}\n`));
+}
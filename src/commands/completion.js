@@ -0,0 +1,471 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { templates } from '../config/templates.js';
+import { channels } from '../utils/self-update.js';
+import { taskRunners } from '../generators/tasks.js';
+
+export const shells = ['bash', 'zsh', 'fish', 'powershell'];
+
+// Values offered for positional arguments, by argument name
+const argumentValues = {
+  shell: shells,
+  kind: ['stack', 'project']
+};
+
+// Print the completion script for a shell, generated from the command tree
+// so new commands and options are picked up without touching the scripts.
+export function printCompletion(program, shell) {
+  const generators = { bash: bashCompletion, zsh: zshCompletion, fish: fishCompletion, powershell: powershellCompletion };
+  if (!generators[shell]) {
+    console.error(chalk.red(`\n❌ Unknown shell "${shell}". Choose one of: ${shells.join(', ')}`));
+    process.exit(1);
+  }
+  process.stdout.write(generators[shell](commandTree(program)));
+}
+
+// Print the man page, or write one page per command into a directory
+// (name.1, name-new.1, name-templates-list.1, ...) for packaging.
+export async function writeManPages(program, options = {}) {
+  const root = commandTree(program);
+  const version = program.version();
+  if (!options.output) {
+    process.stdout.write(manPage(root, root, version, true));
+    return;
+  }
+
+  const dir = path.resolve(options.output);
+  await fs.ensureDir(dir);
+  const pages = [];
+  const visit = (node) => {
+    pages.push(node);
+    node.commands.forEach(visit);
+  };
+  visit(root);
+  for (const node of pages) {
+    await fs.writeFile(path.join(dir, `${pageName(node)}.1`), manPage(node, root, version, false));
+  }
+  console.log(chalk.green(`✅ Wrote ${pages.length} man pages to ${dir}`));
+}
+
+// The command tree as plain data: every command with its path below the
+// program, arguments, options (with value hints) and visible subcommands.
+export function commandTree(cmd, parents = []) {
+  const names = [...parents, cmd.name()];
+  const options = cmd.options.filter(option => !option.hidden).map(option => ({
+    flags: option.flags,
+    short: option.short,
+    long: option.long,
+    description: option.description || '',
+    value: option.required || option.optional ? valueName(option.flags) : null,
+    optionalValue: Boolean(option.optional),
+    values: valueHints(option.long)
+  }));
+  options.push({ flags: '-h, --help', short: '-h', long: '--help', description: 'display help for command', value: null, values: null });
+
+  return {
+    name: cmd.name(),
+    program: names[0],
+    path: names.slice(1).join(' '),
+    description: cmd.description() || '',
+    usage: cmd.usage(),
+    args: (cmd.registeredArguments || cmd._args || []).map(arg => ({
+      name: arg.name(),
+      required: arg.required,
+      variadic: arg.variadic,
+      values: argumentValues[arg.name()] || null,
+      files: /dir|file|path/i.test(arg.name())
+    })),
+    options,
+    commands: cmd.commands.filter(sub => !sub._hidden).map(sub => commandTree(sub, names))
+  };
+}
+
+function valueName(flags) {
+  const match = flags.match(/[<[]([^>\]]+)[>\]]/);
+  return match ? match[1] : 'value';
+}
+
+// Template ids for --template/--stack, release channels, task runners, and the choices
+// of every stack option registered under a flag (merged across stacks).
+function valueHints(long) {
+  if (long === '--template' || long === '--stack') {
+    return Object.keys(templates);
+  }
+  if (long === '--channel') {
+    return channels;
+  }
+  if (long === '--task-runner') {
+    return Object.keys(taskRunners);
+  }
+  const choices = new Set();
+  for (const template of Object.values(templates)) {
+    for (const option of Object.values(template.options || {})) {
+      if (option.flag === long) {
+        option.choices.forEach(choice => choices.add(choice));
+      }
+    }
+  }
+  return choices.size ? [...choices] : null;
+}
+
+function allCommands(node) {
+  return node.commands.flatMap(sub => [sub, ...allCommands(sub)]);
+}
+
+function allOptions(node) {
+  const seen = new Map();
+  for (const command of [node, ...allCommands(node)]) {
+    for (const option of command.options) {
+      for (const name of [option.long, option.short].filter(Boolean)) {
+        if (!seen.has(name)) seen.set(name, option);
+      }
+    }
+  }
+  return seen;
+}
+
+function identifier(name) {
+  return name.replace(/[^A-Za-z0-9]/g, '_');
+}
+
+function words(node) {
+  return [
+    ...node.commands.map(sub => sub.name),
+    ...node.options.flatMap(option => [option.long, option.short].filter(Boolean)),
+    ...node.args.flatMap(arg => arg.values || [])
+  ];
+}
+
+function bashCompletion(root) {
+  const fn = `_${identifier(root.name)}`;
+  const options = allOptions(root);
+  const skip = [...options].filter(([, option]) => option.value && !option.optionalValue).map(([name]) => name);
+  const files = [...options].filter(([, option]) => option.value && !option.values).map(([name]) => name);
+  const hinted = new Map();
+  for (const [name, option] of options) {
+    if (!option.values) continue;
+    const key = option.values.join(' ');
+    hinted.set(key, [...(hinted.get(key) || []), name]);
+  }
+  const commands = allCommands(root);
+  const nodes = [root, ...commands];
+
+  const lines = [
+    `# bash completion for ${root.name}, generated by "${root.name} completion bash".`,
+    '# Load it in the current shell with',
+    `#   source <(${root.name} completion bash)`,
+    `# or install it as /etc/bash_completion.d/${root.name}.`,
+    `${fn}() {`,
+    '    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"',
+    '    local path="" word words="" files="" i',
+    '    for ((i = 1; i < COMP_CWORD; i++)); do',
+    '        word="${COMP_WORDS[i]}"',
+    '        case "$word" in',
+    `            ${skip.join('|')}) ((i++)); continue ;;`,
+    '        esac',
+    '        case "${path:+$path }$word" in',
+    `            ${commands.map(node => `"${node.path}"`).join('|')}) path="\${path:+$path }$word" ;;`,
+    '        esac',
+    '    done',
+    '',
+    '    case "$prev" in'
+  ];
+  for (const [values, names] of hinted) {
+    lines.push(`        ${names.join('|')}) COMPREPLY=($(compgen -W "${values}" -- "$cur")); return ;;`);
+  }
+  if (files.length) {
+    lines.push(`        ${files.join('|')}) COMPREPLY=($(compgen -f -- "$cur")); return ;;`);
+  }
+  lines.push('    esac', '', '    case "$path" in');
+  for (const node of nodes) {
+    const withFiles = node.args.some(arg => arg.files) ? '; files=1' : '';
+    lines.push(`        "${node.path}") words="${words(node).join(' ')}"${withFiles} ;;`);
+  }
+  lines.push(
+    '    esac',
+    '    COMPREPLY=($(compgen -W "$words" -- "$cur"))',
+    '    if [[ -n $files && $cur != -* ]]; then',
+    '        COMPREPLY+=($(compgen -f -- "$cur"))',
+    '    fi',
+    '}',
+    `complete -o filenames -F ${fn} ${root.name}`,
+    ''
+  );
+  return lines.join('\n');
+}
+
+function zshQuote(value) {
+  return `'${value.replace(/'/g, `'\\''`)}'`;
+}
+
+function zshDescription(value) {
+  return value.replace(/[[\]]/g, match => `\\${match}`).replace(/\s+/g, ' ');
+}
+
+function zshAction(name, values, files) {
+  if (values) return `:${name}:(${values.join(' ')})`;
+  return files ? `:${name}:_files` : `:${name}: `;
+}
+
+function zshOption(option) {
+  const names = [option.short, option.long].filter(Boolean);
+  let action = '';
+  if (option.value) {
+    action = (option.optionalValue ? ':' : '') + zshAction(option.value, option.values, true);
+  }
+  const spec = zshQuote(`[${zshDescription(option.description)}]${action}`);
+  if (names.length === 1) {
+    return zshQuote(names[0]) + spec;
+  }
+  return `${zshQuote(`(${names.join(' ')})`)}{${names.join(',')}}${spec}`;
+}
+
+function zshFunction(node, lines) {
+  const fn = `_${identifier([node.program, ...node.path.split(' ').filter(Boolean)].join('_'))}`;
+  const specs = node.options.map(zshOption);
+  lines.push(`${fn}() {`);
+
+  if (node.commands.length) {
+    lines.push(
+      '  local curcontext="$curcontext" state line',
+      '  typeset -A opt_args',
+      '  _arguments -C \\',
+      ...specs.map(spec => `    ${spec} \\`),
+      "    '1: :->command' \\",
+      "    '*:: :->args'",
+      '  case $state in',
+      '    command)',
+      '      local -a commands=(',
+      ...node.commands.map(sub => `        ${zshQuote(`${sub.name}:${sub.description.replace(/\s+/g, ' ')}`)}`),
+      '      )',
+      "      _describe -t commands 'command' commands ;;",
+      '    args)',
+      '      case $line[1] in',
+      ...node.commands.map(sub => `        ${sub.name}) ${fn}_${identifier(sub.name)} ;;`),
+      '      esac ;;',
+      '  esac',
+      '}',
+      ''
+    );
+    node.commands.forEach(sub => zshFunction(sub, lines));
+    return;
+  }
+
+  const args = node.args.map(arg => {
+    const prefix = arg.variadic ? '*' : arg.required ? '' : ':';
+    return zshQuote(prefix + zshAction(arg.name, arg.values, arg.files));
+  });
+  const all = [...specs, ...args];
+  lines.push(
+    '  _arguments \\',
+    ...all.map((spec, index) => `    ${spec}${index < all.length - 1 ? ' \\' : ''}`),
+    '}',
+    ''
+  );
+}
+
+function zshCompletion(root) {
+  const fn = `_${identifier(root.name)}`;
+  const lines = [
+    `#compdef ${root.name}`,
+    `# zsh completion for ${root.name}, generated by "${root.name} completion zsh".`,
+    `# Save it as _${root.name} in a directory on $fpath, or load it with`,
+    `#   source <(${root.name} completion zsh)`,
+    ''
+  ];
+  zshFunction(root, lines);
+  lines.push(
+    `if [ "$funcstack[1]" = "_${root.name}" ]; then`,
+    `  ${fn} "$@"`,
+    'else',
+    `  compdef ${fn} ${root.name}`,
+    'fi',
+    ''
+  );
+  return lines.join('\n');
+}
+
+function fishQuote(value) {
+  return `'${value.replace(/[\\']/g, match => `\\${match}`).replace(/\s+/g, ' ')}'`;
+}
+
+function fishCompletion(root) {
+  const prefix = `__${identifier(root.name)}`;
+  const commands = allCommands(root);
+  const lines = [
+    `# fish completion for ${root.name}, generated by "${root.name} completion fish".`,
+    `# Save it as ~/.config/fish/completions/${root.name}.fish, or load it with`,
+    `#   ${root.name} completion fish | source`,
+    '',
+    `set -g ${prefix}_commands ${commands.map(node => fishQuote(node.path)).join(' ')}`,
+    '',
+    '# The subcommand path typed so far, e.g. "templates list"',
+    `function ${prefix}_path`,
+    '    set -l path',
+    '    for token in (commandline -opc)[2..-1]',
+    '        set -l next (string trim -- "$path $token")',
+    `        if contains -- $next $${prefix}_commands`,
+    '            set path $next',
+    '        end',
+    '    end',
+    '    echo $path',
+    'end',
+    '',
+    `function ${prefix}_at`,
+    `    set -l path (${prefix}_path)`,
+    '    test "$path" = "$argv"',
+    'end',
+    '',
+    `complete -c ${root.name} -f`
+  ];
+
+  for (const node of [root, ...commands]) {
+    const condition = `-n ${fishQuote(`${prefix}_at ${node.path}`.trim())}`;
+    lines.push('');
+    for (const sub of node.commands) {
+      lines.push(`complete -c ${root.name} ${condition} -a ${sub.name} -d ${fishQuote(sub.description)}`);
+    }
+    for (const option of node.options) {
+      const parts = [`complete -c ${root.name}`, condition];
+      if (option.short) parts.push(`-s ${option.short.slice(1)}`);
+      if (option.long) parts.push(`-l ${option.long.slice(2)}`);
+      if (option.values) parts.push(`-x -a ${fishQuote(option.values.join(' '))}`);
+      else if (option.value) parts.push('-r -F');
+      parts.push(`-d ${fishQuote(option.description)}`);
+      lines.push(parts.join(' '));
+    }
+    for (const arg of node.args) {
+      if (arg.values) lines.push(`complete -c ${root.name} ${condition} -a ${fishQuote(arg.values.join(' '))}`);
+      else if (arg.files) lines.push(`complete -c ${root.name} ${condition} -F`);
+    }
+  }
+  lines.push('');
+  return lines.join('\n');
+}
+
+function psQuote(value) {
+  return `'${value.replace(/'/g, "''").replace(/\s+/g, ' ')}'`;
+}
+
+function powershellCompletion(root) {
+  const nodes = [root, ...allCommands(root)];
+  const hinted = [...allOptions(root)].filter(([, option]) => option.values);
+  const entry = (name, description) => `@{ n = ${psQuote(name)}; d = ${psQuote(description || name)} }`;
+
+  const lines = [
+    `# PowerShell completion for ${root.name}, generated by "${root.name} completion powershell".`,
+    '# Load it from your profile with',
+    `#   ${root.name} completion powershell | Out-String | Invoke-Expression`,
+    `Register-ArgumentCompleter -Native -CommandName ${psQuote(root.name)} -ScriptBlock {`,
+    '    param($wordToComplete, $commandAst, $cursorPosition)',
+    '',
+    '    $commands = @{'
+  ];
+  for (const node of nodes) {
+    const entries = [
+      ...node.commands.map(sub => entry(sub.name, sub.description)),
+      ...node.options.flatMap(option => [option.long, option.short].filter(Boolean).map(name => entry(name, option.description))),
+      ...node.args.flatMap(arg => (arg.values || []).map(value => entry(value, arg.name)))
+    ];
+    lines.push(`        ${psQuote(node.path)} = @(`);
+    entries.forEach((item, index) => lines.push(`            ${item}${index < entries.length - 1 ? ',' : ''}`));
+    lines.push('        )');
+  }
+  lines.push('    }', '    $values = @{');
+  for (const [name, option] of hinted) {
+    lines.push(`        ${psQuote(name)} = @(${option.values.map(psQuote).join(', ')})`);
+  }
+  lines.push(
+    '    }',
+    '',
+    '    $elements = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })',
+    '    if ($wordToComplete) {',
+    '        $elements = @($elements | Select-Object -First ($elements.Count - 1))',
+    '    }',
+    "    $path = ''",
+    '    foreach ($element in $elements) {',
+    "        $next = ($path + ' ' + $element).Trim()",
+    '        if ($commands.ContainsKey($next)) { $path = $next }',
+    '    }',
+    "    $previous = if ($elements.Count -gt 0) { $elements[-1] } else { '' }",
+    '',
+    '    if ($values.ContainsKey($previous)) {',
+    '        $values[$previous] | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {',
+    "            [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)",
+    '        }',
+    '        return',
+    '    }',
+    '    $commands[$path] | Where-Object { $_.n -like "$wordToComplete*" } | ForEach-Object {',
+    "        $type = if ($_.n.StartsWith('-')) { 'ParameterName' } else { 'ParameterValue' }",
+    '        [System.Management.Automation.CompletionResult]::new($_.n, $_.n, $type, $_.d)',
+    '    }',
+    '}',
+    ''
+  );
+  return lines.join('\n');
+}
+
+function pageName(node) {
+  return [node.program, ...node.path.split(' ').filter(Boolean)].join('-');
+}
+
+function roff(value) {
+  return value
+    .replace(/\\/g, '\\e')
+    .replace(/-/g, '\\-')
+    .replace(/^([.'])/gm, '\\&$1');
+}
+
+function manOptions(node) {
+  const lines = [];
+  for (const option of node.options) {
+    const names = [option.short, option.long].filter(Boolean).map(name => `\\fB${roff(name)}\\fR`).join(', ');
+    const value = option.value ? ` \\fI${option.optionalValue ? `[${option.value}]` : `<${option.value}>`}\\fR` : '';
+    lines.push('.TP', names + value, roff(option.description));
+  }
+  return lines;
+}
+
+// A roff man page for one command. The full page (stdout) documents every
+// command inline; the per-command pages link to each other under SEE ALSO.
+function manPage(node, root, version, full) {
+  const title = pageName(node);
+  const command = [node.program, node.path].filter(Boolean).join(' ');
+  const lines = [
+    `.TH "${roff(title.toUpperCase())}" "1" "" "${roff(`${root.name} ${version || ''}`.trim())}" "User Commands"`,
+    '.SH NAME',
+    `${roff(title)} \\- ${roff(node.description.split('\n')[0])}`,
+    '.SH SYNOPSIS',
+    `.B ${roff(command)}`,
+    roff(node.usage),
+    '.SH DESCRIPTION',
+    roff(node.description),
+    '.SH OPTIONS',
+    ...manOptions(node)
+  ];
+
+  if (full) {
+    lines.push('.SH COMMANDS');
+    for (const sub of allCommands(node)) {
+      lines.push(`.SS "${roff(`${sub.program} ${sub.path} ${sub.usage}`)}"`, roff(sub.description), ...manOptions(sub));
+    }
+  } else if (node.commands.length) {
+    lines.push('.SH COMMANDS');
+    for (const sub of node.commands) {
+      lines.push('.TP', `\\fB${roff(sub.name)}\\fR`, roff(sub.description));
+    }
+  }
+
+  if (!full) {
+    const related = [
+      ...(node.path ? [pageName({ program: node.program, path: node.path.split(' ').slice(0, -1).join(' ') })] : []),
+      ...node.commands.map(pageName)
+    ];
+    if (related.length) {
+      lines.push('.SH "SEE ALSO"', related.map(name => `\\fB${roff(name)}\\fR(1)`).join(', '));
+    }
+  }
+  lines.push('');
+  return lines.join('\n');
+}
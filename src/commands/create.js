@@ -4,12 +4,33 @@ import ora from 'ora';
 import boxen from 'boxen';
 import fs from 'fs-extra';
 import path from 'node:path';
+import os from 'node:os';
 import { execa } from 'execa';
 import { templates, categories, languages } from '../config/templates.js';
 import { generateProject } from '../generators/index.js';
+import { parseServices, defaultServices } from '../generators/monorepo.js';
+import { taskRunner } from '../generators/tasks.js';
+import { resolveRemoteTemplate } from '../utils/registry.js';
+import { isPluginRef, resolvePluginTemplate } from '../utils/plugins.js';
+import { takeHooks, runHooks } from '../utils/hooks.js';
+import { writeProjectManifest } from '../utils/project-manifest.js';
+import { planPlacement, printTree, showDiff } from '../utils/placement.js';
+
+// Additional features offered by the prompt and accepted by --features
+export const featureChoices = [
+  { name: 'Docker & Docker Compose', value: 'docker', checked: true },
+  { name: 'GitHub Actions CI/CD', value: 'ci', checked: true },
+  { name: 'ESLint/Prettier (if applicable)', value: 'linting', checked: true },
+  { name: 'Testing Setup (Jest/Pytest/etc)', value: 'testing', checked: true },
+  { name: 'Pre-commit Hooks', value: 'hooks', checked: false },
+  { name: 'VS Code Settings', value: 'vscode', checked: true }
+];
+
+// --layout values: one app, or a Go workspace of services (go-monorepo)
+const layouts = ['single', 'monorepo'];
 
 // Helper: Get project name from user input
-async function getProjectName(projectName) {
+export async function getProjectName(projectName) {
   if (projectName) return projectName;
   
   const nameAnswer = await inquirer.prompt([
@@ -27,6 +48,43 @@ async function getProjectName(projectName) {
   return nameAnswer.projectName;
 }
 
+// Stack flags and the template option each sets: --database is options.db.
+// Keys are the flags as typed; commander stores --response-cache as
+// options.responseCache (see flagValue).
+export const stackFlags = { database: 'db', auth: 'auth', logging: 'logging', log: 'log', css: 'css', bundler: 'bundler', frontend: 'frontend', 'theme-toggle': 'themeToggle', 'trailing-slash': 'trailingSlash', 'search-empty': 'searchEmpty', 'error-format': 'errorFormat', realtime: 'realtime', cache: 'cache', observability: 'observability', jobs: 'jobs', i18n: 'i18n', storage: 'storage', mailer: 'mailer', flags: 'flags', ratelimit: 'ratelimit', admin: 'admin', cleanup: 'cleanup', 'response-cache': 'responseCache', e2e: 'e2e', loadtest: 'loadtest', secrets: 'secrets', ci: 'ci' };
+
+// Helper: Check stack flags (--database, --auth, ...) against the template's
+// registered options and fill in defaults
+export function resolveStackOptions(templateConfig, options) {
+  const resolved = {};
+
+  for (const [flag, key] of Object.entries(stackFlags)) {
+    const option = templateConfig.options && templateConfig.options[key];
+    const given = flagValue(options, flag);
+    if (!option) {
+      if (given) {
+        console.log(chalk.yellow(`⚠️  ${templateConfig.name} has no --${flag} option; ignoring it.`));
+      }
+      continue;
+    }
+
+    // A bare flag (--ratelimit, --admin, --e2e) picks the option's enabled choice
+    const value = given === true ? option.enabled : given || option.default;
+    if (!option.choices.includes(value)) {
+      console.log(chalk.red(`\n❌ Invalid --${flag} "${value}" (expected ${option.choices.join(', ')}).`));
+      process.exit(1);
+    }
+    resolved[flag] = value;
+  }
+  return resolved;
+}
+
+// Helper: A stack flag's value, under its own name (the init wizard's
+// answers, manifests) or commander's camelCase one
+function flagValue(options, flag) {
+  return options[flag] ?? options[flag.replace(/-(\w)/g, (match, letter) => letter.toUpperCase())];
+}
+
 // Helper: Get template by language
 async function selectByLanguage() {
   const { language } = await inquirer.prompt([
@@ -112,26 +170,35 @@ async function selectAllTemplates() {
   return template;
 }
 
-// Helper: Select additional features
-async function selectFeatures() {
+// Helper: Select additional features (skipped when --features is given)
+async function selectFeatures(options) {
+  if (options.features !== undefined) {
+    return parseFeatures(options.features);
+  }
+
   const { features } = await inquirer.prompt([
     {
       type: 'checkbox',
       name: 'features',
       message: 'Select additional features (optional):',
-      choices: [
-        { name: 'Docker & Docker Compose', value: 'docker', checked: true },
-        { name: 'GitHub Actions CI/CD', value: 'ci', checked: true },
-        { name: 'ESLint/Prettier (if applicable)', value: 'linting', checked: true },
-        { name: 'Testing Setup (Jest/Pytest/etc)', value: 'testing', checked: true },
-        { name: 'Pre-commit Hooks', value: 'hooks', checked: false },
-        { name: 'VS Code Settings', value: 'vscode', checked: true }
-      ]
+      choices: featureChoices
     }
   ]);
   return features;
 }
 
+// Helper: Parse a comma-separated --features list ("none" for no extras)
+function parseFeatures(list) {
+  const features = list.split(',').map(f => f.trim()).filter(f => f && f !== 'none');
+  const known = featureChoices.map(choice => choice.value);
+  const unknown = features.filter(f => !known.includes(f));
+  if (unknown.length > 0) {
+    console.log(chalk.red(`\n❌ Unknown feature "${unknown[0]}" (expected ${known.join(', ')} or none).`));
+    process.exit(1);
+  }
+  return features;
+}
+
 // Helper: Confirm project creation
 async function confirmProjectCreation(projectName, templateConfig, features) {
   console.log('\n' + boxen(
@@ -189,79 +256,304 @@ export async function createProject(projectName, options = {}) {
     // Step 1: Get project name
     const finalProjectName = await getProjectName(projectName);
 
-    // Step 2: Choose selection method
-    const { selectionMethod } = await inquirer.prompt([
-      {
-        type: 'list',
-        name: 'selectionMethod',
-        message: 'How would you like to choose your stack?',
-        choices: [
-          { name: '🎯 Browse by Language', value: 'language' },
-          { name: '📦 Browse by Category', value: 'category' },
-          { name: '📋 See All Templates', value: 'all' }
-        ]
+    // Steps 2-3: Template selection (skipped when --template is given).
+    // Besides built-in ids, --template accepts registered template names,
+    // git references such as github.com/org/tpl@v1.2.0 and plugin
+    // templates (acme:api)
+    if (options.layout && !layouts.includes(options.layout)) {
+      console.log(chalk.red(`\n❌ Invalid --layout "${options.layout}" (expected ${layouts.join(', ')}).`));
+      process.exit(1);
+    }
+    if (options.layout === 'monorepo' && options.template && options.template !== 'go-monorepo') {
+      console.log(chalk.red('\n❌ --layout monorepo generates the go-monorepo template; pick its services with --services instead of --template.'));
+      process.exit(1);
+    }
+    let selectedTemplate = options.layout === 'monorepo' ? 'go-monorepo' : options.template;
+    let templateConfig = templates[selectedTemplate];
+    if (selectedTemplate && !templateConfig) {
+      const spinner = ora(`Resolving template ${selectedTemplate}...`).start();
+      try {
+        templateConfig = isPluginRef(selectedTemplate)
+          ? await resolvePluginTemplate(selectedTemplate)
+          : await resolveRemoteTemplate(selectedTemplate);
+      } catch (error) {
+        spinner.fail(chalk.red(error.message));
+        process.exit(1);
       }
-    ]);
+      spinner.stop();
+      if (!templateConfig) {
+        console.log(chalk.red(`\n❌ Unknown template "${selectedTemplate}". Run "templates list" to see available templates.`));
+        process.exit(1);
+      }
+    }
 
-    // Step 3: Template selection
-    let selectedTemplate;
-    if (selectionMethod === 'language') {
-      selectedTemplate = await selectByLanguage();
-    } else if (selectionMethod === 'category') {
-      selectedTemplate = await selectByCategory();
-    } else {
-      selectedTemplate = await selectAllTemplates();
+    if (!selectedTemplate) {
+      const { selectionMethod } = await inquirer.prompt([
+        {
+          type: 'list',
+          name: 'selectionMethod',
+          message: 'How would you like to choose your stack?',
+          choices: [
+            { name: '🎯 Browse by Language', value: 'language' },
+            { name: '📦 Browse by Category', value: 'category' },
+            { name: '📋 See All Templates', value: 'all' }
+          ]
+        }
+      ]);
+
+      if (selectionMethod === 'language') {
+        selectedTemplate = await selectByLanguage();
+      } else if (selectionMethod === 'category') {
+        selectedTemplate = await selectByCategory();
+      } else {
+        selectedTemplate = await selectAllTemplates();
+      }
     }
 
-    const templateConfig = templates[selectedTemplate];
+    templateConfig = templateConfig || templates[selectedTemplate];
+    const generatorOptions = {
+      ...resolveStackOptions(templateConfig, options),
+      module: options.module,
+      port: options.port,
+      author: options.author,
+      registry: options.registry,
+      taskRunner: options.taskRunner
+    };
+    try {
+      taskRunner(options.taskRunner);
+    } catch (error) {
+      console.log(chalk.red(`\n❌ ${error.message}`));
+      process.exit(1);
+    }
 
-    // Step 4: Additional options
-    const features = await selectFeatures();
+    // Monorepo services get their ports from their stacks
+    if (selectedTemplate === 'go-monorepo') {
+      if (options.port) {
+        console.log(chalk.yellow('⚠️  Monorepo services use their stacks\' default ports; ignoring --port.'));
+      }
+      try {
+        parseServices(options.services || defaultServices);
+      } catch (error) {
+        console.log(chalk.red(`\n❌ ${error.message}`));
+        process.exit(1);
+      }
+      generatorOptions.services = options.services || defaultServices;
+      delete generatorOptions.port;
+    } else if (options.services) {
+      console.log(chalk.yellow('⚠️  --services only applies to --layout monorepo; ignoring it.'));
+    }
 
-    // Step 5: Confirm
-    const confirm = await confirmProjectCreation(finalProjectName, templateConfig, features);
+    // Step 4: Additional options (--docker always adds the docker files)
+    const features = await selectFeatures(options);
+    if (options.docker && !features.includes('docker')) {
+      features.push('docker');
+    }
+
+    // Step 5: Confirm (skipped with --yes)
+    const confirm = options.yes || await confirmProjectCreation(finalProjectName, templateConfig, features);
 
     if (!confirm) {
       console.log(chalk.yellow('\n✋ Project creation cancelled.'));
       return;
     }
 
-    // Step 6: Generate project
-    const spinner = ora('Creating your project...').start();
-
-    const projectPath = path.join(process.cwd(), finalProjectName);
+    // Steps 6-8: Generate, install, report
+    await scaffoldProject(finalProjectName, selectedTemplate, templateConfig, features, generatorOptions, options);
 
-    // Check if directory exists
-    if (await fs.pathExists(projectPath)) {
-      spinner.fail();
-      console.log(chalk.red(`\n❌ Directory "${finalProjectName}" already exists!`));
-      return;
+  } catch (error) {
+    if (error.isTtyError) {
+      console.log(chalk.red('Prompt could not be rendered in this environment'));
+    } else {
+      console.log(chalk.red('\n❌ Error:'), error.message);
     }
+    process.exit(1);
+  }
+}
 
-    // Create project directory
-    await fs.ensureDir(projectPath);
+// Generate the project directory, install dependencies and print next
+// steps. Returns false when nothing was created.
+//
+// The project is generated into a temporary directory first. --dry-run
+// prints what would be written and stops there; a directory that already
+// exists and isn't empty is only written into with --diff, one file at a
+// time.
+export async function scaffoldProject(projectName, templateId, templateConfig, features, generatorOptions, options = {}) {
+  const spinner = ora('Creating your project...').start();
+
+  const projectPath = path.join(process.cwd(), projectName);
+  const existing = await fs.pathExists(projectPath) && (await fs.readdir(projectPath)).length > 0;
+
+  if (existing && !options.diff && !options.dryRun) {
+    spinner.fail();
+    console.log(chalk.red(`\n❌ Directory "${projectName}" already exists!`));
+    console.log(chalk.dim('   Preview with --dry-run, or write into it file by file with --diff.'));
+    return false;
+  }
+
+  // Generated under the project's name, which the files are derived from
+  const tempRoot = await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-new-'));
+  const generatedPath = path.join(tempRoot, projectName);
+  let hooks;
+  try {
+    await fs.ensureDir(generatedPath);
     spinner.text = 'Generating project files...';
 
     // Generate project based on template
-    await generateProject(projectPath, selectedTemplate, templateConfig, features);
+    await generateProject(generatedPath, templateId, templateConfig, features, generatorOptions);
+
+    // Record the template, choices and file hashes before hooks add their
+    // own files (go.sum, generated code), so "upgrade" can merge later versions
+    hooks = await takeHooks(generatedPath);
+    await writeProjectManifest(generatedPath, { templateId, templateConfig, features, generatorOptions });
+
+    const entries = await planPlacement(generatedPath, projectPath);
+    if (options.dryRun) {
+      spinner.stop();
+      printPlan(projectName, entries, existing, hooks);
+      return false;
+    }
+
+    if (existing) {
+      spinner.stop();
+      if (!(await placeFiles(generatedPath, projectPath, entries, options))) {
+        return false;
+      }
+    } else {
+      await fs.copy(generatedPath, projectPath);
+    }
+  } finally {
+    await fs.remove(tempRoot);
+  }
+
+  spinner.succeed(chalk.green('Project created successfully!'));
+
+  // Templates with a hooks.yaml set themselves up; the rest get the
+  // language's default install (unless skipped)
+  if (hooks.length > 0) {
+    await runProjectHooks(projectPath, templateConfig, hooks, options);
+  } else if (!options.skipInstall) {
+    await installProjectDependencies(projectPath, templateConfig);
+  }
+
+  displaySuccessMessage(projectName, templateConfig, features);
+  return true;
+}
 
-    spinner.succeed(chalk.green('Project created successfully!'));
+// --dry-run: the files that would be written and the hooks that would run
+function printPlan(projectName, entries, existing, hooks) {
+  console.log(chalk.bold(`\n${projectName}/`));
+  printTree(entries, existing);
+
+  const count = status => entries.filter(entry => entry.status === status).length;
+  const summary = existing
+    ? `${count('new')} new, ${count('changed')} changed, ${count('same')} unchanged`
+    : `${entries.length} files`;
+  console.log(chalk.dim(`\n   ${summary}`));
+  if (hooks.length > 0) {
+    console.log(chalk.bold('\nPost-generation hooks:'));
+    hooks.forEach(hook => console.log(`  ${chalk.cyan('$')} ${hook.run}`));
+  }
+  console.log(chalk.dim('\nDry run: nothing was written.'));
+}
 
-    // Step 7: Install dependencies (unless skipped)
-    if (!options.skipInstall) {
-      await installProjectDependencies(projectPath, templateConfig);
+// --diff: write the generated files into an existing directory. New files
+// are added, identical ones left as they are, and each changed file's diff
+// is shown with a prompt to overwrite or keep it. With --yes changed files
+// are kept. Returns false when the user quits; files already written stay.
+async function placeFiles(generatedPath, projectPath, entries, options) {
+  let all;
+  const kept = [];
+
+  for (const { file, status } of entries) {
+    const source = path.join(generatedPath, file);
+    const target = path.join(projectPath, file);
+    if (status === 'same') continue;
+
+    if (status === 'changed') {
+      let answer = all || (options.yes ? 'skip' : undefined);
+      if (!answer) {
+        console.log('');
+        await showDiff(target, source, file);
+        ({ answer } = await inquirer.prompt([
+          {
+            type: 'list',
+            name: 'answer',
+            message: `${file} differs from the template:`,
+            choices: [
+              { name: 'Overwrite', value: 'overwrite' },
+              { name: 'Keep mine', value: 'skip' },
+              { name: 'Overwrite this and all remaining', value: 'overwrite-all' },
+              { name: 'Keep this and all remaining', value: 'skip-all' },
+              { name: 'Quit', value: 'quit' }
+            ]
+          }
+        ]));
+        if (answer === 'quit') {
+          console.log(chalk.yellow('\nStopped; files written so far were kept.'));
+          return false;
+        }
+        if (answer.endsWith('-all')) {
+          answer = all = answer.slice(0, -'-all'.length);
+        }
+      }
+      if (answer === 'skip') {
+        kept.push(file);
+        continue;
+      }
     }
+    await fs.copy(source, target);
+  }
 
-    // Step 8: Success message
-    displaySuccessMessage(finalProjectName, templateConfig, features);
+  if (kept.length > 0) {
+    console.log(chalk.yellow(`\n⚠️  Kept ${kept.length} existing file${kept.length === 1 ? '' : 's'} that differ from the template:`));
+    kept.forEach(file => console.log(`   ${file}`));
+  }
+  return true;
+}
 
-  } catch (error) {
-    if (error.isTtyError) {
-      console.log(chalk.red('Prompt could not be rendered in this environment'));
-    } else {
-      console.log(chalk.red('\n❌ Error:'), error.message);
+// Run the template's post-generation hooks. Remote templates are third-party
+// code, so their commands are shown and confirmed first (unless --yes).
+// A failed hook leaves the project in place with a warning.
+async function runProjectHooks(projectPath, templateConfig, hooks, options) {
+  const listing = hooks.map(hook => `  ${chalk.cyan('$')} ${hook.run}${hook.optional ? chalk.dim(' (optional)') : ''}`).join('\n');
+
+  if (options.skipHooks) {
+    console.log(chalk.yellow('\n⏭️  Skipped post-generation hooks. Run them yourself when ready:'));
+    console.log(listing);
+    return;
+  }
+  if (options.hooksDryRun) {
+    console.log(chalk.bold('\nPost-generation hooks (not run):'));
+    console.log(listing);
+    return;
+  }
+  if (templateConfig.source && !options.yes) {
+    console.log(chalk.bold(`\n${templateConfig.name} wants to run:`));
+    console.log(listing);
+    const { run } = await inquirer.prompt([
+      { type: 'confirm', name: 'run', message: 'Run these commands?', default: false }
+    ]);
+    if (!run) {
+      return;
     }
-    process.exit(1);
+  }
+
+  const spinners = new Map();
+  try {
+    await runHooks(projectPath, hooks, (hook, status, detail) => {
+      if (status === 'run') {
+        spinners.set(hook, ora(hook.name).start());
+        return;
+      }
+      const spinner = spinners.get(hook);
+      if (status === 'ok') spinner.succeed();
+      if (status === 'skipped') spinner.warn(`${hook.name} ${chalk.dim(`(optional, failed: ${detail})`)}`);
+      if (status === 'failed') spinner.fail();
+    });
+  } catch (error) {
+    console.log(chalk.yellow(`\n⚠️  ${error.message}`));
+    console.log(chalk.yellow('The project was generated; finish setup by hand:'));
+    console.log(listing);
   }
 }
 
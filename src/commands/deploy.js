@@ -0,0 +1,35 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { generateDeployment, targets } from '../deploy/index.js';
+
+export async function deployGenerate(projectDir = '.', options = {}) {
+  const root = path.resolve(projectDir);
+  const targetName = options.target || 'k8s';
+
+  if (!targets[targetName]) {
+    console.log(chalk.red(`\n❌ Unknown target "${targetName}" (expected ${Object.keys(targets).join(', ')}).`));
+    process.exit(1);
+  }
+  if (!(await fs.pathExists(path.join(root, '.env.example'))) && !(await fs.pathExists(path.join(root, 'main.go')))) {
+    console.log(chalk.red(`\n❌ ${root} doesn't look like a generated project (no .env.example or main.go).`));
+    process.exit(1);
+  }
+
+  let result;
+  try {
+    result = await generateDeployment(root, targetName, { force: options.force });
+  } catch (error) {
+    console.log(chalk.red(`\n❌ ${error.message}`));
+    process.exit(1);
+  }
+  const { app, outDir, files } = result;
+
+  console.log(chalk.green(`\n✅ Wrote ${targets[targetName].description}`));
+  console.log(chalk.dim(`   app ${app.name}, port ${app.port}, liveness ${app.health.liveness || 'none'}, readiness ${app.health.readiness || 'none'}`));
+  files.forEach(file => console.log(chalk.white(`   ${path.relative(root, path.join(outDir, file))}`)));
+  if (Object.keys(app.secrets).length > 0) {
+    console.log(chalk.yellow(`\n⚠️  Fill in secret values before deploying: ${Object.keys(app.secrets).join(', ')}`));
+  }
+  console.log();
+}
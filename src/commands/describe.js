@@ -0,0 +1,87 @@
+import chalk from 'chalk';
+import path from 'node:path';
+import { templates } from '../config/templates.js';
+import { stackFlags } from './create.js';
+import { readProjectManifest, hashProject, compareHashes, projectManifestFile } from '../utils/project-manifest.js';
+
+export function describeStack(stackId) {
+  const template = templates[stackId];
+
+  if (!template || !template.options) {
+    console.log(chalk.red(`\n❌ Unknown stack "${stackId}". Run "list stacks" to see configurable stacks.`));
+    process.exit(1);
+  }
+
+  console.log(chalk.bold.cyan(`\n🧱 ${template.name}`) + chalk.dim(` (${stackId})`));
+  console.log(chalk.dim(`   ${template.description}\n`));
+  console.log(chalk.bold.yellow('Options:'));
+  console.log(chalk.dim('─'.repeat(50)));
+
+  Object.entries(template.options).forEach(([name, option]) => {
+    const flag = option.flag ? chalk.magenta(` ${option.flag}`) : '';
+    console.log(`\n  ${chalk.bold(name)}${flag}  ${chalk.dim(option.description)}`);
+    console.log(`  ${chalk.cyan('Choices:')} ${option.choices.join(', ')}`);
+    console.log(`  ${chalk.green('Default:')} ${option.default}`);
+  });
+  console.log();
+}
+
+// Show how a generated project was made, from its .stackapp.yaml: the
+// template and version, features and options (with the command that
+// recreates it), and which files changed since generation.
+export async function describeProject(projectDir = '.') {
+  const root = path.resolve(projectDir);
+  let manifest;
+  try {
+    manifest = await readProjectManifest(root);
+  } catch (error) {
+    console.log(chalk.red(`\n❌ ${error.message}`));
+    process.exit(1);
+  }
+
+  const template = templates[manifest.template];
+  console.log(chalk.bold.cyan(`\n📦 ${manifest.name}`) + chalk.dim(` (${projectManifestFile})`));
+  console.log(`   ${chalk.cyan('Template:')} ${manifest.template} ${manifest.version}${template ? chalk.dim(` (${template.name})`) : ''}`);
+  if (manifest.source) {
+    console.log(`   ${chalk.cyan('Source:')}   ${manifest.source.url}${manifest.source.ref ? `@${manifest.source.ref}` : chalk.dim(' (default branch)')}`);
+  }
+  console.log(`   ${chalk.cyan('Features:')} ${manifest.features.join(', ') || 'none'}`);
+
+  console.log(chalk.bold.yellow('\nOptions:'));
+  console.log(chalk.dim('─'.repeat(50)));
+  for (const [name, value] of Object.entries(manifest.options)) {
+    const option = template && template.options && template.options[stackFlags[name]];
+    const note = option ? chalk.dim(value === option.default ? '  (default)' : `  (default: ${option.default})`) : '';
+    console.log(`  ${chalk.bold(name.padEnd(14))} ${value}${note}`);
+  }
+
+  console.log(chalk.bold.yellow('\nRecreate with:'));
+  console.log(`  ${chalk.cyan('$')} ${recreateCommand(manifest)}`);
+
+  const { changed, missing, extra } = compareHashes(manifest.files, await hashProject(root));
+  const recorded = Object.keys(manifest.files).length;
+  console.log(chalk.bold.yellow('\nFiles:'));
+  console.log(`  ${recorded} generated, ${recorded - changed.length - missing.length} unchanged since`);
+  changed.forEach(file => console.log(`  ${chalk.cyan('changed'.padEnd(8))} ${file}`));
+  missing.forEach(file => console.log(`  ${chalk.red('removed'.padEnd(8))} ${file}`));
+  if (extra.length > 0) {
+    console.log(chalk.dim(`  ${extra.length} file${extra.length === 1 ? '' : 's'} added since (not tracked)`));
+  }
+  console.log();
+}
+
+// The "new" command line that generates the manifest's project again.
+// The year is recorded but not a flag: regen reproduces it exactly.
+function recreateCommand(manifest) {
+  const args = [manifest.name, '--template', manifest.template];
+  const { options } = manifest;
+  for (const flag of Object.keys(stackFlags)) {
+    if (options[flag] !== undefined) args.push(`--${flag}`, options[flag]);
+  }
+  for (const flag of ['services', 'module', 'port', 'author']) {
+    if (options[flag] !== undefined) args.push(`--${flag}`, options[flag]);
+  }
+  args.push('--features', manifest.features.join(',') || 'none', '--yes');
+  const quote = arg => (/^[\w@%+=:,./-]+$/.test(arg) ? arg : `'${arg.replace(/'/g, `'\\''`)}'`);
+  return `npx create-stack-app new ${args.map(arg => quote(String(arg))).join(' ')}`;
+}
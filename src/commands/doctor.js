@@ -0,0 +1,118 @@
+import chalk from 'chalk';
+import { execa } from 'execa';
+import { templates } from '../config/templates.js';
+
+// Toolchains doctor knows how to find, with the version pattern in their
+// output and how to install them
+const tools = {
+  node: { name: 'Node.js', args: ['--version'], hint: 'https://nodejs.org/' },
+  git: { name: 'git', args: ['--version'], hint: 'https://git-scm.com/downloads' },
+  go: { name: 'Go', args: ['version'], hint: 'https://go.dev/dl/' },
+  templ: { name: 'templ CLI', args: ['version'], hint: 'go install github.com/a-h/templ/cmd/templ@latest' },
+  python3: { name: 'Python', args: ['--version'], hint: 'https://www.python.org/downloads/' },
+  cargo: { name: 'Rust (cargo)', args: ['--version'], hint: 'https://rustup.rs/' },
+  dotnet: { name: '.NET SDK', args: ['--version'], hint: 'https://dotnet.microsoft.com/download' },
+  mix: { name: 'Elixir (mix)', args: ['--version'], hint: 'https://elixir-lang.org/install.html' },
+  docker: { name: 'Docker', args: ['--version'], hint: 'https://docs.docker.com/get-docker/' },
+  goose: { name: 'goose', args: ['-version'], hint: 'go install github.com/pressly/goose/v3/cmd/goose@latest' }
+};
+
+// Minimum toolchain per template language
+const languageTools = {
+  TypeScript: [{ tool: 'node', min: '18.0.0' }],
+  JavaScript: [{ tool: 'node', min: '18.0.0' }],
+  Python: [{ tool: 'python3', min: '3.10.0' }],
+  Rust: [{ tool: 'cargo' }],
+  Go: [{ tool: 'go', min: '1.21.0' }],
+  'C#': [{ tool: 'dotnet', min: '8.0.0' }],
+  Elixir: [{ tool: 'mix' }]
+};
+
+// Helper: Build the checklist for a template and its options. Optional
+// checks are reported but never fail the run.
+function requirementsFor(templateId, options) {
+  const checks = [{ tool: 'node', min: '18.0.0' }];
+  if (!templateId) {
+    // No template: survey every toolchain without failing on any of them
+    for (const tool of Object.keys(tools).filter(tool => tool !== 'node')) {
+      checks.push({ tool, optional: true });
+    }
+    return checks;
+  }
+
+  const template = templates[templateId];
+  for (const check of languageTools[template.language] || []) {
+    if (!checks.some(c => c.tool === check.tool)) checks.push(check);
+  }
+  if (templateId === 'go-htmx') {
+    checks.push({ tool: 'templ', why: 'compiles the .templ views' });
+  }
+  if (template.language === 'Go' && options.database && options.database !== 'memory') {
+    checks.push({ tool: 'goose', optional: true, why: 'runs make migrate-up/down' });
+  }
+  checks.push({ tool: 'docker', optional: true, why: 'for the generated Dockerfile and Compose setup' });
+  return checks;
+}
+
+// Helper: Run a tool and pull the first version number out of its output
+async function probe(tool) {
+  const { args } = tools[tool];
+  try {
+    const { stdout, stderr } = await execa(tool, args);
+    const match = /(\d+)\.(\d+)(?:\.(\d+))?/.exec(`${stdout}\n${stderr}`);
+    return { found: true, version: match ? `${match[1]}.${match[2]}.${match[3] || 0}` : null };
+  } catch (error) {
+    // Present but exited non-zero still counts as installed
+    return { found: error.code !== 'ENOENT', version: null };
+  }
+}
+
+function older(version, min) {
+  const a = version.split('.').map(Number);
+  const b = min.split('.').map(Number);
+  for (let i = 0; i < 3; i++) {
+    if (a[i] !== b[i]) return a[i] < b[i];
+  }
+  return false;
+}
+
+// Check the local environment for the toolchains a template needs.
+// Exits 1 when a required tool is missing or too old, for use in CI.
+export async function runDoctor(options = {}) {
+  const templateId = options.template;
+  if (templateId && !templates[templateId]) {
+    console.log(chalk.red(`\n❌ Unknown template "${templateId}". Run "list" to see available templates.`));
+    process.exit(1);
+  }
+
+  const heading = templateId ? `${templates[templateId].name} (${templateId})` : 'all toolchains';
+  console.log(chalk.bold.cyan(`\n🩺 Checking environment for ${heading}\n`));
+
+  let failed = 0;
+  for (const check of requirementsFor(templateId, options)) {
+    const tool = tools[check.tool];
+    const { found, version } = await probe(check.tool);
+    const label = `${tool.name}${version ? ` ${version}` : ''}`;
+    const why = check.why ? chalk.dim(` (${check.why})`) : '';
+
+    if (found && !(check.min && version && older(version, check.min))) {
+      console.log(`  ${chalk.green('✓')} ${label}${why}`);
+      continue;
+    }
+
+    const problem = found ? `${label} is older than ${check.min}` : `${tool.name} not found`;
+    if (check.optional) {
+      console.log(`  ${chalk.yellow('⚠')} ${problem} ${chalk.dim('(optional)')}${why}`);
+    } else {
+      failed++;
+      console.log(`  ${chalk.red('✗')} ${problem}${why}`);
+    }
+    console.log(chalk.dim(`      Install: ${tool.hint}`));
+  }
+
+  if (failed > 0) {
+    console.log(chalk.red(`\n❌ ${failed} required tool${failed === 1 ? '' : 's'} missing or outdated.\n`));
+    process.exit(1);
+  }
+  console.log(chalk.green('\n✅ Environment looks good.\n'));
+}
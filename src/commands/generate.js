@@ -0,0 +1,29 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { describeResource, generateResource } from '../generators/resource.js';
+
+// Add a CRUD resource to an existing go-htmx project
+export async function generateResourceCommand(name, fieldArgs = [], options = {}) {
+  const root = path.resolve(options.dir || '.');
+
+  if (!(await fs.pathExists(path.join(root, 'main.go'))) || !(await fs.pathExists(path.join(root, 'views')))) {
+    console.log(chalk.red(`\n❌ ${root} doesn't look like a generated go-htmx project (needs main.go and views/).`));
+    process.exit(1);
+  }
+
+  let files;
+  let res;
+  try {
+    res = describeResource(name, fieldArgs);
+    files = await generateResource(root, res);
+  } catch (error) {
+    console.log(chalk.red(`\n❌ ${error.message}`));
+    process.exit(1);
+  }
+
+  console.log(chalk.green(`\n✅ Added ${res.pluralLabel} at ${res.route}`));
+  files.forEach(file => console.log(chalk.white(`   ${file}`)));
+  console.log(chalk.white('   main.go (store and routes registered)'));
+  console.log(chalk.cyan('\nNext: run templ generate, then go run .\n'));
+}
@@ -0,0 +1,107 @@
+import inquirer from 'inquirer';
+import chalk from 'chalk';
+import { templates, languages } from '../config/templates.js';
+import {
+  featureChoices,
+  getProjectName,
+  resolveStackOptions,
+  scaffoldProject
+} from './create.js';
+
+// Where the project will run; Docker adds Dockerfile + Compose
+const deployTargets = [
+  { name: '🐳 Docker (Dockerfile + Docker Compose)', value: 'docker' },
+  { name: '💻 None (run it directly)', value: 'none' }
+];
+
+// Helper: Ask for each registered stack option that has a flag and more
+// than one choice (e.g. --database), keyed by flag name
+async function selectStackOptions(templateConfig) {
+  const questions = Object.values(templateConfig.options || {})
+    .filter(option => option.flag && option.choices.length > 1)
+    .map(option => ({
+      type: 'list',
+      name: option.flag.replace(/^--/, ''),
+      message: `${option.description}:`,
+      choices: option.choices,
+      default: option.default
+    }));
+  return inquirer.prompt(questions);
+}
+
+// Helper: Build the `new` invocation that reproduces the wizard's answers
+export function equivalentCommand(projectName, templateId, stackOptions, features, options = {}) {
+  const args = ['npx create-stack-app new', projectName, '--template', templateId];
+  for (const [flag, value] of Object.entries(stackOptions)) {
+    args.push(`--${flag}`, value);
+  }
+  args.push('--features', features.join(',') || 'none');
+  if (options.skipInstall) {
+    args.push('--skip-install');
+  }
+  if (options.skipHooks) {
+    args.push('--skip-hooks');
+  }
+  args.push('--yes');
+  return args.join(' ');
+}
+
+export async function initProject(options = {}) {
+  try {
+    const projectName = await getProjectName();
+
+    const { language } = await inquirer.prompt([
+      {
+        type: 'list',
+        name: 'language',
+        message: 'Language:',
+        choices: Object.keys(languages)
+      }
+    ]);
+
+    const { templateId } = await inquirer.prompt([
+      {
+        type: 'list',
+        name: 'templateId',
+        message: 'Framework:',
+        choices: languages[language].map(id => ({
+          name: `${templates[id].name} - ${templates[id].description}`,
+          value: id
+        }))
+      }
+    ]);
+    const templateConfig = templates[templateId];
+
+    const stackOptions = await selectStackOptions(templateConfig);
+
+    const { deploy, extras } = await inquirer.prompt([
+      {
+        type: 'list',
+        name: 'deploy',
+        message: 'Deployment target:',
+        choices: deployTargets
+      },
+      {
+        type: 'checkbox',
+        name: 'extras',
+        message: 'Additional features (optional):',
+        choices: featureChoices.filter(choice => choice.value !== 'docker')
+      }
+    ]);
+    const features = deploy === 'docker' ? ['docker', ...extras] : extras;
+
+    const generatorOptions = resolveStackOptions(templateConfig, stackOptions);
+    const created = await scaffoldProject(projectName, templateId, templateConfig, features, generatorOptions, options);
+    if (!created) return;
+
+    console.log(chalk.bold('🔁 Recreate this project without prompts:'));
+    console.log(chalk.white(`   ${equivalentCommand(projectName, templateId, stackOptions, features, options)}\n`));
+  } catch (error) {
+    if (error.isTtyError) {
+      console.log(chalk.red('Prompt could not be rendered in this environment'));
+    } else {
+      console.log(chalk.red('\n❌ Error:'), error.message);
+    }
+    process.exit(1);
+  }
+}
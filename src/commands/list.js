@@ -1,5 +1,5 @@
 import chalk from 'chalk';
-import { templates, languages } from '../config/templates.js';
+import { templates, languages, configurableStacks } from '../config/templates.js';
 
 // Helper: Get popularity indicator emoji
 function getPopularityIcon(popularity) {
@@ -32,3 +32,14 @@ export function listTemplates() {
   console.log(chalk.bold.cyan('\n\n💡 Usage:'));
   console.log(chalk.white('  npx create-stack-app new my-project\n'));
 }
+
+export function listStacks() {
+  console.log(chalk.bold.cyan('\n🧱 Configurable Stacks\n'));
+
+  configurableStacks().forEach(stack => {
+    console.log(`  ${chalk.green(stack.id.padEnd(16))} ${stack.description}`);
+  });
+
+  console.log(chalk.bold.cyan('\n💡 Details:'));
+  console.log(chalk.white('  npx create-stack-app describe stack <id>\n'));
+}
@@ -0,0 +1,163 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+import yaml from 'js-yaml';
+
+// Where the stacks keep their spec, in the order they are looked for
+const specCandidates = ['openapi/openapi.yaml', 'swagger/openapi.yaml', 'openapi.yaml', 'openapi.json'];
+
+const methods = ['get', 'put', 'post', 'delete', 'options', 'head', 'patch', 'trace'];
+
+// Helper: Follow a local $ref ("#/components/schemas/Item"); undefined when
+// it points nowhere
+function resolveRef(doc, ref) {
+  return ref.slice(2).split('/')
+    .map(part => part.replace(/~1/g, '/').replace(/~0/g, '~'))
+    .reduce((node, part) => (node == null ? undefined : node[part]), doc);
+}
+
+// Helper: Collect every $ref in the document with the location using it
+function collectRefs(node, at, refs = []) {
+  if (Array.isArray(node)) {
+    node.forEach((child, i) => collectRefs(child, `${at}/${i}`, refs));
+  } else if (node && typeof node === 'object') {
+    for (const [key, child] of Object.entries(node)) {
+      if (key === '$ref' && typeof child === 'string') {
+        refs.push({ ref: child, at });
+      } else {
+        collectRefs(child, `${at}/${key.replace(/~/g, '~0').replace(/\//g, '~1')}`, refs);
+      }
+    }
+  }
+  return refs;
+}
+
+// Helper: Parameters of an operation, its path's merged in, with local
+// $refs followed
+function parametersOf(doc, pathItem, operation) {
+  return [...(pathItem.parameters || []), ...(operation.parameters || [])]
+    .map(param => (param && param.$ref ? resolveRef(doc, param.$ref) : param))
+    .filter(Boolean);
+}
+
+// Lint a parsed OpenAPI 3 document. Errors are spec violations or routes
+// the spec can't describe; warnings are likely mistakes (unused schemas).
+export function lintSpec(doc) {
+  const errors = [];
+  const warnings = [];
+
+  if (!doc || typeof doc !== 'object') {
+    return { errors: ['The document is not a YAML or JSON object'], warnings };
+  }
+  if (doc.swagger) {
+    return { errors: [`Swagger ${doc.swagger} documents are not supported; convert to OpenAPI 3`], warnings };
+  }
+  if (!/^3\.[01]\.\d+$/.test(String(doc.openapi || ''))) {
+    errors.push(`openapi: expected a 3.0.x or 3.1.x version, got ${JSON.stringify(doc.openapi)}`);
+  }
+  if (!doc.info?.title) errors.push('info.title is required');
+  if (!doc.info?.version) errors.push('info.version is required');
+  if (!doc.paths || typeof doc.paths !== 'object') {
+    errors.push('paths is required');
+  }
+
+  const operationIds = new Map();
+  const templates = new Map();
+  for (const [route, pathItem] of Object.entries(doc.paths || {})) {
+    if (!route.startsWith('/')) {
+      errors.push(`paths.${route}: must start with "/"`);
+    }
+
+    // /items/{id} and /items/{itemId} are the same route
+    const template = route.replace(/\{[^}]+\}/g, '{}');
+    if (templates.has(template)) {
+      errors.push(`paths.${route}: same route as ${templates.get(template)}`);
+    }
+    templates.set(template, route);
+
+    const placeholders = [...route.matchAll(/\{([^}]+)\}/g)].map(match => match[1]);
+    for (const method of methods.filter(method => pathItem?.[method])) {
+      const operation = pathItem[method];
+      const at = `${method.toUpperCase()} ${route}`;
+
+      if (!operation.responses || Object.keys(operation.responses).length === 0) {
+        errors.push(`${at}: no responses`);
+      }
+      if (operation.operationId) {
+        if (operationIds.has(operation.operationId)) {
+          errors.push(`${at}: operationId "${operation.operationId}" is also used by ${operationIds.get(operation.operationId)}`);
+        }
+        operationIds.set(operation.operationId, at);
+      }
+
+      const pathParams = parametersOf(doc, pathItem, operation).filter(param => param.in === 'path');
+      for (const name of placeholders) {
+        const param = pathParams.find(p => p.name === name);
+        if (!param) {
+          errors.push(`${at}: path parameter {${name}} is not declared`);
+        } else if (param.required !== true) {
+          errors.push(`${at}: path parameter {${name}} must be required: true`);
+        }
+      }
+      for (const param of pathParams.filter(p => !placeholders.includes(p.name))) {
+        errors.push(`${at}: path parameter "${param.name}" is not in the route`);
+      }
+    }
+  }
+
+  const refs = collectRefs(doc, '#');
+  for (const { ref, at } of refs) {
+    if (!ref.startsWith('#/')) {
+      warnings.push(`${at}: external $ref ${ref} was not checked`);
+    } else if (resolveRef(doc, ref) === undefined) {
+      errors.push(`${at}: $ref ${ref} does not resolve`);
+    }
+  }
+  const used = new Set(refs.map(({ ref }) => ref));
+  for (const name of Object.keys(doc.components?.schemas || {})) {
+    if (!used.has(`#/components/schemas/${name}`)) {
+      warnings.push(`components.schemas.${name} is never referenced`);
+    }
+  }
+
+  return { errors, warnings };
+}
+
+// Lint a project's OpenAPI spec (or the file given). Exits 1 on errors, or
+// on warnings too with --strict, for use in CI.
+export async function validateOpenAPI(file, options = {}) {
+  const root = path.resolve(options.dir || '.');
+  let specPath = file && path.resolve(file);
+  if (!specPath) {
+    for (const candidate of specCandidates) {
+      if (await fs.pathExists(path.join(root, candidate))) {
+        specPath = path.join(root, candidate);
+        break;
+      }
+    }
+  }
+  if (!specPath || !await fs.pathExists(specPath)) {
+    console.log(chalk.red(`\n❌ No OpenAPI spec found (looked for ${file || specCandidates.join(', ')}).`));
+    process.exit(1);
+  }
+
+  let doc;
+  try {
+    doc = yaml.load(await fs.readFile(specPath, 'utf8'));
+  } catch (error) {
+    console.log(chalk.red(`\n❌ ${path.relative(process.cwd(), specPath)} does not parse: ${error.message}`));
+    process.exit(1);
+  }
+
+  const { errors, warnings } = lintSpec(doc);
+  const name = path.relative(process.cwd(), specPath) || specPath;
+  for (const error of errors) console.log(chalk.red(`  ✖ ${error}`));
+  for (const warning of warnings) console.log(chalk.yellow(`  ⚠ ${warning}`));
+
+  if (errors.length > 0 || (options.strict && warnings.length > 0)) {
+    console.log(chalk.red(`\n❌ ${name}: ${errors.length} error(s), ${warnings.length} warning(s)`));
+    process.exit(1);
+  }
+  const paths = Object.keys(doc.paths || {}).length;
+  console.log(chalk.green(`\n✅ ${name} is valid (${paths} paths${warnings.length ? `, ${warnings.length} warning(s)` : ''})`));
+}
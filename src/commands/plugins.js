@@ -0,0 +1,45 @@
+import chalk from 'chalk';
+import { discoverPlugins, readPluginManifest, pluginDir, pluginPrefix } from '../utils/plugins.js';
+
+// List the stack-app-<name> executables found, with the templates and
+// features each one offers. Plugins without a manifest are commands only.
+export async function listPlugins() {
+  const plugins = await discoverPlugins();
+
+  console.log(chalk.bold.cyan('\n🔌 Plugins\n'));
+  if (plugins.length === 0) {
+    console.log(chalk.dim(`  None found. Put ${pluginPrefix}<name> executables on PATH or in ${pluginDir()}.\n`));
+    return;
+  }
+
+  for (const plugin of plugins) {
+    let manifest = null;
+    let problem = null;
+    try {
+      manifest = await readPluginManifest(plugin);
+    } catch (error) {
+      problem = error.message;
+    }
+
+    const version = manifest ? chalk.dim(` ${manifest.version}`) : '';
+    console.log(`  ${chalk.green(plugin.name)}${version}  ${manifest ? manifest.description : chalk.dim('command only (no manifest)')}`);
+    console.log(`  ${chalk.dim(plugin.path)}`);
+    if (manifest) {
+      manifest.templates.forEach(template => {
+        console.log(`    ${chalk.cyan('template')} ${`${plugin.name}:${template.id}`.padEnd(24)} ${template.name}`);
+      });
+      manifest.features.forEach(feature => {
+        console.log(`    ${chalk.magenta('feature')}  ${`${plugin.name}:${feature.name}`.padEnd(24)} ${feature.description}`);
+      });
+    } else if (!/"manifest" failed/.test(problem)) {
+      console.log(chalk.yellow(`    ⚠️  ${problem}`));
+    }
+    plugin.shadowed.forEach(file => console.log(chalk.yellow(`    ⚠️  shadows ${file}`)));
+    console.log();
+  }
+
+  console.log(chalk.bold.cyan('💡 Usage:'));
+  console.log(chalk.white('  npx create-stack-app <plugin> [args...]'));
+  console.log(chalk.white('  npx create-stack-app new my-project --template <plugin>:<template>'));
+  console.log(chalk.white('  npx create-stack-app add <plugin>:<feature> [value]\n'));
+}
@@ -0,0 +1,150 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import fs from 'fs-extra';
+import os from 'node:os';
+import path from 'node:path';
+import http from 'node:http';
+import { execa } from 'execa';
+import { templates } from '../config/templates.js';
+import { generateProject } from '../generators/index.js';
+import { streamProjectZip } from '../utils/zip.js';
+import { takeHooks, runHooks } from '../utils/hooks.js';
+
+// Helper: Check that a toolchain binary is callable
+async function hasCommand(command, args = ['version']) {
+  try {
+    await execa(command, args);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+// Helper: Generate the stack into a throwaway directory. Its hooks are
+// returned rather than left in the project (or the zip).
+export async function generateToTemp(templateId, features = []) {
+  const templateConfig = templates[templateId];
+  const tempRoot = await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-preview-'));
+  const projectPath = path.join(tempRoot, `${templateId}-preview`);
+
+  await fs.ensureDir(projectPath);
+  await generateProject(projectPath, templateId, templateConfig, features);
+  const hooks = await takeHooks(projectPath);
+
+  return { tempRoot, projectPath, hooks };
+}
+
+// Write the generated stack straight to a zip file instead of serving it
+export async function exportZip(templateId, zipPath) {
+  const spinner = ora(`Packaging ${templates[templateId].name}...`).start();
+  const { tempRoot, projectPath } = await generateToTemp(templateId);
+
+  try {
+    await streamProjectZip(projectPath, fs.createWriteStream(zipPath), `${templateId}-app`);
+    spinner.succeed(chalk.green(`Project exported to ${zipPath}`));
+  } catch (error) {
+    spinner.fail(chalk.red('Export failed'));
+    throw error;
+  } finally {
+    await fs.remove(tempRoot);
+  }
+}
+
+// Serve the preview project as a zip download next to the running app
+function startDownloadServer(projectPath, templateId, port) {
+  const server = http.createServer(async (req, res) => {
+    if (req.url !== '/project.zip') {
+      res.writeHead(404).end();
+      return;
+    }
+    res.writeHead(200, {
+      'Content-Type': 'application/zip',
+      'Content-Disposition': `attachment; filename="${templateId}-app.zip"`
+    });
+    try {
+      await streamProjectZip(projectPath, res, `${templateId}-app`);
+    } catch {
+      res.destroy();
+    }
+  });
+  server.listen(port);
+  return server;
+}
+
+export async function previewProject(options = {}) {
+  const templateId = options.stack || 'go-htmx';
+  const port = options.port || '3000';
+  const templateConfig = templates[templateId];
+
+  if (!templateConfig) {
+    console.log(chalk.red(`\n❌ Unknown stack "${templateId}". Run "list" to see available stacks.`));
+    process.exit(1);
+  }
+
+  if (options.zip) {
+    await exportZip(templateId, path.resolve(options.zip));
+    return;
+  }
+
+  if (templateConfig.language !== 'Go') {
+    console.log(chalk.yellow(`\n⚠️  Preview currently supports Go stacks only (got ${templateConfig.language}).`));
+    process.exit(1);
+  }
+
+  if (!(await hasCommand('go'))) {
+    console.log(chalk.red('\n❌ Go toolchain not found on PATH.'));
+    console.log(chalk.white('   Install it from https://go.dev/dl/ and try again.'));
+    process.exit(1);
+  }
+
+  const spinner = ora(`Generating ${templateConfig.name} preview...`).start();
+  const { tempRoot, projectPath, hooks } = await generateToTemp(templateId);
+
+  let server;
+  let downloads;
+  const cleanup = async () => {
+    if (server && !server.killed) {
+      server.kill('SIGTERM');
+    }
+    if (downloads) {
+      downloads.close();
+    }
+    await fs.remove(tempRoot);
+  };
+
+  process.once('SIGINT', async () => {
+    console.log(chalk.dim('\n🧹 Cleaning up preview...'));
+    await cleanup();
+    process.exit(0);
+  });
+
+  try {
+    // The template's own setup (go mod tidy, templ generate, ...)
+    await runHooks(projectPath, hooks, (hook, status) => {
+      if (status === 'run') spinner.text = `${hook.name}...`;
+    });
+
+    // Offer the scaffold as a zip download while the preview runs
+    const downloadPort = Number(port) + 1;
+    downloads = startDownloadServer(projectPath, templateId, downloadPort);
+
+    spinner.succeed(chalk.green('Preview ready!'));
+    console.log(chalk.cyan('\n🌐 Serving on ') + chalk.white(`http://localhost:${port}`));
+    console.log(chalk.cyan('📦 Download zip: ') + chalk.white(`http://localhost:${downloadPort}/project.zip`));
+    console.log(chalk.dim('   Press Ctrl+C to stop. Nothing is written to your working directory.\n'));
+
+    server = execa('go', ['run', '.'], {
+      cwd: projectPath,
+      env: { PORT: String(port) },
+      stdio: 'inherit'
+    });
+    await server;
+  } catch (error) {
+    if (!error.isCanceled && error.signal !== 'SIGTERM') {
+      spinner.fail(chalk.red('Preview failed'));
+      console.error(error.stderr || error.message);
+    }
+  } finally {
+    await cleanup();
+  }
+}
@@ -0,0 +1,86 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { readProjectManifest, templateVersion, hashProject, compareHashes, projectManifestFile } from '../utils/project-manifest.js';
+import { manifestTemplate, regenerate } from '../utils/upgrade.js';
+
+// Generate a project again from its .stackapp.yaml into a fresh directory:
+// same template version, features, options, author and year. The output
+// is checked against the recorded file hashes, so a template change (or
+// a generator that isn't deterministic) shows up as files that differ.
+export async function regenProject(projectDir = '.', options = {}) {
+  const root = path.resolve(projectDir);
+  const fail = message => {
+    console.log(chalk.red(`\n❌ ${message}`));
+    process.exit(1);
+  };
+  if (!options.output) {
+    fail('Pass --output <dir> for the regenerated project.');
+  }
+  const output = path.resolve(options.output);
+  if (output === root || root.startsWith(output + path.sep)) {
+    fail('--output must be a new directory, not the project or one containing it.');
+  }
+  if (await fs.pathExists(output) && (await fs.readdir(output)).length > 0 && !options.force) {
+    fail(`${options.output} is not empty (pass --force to replace it).`);
+  }
+
+  let manifest;
+  try {
+    manifest = await readProjectManifest(root);
+  } catch (error) {
+    fail(error.message);
+  }
+
+  let tempRoot;
+  try {
+    const spinner = ora(`Regenerating ${manifest.name} from ${manifest.template} ${manifest.version}...`).start();
+    let next;
+    let version;
+    try {
+      const templateConfig = await manifestTemplate(manifest, manifest.source && manifest.source.ref);
+      version = await templateVersion(templateConfig);
+      // Generated under the project's own name, which the files use
+      next = await regenerate(manifest, templateConfig);
+      tempRoot = next.tempRoot;
+    } catch (error) {
+      spinner.fail(chalk.red(error.message));
+      process.exit(1);
+    }
+    spinner.stop();
+
+    if (version !== manifest.version) {
+      console.log(chalk.yellow(`⚠️  ${manifest.template} is at ${version}, not the recorded ${manifest.version}; files it changed since will differ.`));
+    }
+
+    await fs.emptyDir(output);
+    await fs.copy(next.projectPath, output);
+    await fs.copy(path.join(root, projectManifestFile), path.join(output, projectManifestFile));
+
+    const { changed, missing, extra } = compareHashes(manifest.files, await hashProject(output));
+    const differ = changed.length + missing.length + extra.length;
+    console.log(chalk.bold(`\n${manifest.name} regenerated into ${options.output}`));
+    if (differ === 0) {
+      console.log(chalk.green(`✅ All ${Object.keys(manifest.files).length} files match the recorded scaffold.`));
+    } else {
+      console.log(chalk.yellow(`⚠️  ${differ} file${differ === 1 ? '' : 's'} differ from the recorded scaffold:`));
+      changed.forEach(file => console.log(`   ${chalk.cyan('changed'.padEnd(8))} ${file}`));
+      missing.forEach(file => console.log(`   ${chalk.red('missing'.padEnd(8))} ${file}`));
+      extra.forEach(file => console.log(`   ${chalk.green('new'.padEnd(8))} ${file}`));
+    }
+    console.log(chalk.dim(`\nCompare it with the project: diff -r ${path.relative(process.cwd(), root) || '.'} ${options.output}`));
+    if (next.hooks.length > 0) {
+      console.log(chalk.dim('The template\'s setup was not run; to build it:'));
+      next.hooks.forEach(hook => console.log(`  ${chalk.cyan('$')} ${hook.run}`));
+    }
+
+    if (options.check && differ > 0) {
+      process.exit(1);
+    }
+  } finally {
+    if (tempRoot) {
+      await fs.remove(tempRoot);
+    }
+  }
+}
@@ -0,0 +1,76 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import fs from 'fs-extra';
+import { cliVersion } from '../utils/project-manifest.js';
+import {
+  channels, defaultChannel, compareVersions, latestRelease, checkForUpdate,
+  downloadRelease, installRelease, releaseRepo
+} from '../utils/self-update.js';
+
+function resolveChannel(version, channel) {
+  const resolved = channel || defaultChannel(version);
+  if (!channels.includes(resolved)) {
+    console.log(chalk.red(`\n❌ Unknown channel "${resolved}". Choose one of: ${channels.join(', ')}`));
+    process.exit(1);
+  }
+  return resolved;
+}
+
+// Replace the installed CLI with the newest release on a channel, after
+// checking the download against the release's SHA256SUMS (and its
+// signature, given --public-key)
+export async function selfUpdate(options = {}) {
+  const current = await cliVersion();
+  const channel = resolveChannel(current, options.channel);
+
+  const spinner = ora(`Checking ${releaseRepo()} for ${channel} releases...`).start();
+  try {
+    const release = await latestRelease(channel);
+    if (!release) {
+      spinner.fail(chalk.red(`No ${channel} releases found`));
+      process.exit(1);
+    }
+    if (compareVersions(release.version, current) <= 0 && !options.force) {
+      spinner.succeed(chalk.green(`create-stack-app ${current} is up to date (${channel}: ${release.version})`));
+      return;
+    }
+
+    const publicKey = options.publicKey ? await fs.readFile(options.publicKey, 'utf8') : null;
+    spinner.text = `Downloading ${release.version}...`;
+    const { tarball, data } = await downloadRelease(release, publicKey);
+
+    spinner.text = `Installing ${release.version}...`;
+    await installRelease(tarball, data, release.version);
+    spinner.succeed(chalk.green(`Updated create-stack-app ${current} → ${release.version}`));
+    console.log(chalk.dim(`   ${publicKey ? 'Checksum and signature' : 'Checksum'} verified. Release notes: ${release.url}`));
+  } catch (error) {
+    spinner.fail(chalk.red(error.message));
+    if (error.code === 'EACCES' || error.code === 'EPERM') {
+      console.log(chalk.yellow('   The install directory is not writable; run the update with the permissions used to install the CLI.'));
+    }
+    process.exit(1);
+  }
+}
+
+// Print the CLI version; with --check also look up the newest release on
+// the channel and say whether an update is available. Nothing is installed.
+export async function showVersion(options = {}) {
+  const current = await cliVersion();
+  console.log(current);
+  if (!options.check) {
+    return;
+  }
+
+  const channel = resolveChannel(current, options.channel);
+  try {
+    const { latest, outdated } = await checkForUpdate(current, channel);
+    if (outdated) {
+      console.log(chalk.yellow(`⬆️  ${latest.version} is available on the ${channel} channel: ${latest.url}`));
+      console.log(chalk.dim(`   Update with: create-stack-app self-update${options.channel ? ` --channel ${channel}` : ''}`));
+    } else {
+      console.log(chalk.green(`✅ Up to date (${channel}${latest ? `: ${latest.version}` : ', no releases yet'})`));
+    }
+  } catch (error) {
+    console.log(chalk.yellow(`⚠️  Could not check for updates: ${error.message}`));
+  }
+}
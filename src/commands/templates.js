@@ -0,0 +1,106 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import path from 'node:path';
+import { templates } from '../config/templates.js';
+import {
+  fetchTemplate,
+  loadRegistry,
+  parseTemplateRef,
+  readManifest,
+  saveRegistry
+} from '../utils/registry.js';
+import { discoverPlugins, readPluginManifest } from '../utils/plugins.js';
+import { lintTemplate } from '../utils/template-lint.js';
+
+export async function listAllTemplates() {
+  console.log(chalk.bold.cyan('\n📦 Built-in Templates\n'));
+  Object.entries(templates).forEach(([id, template]) => {
+    console.log(`  ${chalk.green(id.padEnd(20))} ${template.name}`);
+  });
+
+  const registry = await loadRegistry();
+  const entries = Object.values(registry.templates);
+
+  console.log(chalk.bold.cyan('\n🌐 Registered Templates\n'));
+  if (entries.length === 0) {
+    console.log(chalk.dim('  None yet. Add one with: templates add <git-url>[@version]'));
+  }
+  entries.forEach(entry => {
+    const pin = entry.ref ? `@${entry.ref}` : chalk.dim(' (default branch)');
+    console.log(`  ${chalk.green(entry.name.padEnd(20))} ${entry.description}`);
+    console.log(`  ${''.padEnd(20)} ${chalk.dim(entry.url)}${pin}`);
+  });
+
+  // Plugins without a manifest offer no templates
+  const pluginTemplates = [];
+  for (const plugin of await discoverPlugins()) {
+    try {
+      const manifest = await readPluginManifest(plugin);
+      manifest.templates.forEach(template => pluginTemplates.push([`${plugin.name}:${template.id}`, template]));
+    } catch {
+      continue;
+    }
+  }
+  if (pluginTemplates.length > 0) {
+    console.log(chalk.bold.cyan('\n🔌 Plugin Templates\n'));
+    pluginTemplates.forEach(([id, template]) => {
+      console.log(`  ${chalk.green(id.padEnd(20))} ${template.name}`);
+    });
+  }
+
+  console.log(chalk.bold.cyan('\n💡 Usage:'));
+  console.log(chalk.white('  npx create-stack-app new my-project --template <id | name | git-url@version | plugin:template>\n'));
+}
+
+// Register a remote template under the name in its stack-app.json. A
+// trailing @version pins it; without one, each use fetches the default
+// branch.
+export async function addTemplate(spec) {
+  const spinner = ora(`Fetching ${spec}...`).start();
+  try {
+    const { url, ref } = parseTemplateRef(spec);
+    const manifest = await readManifest(await fetchTemplate(url, ref));
+
+    if (templates[manifest.name]) {
+      throw new Error(`"${manifest.name}" is a built-in template; rename it in its manifest`);
+    }
+
+    const registry = await loadRegistry();
+    const replaced = Boolean(registry.templates[manifest.name]);
+    registry.templates[manifest.name] = {
+      name: manifest.name,
+      description: manifest.description,
+      language: manifest.language,
+      url,
+      ref
+    };
+    await saveRegistry(registry);
+
+    spinner.succeed(chalk.green(`${replaced ? 'Updated' : 'Added'} template "${manifest.name}"`));
+    console.log(chalk.white(`   npx create-stack-app new my-project --template ${manifest.name}\n`));
+  } catch (error) {
+    spinner.fail(chalk.red(error.message));
+    process.exit(1);
+  }
+}
+
+// Check a template checkout before publishing it: stack-app.json, the
+// {{ .Name }} placeholders, and the project it renders (declared files,
+// Go syntax, go build). Exits 1 on errors, or on warnings with --strict.
+export async function lintTemplateCommand(dir = '.', options = {}) {
+  const root = path.resolve(dir);
+  const name = path.relative(process.cwd(), root) || '.';
+  const spinner = ora(`Linting ${name}...`).start();
+  const { errors, warnings, passed } = await lintTemplate(root, options);
+  spinner.stop();
+
+  for (const check of passed) console.log(chalk.green(`  ✔ ${check}`));
+  for (const error of errors) console.log(chalk.red(`  ✖ ${error}`));
+  for (const warning of warnings) console.log(chalk.yellow(`  ⚠ ${warning}`));
+
+  if (errors.length > 0 || (options.strict && warnings.length > 0)) {
+    console.log(chalk.red(`\n❌ ${name}: ${errors.length} error(s), ${warnings.length} warning(s)`));
+    process.exit(1);
+  }
+  console.log(chalk.green(`\n✅ ${name} is a valid template${warnings.length ? ` (${warnings.length} warning(s))` : ''}`));
+}
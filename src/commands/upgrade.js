@@ -0,0 +1,149 @@
+import chalk from 'chalk';
+import ora from 'ora';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { execa } from 'execa';
+import { readProjectManifest, saveProjectManifest, templateVersion, projectManifestFile } from '../utils/project-manifest.js';
+import { manifestTemplate, regenerate, planUpgrade, applyUpgrade } from '../utils/upgrade.js';
+
+const actionColors = {
+  add: chalk.green,
+  update: chalk.cyan,
+  remove: chalk.red,
+  merge: chalk.magenta,
+  conflict: chalk.yellow
+};
+
+// Bring a generated project up to a newer version of its template. The
+// old and new versions are regenerated with the choices recorded in
+// .stackapp.yaml and three-way merged into the project; conflicts are
+// listed for manual resolution.
+export async function upgradeProject(projectDir = '.', options = {}) {
+  const root = path.resolve(projectDir);
+
+  let manifest;
+  try {
+    manifest = await readProjectManifest(root);
+  } catch (error) {
+    console.log(chalk.red(`\n❌ ${error.message}`));
+    process.exit(1);
+  }
+
+  if (!options.force && !options.dryRun && await hasUncommittedChanges(root)) {
+    console.log(chalk.red('\n❌ The project has uncommitted changes. Commit or stash them first so the upgrade can be reviewed and undone (or pass --force).'));
+    process.exit(1);
+  }
+  if (options.to && !manifest.source) {
+    console.log(chalk.yellow(`⚠️  ${manifest.template} is a built-in template; it upgrades to this CLI's version and --to is ignored.`));
+  }
+
+  const temps = [];
+  try {
+    const spinner = ora(`Resolving ${manifest.template}...`).start();
+    let nextConfig;
+    let nextVersion;
+    try {
+      nextConfig = await manifestTemplate(manifest, options.to);
+      nextVersion = await templateVersion(nextConfig);
+    } catch (error) {
+      spinner.fail(chalk.red(error.message));
+      process.exit(1);
+    }
+    spinner.stop();
+
+    if (nextVersion === manifest.version) {
+      console.log(chalk.green(`\n✅ ${manifest.template} is already at ${manifest.version}.`));
+      return;
+    }
+
+    const next = await regenerate(manifest, nextConfig);
+    temps.push(next.tempRoot);
+
+    // Pinned remote versions can be fetched again, giving line merges a
+    // real common ancestor
+    let basePath = null;
+    if (manifest.source && manifest.source.ref) {
+      try {
+        const base = await regenerate(manifest, await manifestTemplate(manifest, manifest.source.ref));
+        temps.push(base.tempRoot);
+        basePath = base.projectPath;
+      } catch (error) {
+        console.log(chalk.yellow(`⚠️  Could not regenerate ${manifest.version} (${error.message}); files changed on both sides will conflict wherever they differ.`));
+      }
+    }
+
+    const { steps, files } = await planUpgrade(root, manifest, next.projectPath, basePath);
+
+    console.log(chalk.bold(`\n${manifest.template} ${manifest.version} → ${nextVersion}`));
+    if (steps.length === 0) {
+      console.log(chalk.dim('   No file changes.'));
+    }
+
+    if (options.dryRun) {
+      printSteps(steps);
+      console.log(chalk.dim('\nDry run: nothing was written.'));
+      return;
+    }
+
+    await applyUpgrade(root, steps, next.projectPath, {
+      ours: 'project',
+      base: `${manifest.template} ${manifest.version}`,
+      theirs: `${manifest.template} ${nextVersion}`
+    });
+    printSteps(steps);
+
+    // The new template output is the base of the next upgrade
+    await saveProjectManifest(root, {
+      ...manifest,
+      template: manifest.source ? retagTemplate(manifest.template, options.to) : manifest.template,
+      version: nextVersion,
+      ...(manifest.source && { source: { url: manifest.source.url, ref: options.to || null } }),
+      files
+    });
+
+    const conflicts = steps.filter(step => step.action === 'conflict');
+    if (conflicts.length > 0) {
+      console.log(chalk.yellow(`\n⚠️  ${conflicts.length} file${conflicts.length === 1 ? '' : 's'} need manual resolution:`));
+      conflicts.forEach(step => console.log(`   ${chalk.white(step.file)} ${chalk.dim(`(${step.reason})`)}`));
+    } else {
+      console.log(chalk.green(`\n✅ Upgraded to ${nextVersion}.`));
+    }
+    console.log(chalk.dim(`\nReview the changes (git diff), then commit them with ${projectManifestFile}.`));
+    if (next.hooks.length > 0) {
+      console.log(chalk.dim('If dependencies changed, re-run the template\'s setup:'));
+      next.hooks.forEach(hook => console.log(`  ${chalk.cyan('$')} ${hook.run}`));
+    }
+  } finally {
+    for (const dir of temps) {
+      await fs.remove(dir);
+    }
+  }
+}
+
+export function printSteps(steps) {
+  for (const step of steps) {
+    const color = actionColors[step.action];
+    const detail = step.action === 'merge' && !step.base ? chalk.dim(' (no common ancestor)') : step.reason ? chalk.dim(` (${step.reason})`) : '';
+    console.log(`   ${color(step.action.padEnd(8))} ${step.file}${detail}`);
+  }
+}
+
+// Keep a git-reference template id ("github.com/acme/tpl@v1") in step with
+// the version it now tracks. Registered names are left alone.
+function retagTemplate(template, ref) {
+  if (!template.includes('/')) {
+    return template;
+  }
+  const at = template.lastIndexOf('@');
+  const location = at > template.lastIndexOf('/') ? template.slice(0, at) : template;
+  return ref ? `${location}@${ref}` : location;
+}
+
+export async function hasUncommittedChanges(root) {
+  try {
+    const { stdout } = await execa('git', ['status', '--porcelain'], { cwd: root });
+    return stdout.trim() !== '';
+  } catch {
+    return false;
+  }
+}
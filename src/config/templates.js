@@ -24,6 +24,22 @@ export const templates = {
     popularity: 'high',
     difficulty: 'intermediate'
   },
+  'node-htmx': {
+    name: 'Node.js + HTMX Hypermedia',
+    description: 'Server-rendered Express app with HTMX and Nunjucks',
+    language: 'JavaScript',
+    features: ['Express 5', 'HTMX', 'Nunjucks', 'Item CRUD', 'Health Checks', 'node:test'],
+    popularity: 'growing',
+    difficulty: 'beginner',
+    options: {
+      db: { description: 'Item persistence backend', choices: ['memory'], default: 'memory' },
+      framework: { description: 'HTTP framework', choices: ['express'], default: 'express' },
+      views: { description: 'Template engine', choices: ['nunjucks'], default: 'nunjucks' },
+      secrets: { flag: '--secrets', description: 'Secret store the config package loads credentials (DB_PASSWORD, SESSION_SECRET, ...) from at startup, named by SECRETS_PATH, with .env as the local fallback (HashiCorp Vault KV, AWS Secrets Manager, or a SOPS-encrypted file)', choices: ['none', 'vault', 'aws-sm', 'sops'], default: 'none' },
+      loadtest: { flag: '--loadtest', description: 'k6 script in loadtest/ driving the item CRUD routes with latency and error-rate thresholds (make loadtest), plus item store benchmarks (make bench)', choices: ['none', 'k6'], default: 'none', enabled: 'k6' },
+      mode: { description: 'Response style', choices: ['htmx'], default: 'htmx' }
+    }
+  },
 
   // Python Stacks
   'fastapi-modern': {
@@ -34,6 +50,19 @@ export const templates = {
     popularity: 'high',
     difficulty: 'intermediate'
   },
+  'python-fastapi': {
+    name: 'Python FastAPI + SQLModel',
+    description: 'JSON API with pydantic models, a SQLModel store and pytest',
+    language: 'Python',
+    features: ['FastAPI', 'Pydantic', 'SQLModel', 'uvicorn', 'pytest', 'Docker'],
+    popularity: 'high',
+    difficulty: 'intermediate',
+    options: {
+      db: { flag: '--database', description: 'Item database, through SQLAlchemy (DATABASE_URL)', choices: ['sqlite', 'postgres', 'mysql'], default: 'sqlite' },
+      framework: { description: 'HTTP framework', choices: ['fastapi'], default: 'fastapi' },
+      mode: { description: 'Response style', choices: ['json'], default: 'json' }
+    }
+  },
   'django-pro': {
     name: 'Django Professional',
     description: 'Enterprise Django with best practices',
@@ -84,7 +113,114 @@ export const templates = {
     language: 'Go',
     features: ['Chi Router', 'HTMX', 'Templ', 'PostgreSQL', 'Tailwind'],
     popularity: 'growing',
-    difficulty: 'intermediate'
+    difficulty: 'intermediate',
+    // Generation options; `list stacks` and `describe stack` read these
+    options: {
+      db: { flag: '--database', description: 'Item persistence backend', choices: ['memory', 'postgres', 'sqlite', 'mysql'], default: 'memory' },
+      framework: { description: 'HTTP router', choices: ['chi'], default: 'chi' },
+      auth: { flag: '--auth', description: 'Login flow (sessions, JWT, or sessions plus GitHub/Google OAuth)', choices: ['none', 'session', 'jwt', 'oauth'], default: 'none' },
+      logging: { flag: '--logging', description: 'Structured logger (JSON in production, readable in development, tagged with the request ID)', choices: ['slog', 'zerolog'], default: 'slog' },
+      log: { flag: '--log', description: 'Access log line format, the default of ACCESS_LOG_FORMAT (clf is the Common Log Format, combined adds referer and user agent)', choices: ['text', 'json', 'clf', 'combined'], default: 'text' },
+      cache: { flag: '--cache', description: 'Item read cache, on when CACHE_TTL is set (in process, or Redis shared by every replica)', choices: ['memory', 'redis'], default: 'memory' },
+      observability: { flag: '--observability', description: 'OpenTelemetry tracing and metrics (otelhttp on the router, store spans, OTLP export, Prometheus /metrics)', choices: ['none', 'otel'], default: 'none' },
+      ratelimit: { flag: '--ratelimit', description: 'Token-bucket rate limiting per client and route, answering 429 with Retry-After (buckets in Redis with --cache redis)', choices: ['none', 'token-bucket'], default: 'none', enabled: 'token-bucket' },
+      ci: { flag: '--ci', description: 'CI pipeline: go vet, test and build on a Go version matrix, plus image push with layer caching and a deploy stub with the docker feature', choices: ['none', 'github', 'gitlab'], default: 'none', enabled: 'github' },
+      css: { flag: '--css', description: 'Stylesheet setup (tailwind builds web/input.css with the Tailwind CLI)', choices: ['plain', 'tailwind'], default: 'plain' },
+      bundler: { flag: '--bundler', description: 'JavaScript bundling (esbuild bundles web/js/app.js with htmx instead of loading it from unpkg)', choices: ['none', 'esbuild'], default: 'none' },
+      frontend: { flag: '--frontend', description: 'Client-side approach on top of the htmx views (alpine adds Alpine.js behaviours to them, svelte mounts Svelte components built into static/)', choices: ['htmx', 'alpine', 'svelte'], default: 'htmx' },
+      errorFormat: { flag: '--error-format', description: 'Error response bodies, the default of ERROR_FORMAT: html fragments, {"errors": [...]} JSON, or RFC 7807 application/problem+json (type, title, status, detail, instance)', choices: ['html', 'json', 'problem'], default: 'html' },
+      trailingSlash: { flag: '--trailing-slash', description: 'How /items/ is handled, baked into middleware.TrailingSlashes: strip routes it as /items, redirect answers 301 to /items, strict leaves it a 404', choices: ['strip', 'redirect', 'strict'], default: 'strip' },
+      searchEmpty: { flag: '--search-empty', description: 'What an empty search query lists, including the initial list render, the default of SEARCH_EMPTY: every item, or none until the user types', choices: ['all', 'none'], default: 'all' },
+      themeToggle: { flag: '--theme-toggle', description: 'Light/dark switch in the layout, the default of THEME_TOGGLE: the choice is kept in the theme cookie and rendered server-side on first load, so pages never flash the wrong theme', choices: ['none', 'cookie'], default: 'none', enabled: 'cookie' },
+      realtime: { flag: '--realtime', description: 'Live item updates pushed to open pages over /events (Server-Sent Events or WebSocket, via the htmx extensions)', choices: ['none', 'sse', 'websocket'], default: 'none' },
+      jobs: { flag: '--jobs', description: 'Background job queue with a worker binary (asynq on Redis, or River on Postgres)', choices: ['none', 'asynq', 'river'], default: 'none' },
+      storage: { flag: '--storage', description: 'Where item attachments (images by default) are stored: UPLOAD_DIR, or an S3 bucket with presigned downloads', choices: ['local', 's3'], default: 'local' },
+      i18n: { flag: '--i18n', description: 'Translation catalogs for the views, negotiated from the lang cookie and Accept-Language (x/text catalog in Go, or go-i18n with JSON files in locales/)', choices: ['x-text', 'go-i18n'], default: 'x-text' },
+      mailer: { flag: '--mailer', description: 'Outgoing email rendered from HTML and text templates: written to the log, or sent over SMTP (SMTP_HOST); --auth sends verification and password reset links', choices: ['console', 'smtp'], default: 'console' },
+      flags: { flag: '--flags', description: 'Feature flags gating handlers and views per request, from FEATURE_FLAGS (on, off or a percentage rollout), or evaluated through the OpenFeature SDK', choices: ['env', 'openfeature'], default: 'env' },
+      admin: { flag: '--admin', description: 'Back office under /admin: metrics cards, an item table with bulk actions and user management, limited to ADMIN_EMAILS; needs --auth', choices: ['none', 'htmx'], default: 'none', enabled: 'htmx' },
+      cleanup: { flag: '--cleanup', description: 'Background job every CLEANUP_INTERVAL that purges expired sessions, cache entries and nonces, and deletes items soft-deleted longer than ITEM_RETENTION; runs never overlap and stop on shutdown', choices: ['none', 'ticker'], default: 'none', enabled: 'ticker' },
+      responseCache: { flag: '--response-cache', description: 'In-process cache of item reads, on for RESPONSE_CACHE_TTL (30s) and dropped by writes to the same resource; requests with a session or Authorization header bypass it', choices: ['none', 'memory'], default: 'none', enabled: 'memory' },
+      e2e: { flag: '--e2e', description: 'Browser tests in e2e/ that start the app and drive the create, edit and delete flows through the htmx UI (Playwright), run by make e2e and the CI pipeline', choices: ['none', 'playwright'], default: 'none', enabled: 'playwright' },
+      mode: { description: 'Response style', choices: ['htmx'], default: 'htmx' }
+    }
+  },
+  'go-rest': {
+    name: 'Go REST API',
+    description: 'JSON-first API for SPA and mobile backends',
+    language: 'Go',
+    features: ['Chi Router', 'JSON DTOs', 'Validation', 'OpenAPI 3.0', 'Docker'],
+    popularity: 'growing',
+    difficulty: 'intermediate',
+    options: {
+      db: { flag: '--database', description: 'Item persistence backend', choices: ['memory', 'postgres', 'sqlite', 'mysql'], default: 'memory' },
+      framework: { description: 'HTTP router', choices: ['chi'], default: 'chi' },
+      auth: { description: 'Authentication scaffolding', choices: ['none'], default: 'none' },
+      logging: { flag: '--logging', description: 'Structured logger (JSON in production, readable in development, tagged with the request ID)', choices: ['slog', 'zerolog'], default: 'slog' },
+      cache: { flag: '--cache', description: 'Item read cache, on when CACHE_TTL is set (in process, or Redis shared by every replica)', choices: ['memory', 'redis'], default: 'memory' },
+      observability: { flag: '--observability', description: 'OpenTelemetry tracing and metrics (otelhttp on the router, store spans, OTLP export, Prometheus /metrics)', choices: ['none', 'otel'], default: 'none' },
+      ratelimit: { flag: '--ratelimit', description: 'Token-bucket rate limiting per client and route, answering 429 with Retry-After (buckets in Redis with --cache redis)', choices: ['none', 'token-bucket'], default: 'none', enabled: 'token-bucket' },
+      secrets: { flag: '--secrets', description: 'Secret store the config package loads credentials (DB_PASSWORD, SESSION_SECRET, ...) from at startup, named by SECRETS_PATH, with .env as the local fallback (HashiCorp Vault KV, AWS Secrets Manager, or a SOPS-encrypted file)', choices: ['none', 'vault', 'aws-sm', 'sops'], default: 'none' },
+      loadtest: { flag: '--loadtest', description: 'k6 script in loadtest/ driving the item CRUD routes with latency and error-rate thresholds (make loadtest), plus item store benchmarks (make bench)', choices: ['none', 'k6'], default: 'none', enabled: 'k6' },
+      ci: { flag: '--ci', description: 'CI pipeline: go vet, test and build on a Go version matrix, plus image push with layer caching and a deploy stub with the docker feature', choices: ['none', 'github', 'gitlab'], default: 'none', enabled: 'github' },
+      mode: { description: 'Response style', choices: ['json'], default: 'json' }
+    }
+  },
+  'fullstack-react-go': {
+    name: 'React + Go REST API',
+    description: 'Vite + React SPA on the Go REST API, embedded into one binary',
+    language: 'Go',
+    features: ['React 18', 'Vite', 'TypeScript', 'Chi Router', 'OpenAPI Types', 'go:embed', 'Docker'],
+    popularity: 'growing',
+    difficulty: 'intermediate',
+    options: {
+      db: { flag: '--database', description: 'Item persistence backend', choices: ['memory', 'postgres', 'sqlite', 'mysql'], default: 'memory' },
+      framework: { description: 'Frontend framework', choices: ['react'], default: 'react' },
+      logging: { flag: '--logging', description: 'Structured logger (JSON in production, readable in development, tagged with the request ID)', choices: ['slog', 'zerolog'], default: 'slog' },
+      cache: { flag: '--cache', description: 'Item read cache, on when CACHE_TTL is set (in process, or Redis shared by every replica)', choices: ['memory', 'redis'], default: 'memory' },
+      observability: { flag: '--observability', description: 'OpenTelemetry tracing and metrics (otelhttp on the router, store spans, OTLP export, Prometheus /metrics)', choices: ['none', 'otel'], default: 'none' },
+      ratelimit: { flag: '--ratelimit', description: 'Token-bucket rate limiting per client and route, answering 429 with Retry-After (buckets in Redis with --cache redis)', choices: ['none', 'token-bucket'], default: 'none', enabled: 'token-bucket' },
+      secrets: { flag: '--secrets', description: 'Secret store the config package loads credentials (DB_PASSWORD, SESSION_SECRET, ...) from at startup, named by SECRETS_PATH, with .env as the local fallback (HashiCorp Vault KV, AWS Secrets Manager, or a SOPS-encrypted file)', choices: ['none', 'vault', 'aws-sm', 'sops'], default: 'none' },
+      loadtest: { flag: '--loadtest', description: 'k6 script in loadtest/ driving the item CRUD routes with latency and error-rate thresholds (make loadtest), plus item store benchmarks (make bench)', choices: ['none', 'k6'], default: 'none', enabled: 'k6' },
+      ci: { flag: '--ci', description: 'CI pipeline: go vet, test and build on a Go version matrix, plus image push with layer caching and a deploy stub with the docker feature', choices: ['none', 'github', 'gitlab'], default: 'none', enabled: 'github' }
+    }
+  },
+  'go-grpc': {
+    name: 'Go gRPC + Connect',
+    description: 'Protobuf-first service over gRPC, Connect and REST/JSON',
+    language: 'Go',
+    features: ['Protobuf', 'buf', 'connect-go', 'REST Transcoding', 'gRPC Reflection', 'Docker'],
+    popularity: 'growing',
+    difficulty: 'advanced',
+    options: {
+      db: { flag: '--database', description: 'Item persistence backend', choices: ['memory', 'postgres', 'sqlite', 'mysql'], default: 'memory' },
+      framework: { description: 'RPC framework', choices: ['connect-go'], default: 'connect-go' },
+      logging: { flag: '--logging', description: 'Structured logger (JSON in production, readable in development, tagged with the request ID)', choices: ['slog', 'zerolog'], default: 'slog' },
+      cache: { flag: '--cache', description: 'Item read cache, on when CACHE_TTL is set (in process, or Redis shared by every replica)', choices: ['memory', 'redis'], default: 'memory' },
+      ci: { flag: '--ci', description: 'CI pipeline: go vet, test and build on a Go version matrix, plus image push with layer caching and a deploy stub with the docker feature', choices: ['none', 'github', 'gitlab'], default: 'none', enabled: 'github' },
+      mode: { description: 'Protocols', choices: ['grpc+connect+rest'], default: 'grpc+connect+rest' }
+    }
+  },
+  'go-monorepo': {
+    name: 'Go Monorepo',
+    description: 'Go workspace of services (API, web, worker) sharing a pkg/ module',
+    language: 'Go',
+    features: ['go.work', 'Shared pkg/ Module', 'Docker Compose', 'Per-service Makefiles'],
+    popularity: 'growing',
+    difficulty: 'advanced',
+    // Also chosen by --layout monorepo. Stack options apply to every
+    // service whose stack has them.
+    options: {
+      services: { description: 'Services under services/, set with --services (comma-separated roles, or name:stack with go-rest, go-htmx, go-grpc or go-worker)', choices: ['api', 'web', 'grpc', 'worker'], default: 'api,web' },
+      db: { flag: '--database', description: 'Item persistence backend, shared by every service', choices: ['memory', 'postgres', 'sqlite', 'mysql'], default: 'memory' },
+      auth: { flag: '--auth', description: 'Login flow of the web services', choices: ['none', 'session', 'jwt', 'oauth'], default: 'none' },
+      logging: { flag: '--logging', description: 'Structured logger of every service', choices: ['slog', 'zerolog'], default: 'slog' },
+      ci: { flag: '--ci', description: 'CI pipeline for the whole workspace, with an image per service with the docker feature', choices: ['none', 'github', 'gitlab'], default: 'none', enabled: 'github' },
+      css: { flag: '--css', description: 'Stylesheet setup of the web services', choices: ['plain', 'tailwind'], default: 'plain' },
+      bundler: { flag: '--bundler', description: 'JavaScript bundling of the web services', choices: ['none', 'esbuild'], default: 'none' },
+      frontend: { flag: '--frontend', description: 'Client-side approach of the web services', choices: ['htmx', 'alpine', 'svelte'], default: 'htmx' },
+      realtime: { flag: '--realtime', description: 'Live item updates in the web services', choices: ['none', 'sse', 'websocket'], default: 'none' }
+    }
   },
 
   // AI/ML Focused
@@ -134,20 +270,28 @@ export const templates = {
   }
 };
 
+// Stacks that declare generation options in the registry
+export function configurableStacks() {
+  return Object.entries(templates)
+    .filter(([, template]) => template.options)
+    .map(([id, template]) => ({ id, ...template }));
+}
+
 export const categories = {
   frontend: ['react-vite', 'nextjs-saas'],
-  backend: ['node-express-api', 'fastapi-modern', 'django-pro', 'flask-api', 'go-fiber', 'rust-axum'],
-  fullstack: ['nextjs-saas', 'rust-fullstack', 'go-htmx', 'elixir-phoenix'],
+  backend: ['node-express-api', 'fastapi-modern', 'python-fastapi', 'django-pro', 'flask-api', 'go-fiber', 'go-rest', 'go-grpc', 'rust-axum'],
+  fullstack: ['nextjs-saas', 'rust-fullstack', 'node-htmx', 'go-htmx', 'fullstack-react-go', 'go-monorepo', 'elixir-phoenix'],
   ai: ['ai-saas-nextjs', 'python-ml-api'],
   mobile: ['react-native-expo'],
-  api: ['node-express-api', 'fastapi-modern', 'go-fiber', 'rust-axum', 'dotnet-minimal-api']
+  api: ['node-express-api', 'fastapi-modern', 'python-fastapi', 'go-fiber', 'go-rest', 'go-grpc', 'rust-axum', 'dotnet-minimal-api']
 };
 
 export const languages = {
   TypeScript: ['nextjs-saas', 'react-vite', 'node-express-api', 'ai-saas-nextjs', 'react-native-expo'],
-  Python: ['fastapi-modern', 'django-pro', 'flask-api', 'python-ml-api'],
+  JavaScript: ['node-htmx'],
+  Python: ['fastapi-modern', 'python-fastapi', 'django-pro', 'flask-api', 'python-ml-api'],
   Rust: ['rust-axum', 'rust-fullstack'],
-  Go: ['go-fiber', 'go-htmx'],
+  Go: ['go-fiber', 'go-htmx', 'go-rest', 'fullstack-react-go', 'go-grpc', 'go-monorepo'],
   'C#': ['dotnet-minimal-api'],
   Elixir: ['elixir-phoenix']
 };
@@ -0,0 +1,59 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+
+// Env vars whose values belong in a Secret rather than a ConfigMap
+const secretPattern = /(SECRET|PASSWORD|TOKEN|PRIVATE|_KEY$|^DATABASE_URL$)/;
+
+// Kubernetes names are DNS-1123 labels
+function dnsLabel(value) {
+  return value.toLowerCase().replace(/[^a-z0-9-]+/g, '-').replace(/^-+|-+$/g, '').slice(0, 63) || 'app';
+}
+
+// Parse KEY=VALUE lines, skipping comments and blanks
+function parseEnvFile(text) {
+  const env = [];
+  for (const line of text.split('\n')) {
+    const match = /^\s*([A-Z_][A-Z0-9_]*)=(.*)$/.exec(line);
+    if (match) {
+      env.push({ name: match[1], value: match[2].trim() });
+    }
+  }
+  return env;
+}
+
+// Inspect a generated project for what its manifests need: a name, the
+// HTTP port, health endpoints and the env vars listed in .env.example.
+export async function detectApp(projectDir) {
+  const read = file => fs.readFile(path.join(projectDir, file), 'utf8').catch(() => '');
+
+  const goMod = await read('go.mod');
+  const moduleName = (/^module\s+(\S+)/m.exec(goMod) || [])[1];
+  const name = dnsLabel(moduleName ? moduleName.split('/').pop() : path.basename(projectDir));
+
+  const env = parseEnvFile(await read('.env.example'));
+  const portVar = env.find(v => v.name === 'PORT');
+  const port = Number(portVar && portVar.value) || 8080;
+
+  // Health routes as registered in main.go: /healthz and /readyz, or the
+  // older /health and /health/ready (still used by the worker)
+  const main = await read('main.go');
+  const route = (...paths) => paths.find(p => main.includes(`"${p}"`)) || null;
+  const health = {
+    liveness: route('/healthz', '/health'),
+    readiness: route('/readyz', '/health/ready')
+  };
+  health.readiness = health.readiness || health.liveness;
+
+  const config = {};
+  const secrets = {};
+  for (const { name: key, value } of env) {
+    if (key === 'PORT') continue;
+    if (secretPattern.test(key)) {
+      secrets[key] = value;
+    } else {
+      config[key] = value;
+    }
+  }
+
+  return { name, port, health, config, secrets };
+}
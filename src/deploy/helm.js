@@ -0,0 +1,225 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+
+function yamlMap(map, indent) {
+  const pad = ' '.repeat(indent);
+  const entries = Object.entries(map);
+  if (entries.length === 0) return ' {}\n';
+  return '\n' + entries.map(([key, value]) => `${pad}${key}: ${JSON.stringify(value)}\n`).join('');
+}
+
+function chart(app) {
+  return `apiVersion: v2
+name: ${app.name}
+description: Helm chart for ${app.name}
+type: application
+version: 0.1.0
+appVersion: "latest"
+`;
+}
+
+function values(app) {
+  return `replicaCount: 2
+
+image:
+  repository: ${app.name}
+  tag: latest
+  pullPolicy: IfNotPresent
+
+containerPort: ${app.port}
+
+service:
+  port: 80
+
+ingress:
+  enabled: true
+  className: ""
+  host: ${app.name}.example.com
+
+autoscaling:
+  enabled: true
+  minReplicas: 2
+  maxReplicas: 10
+  targetCPUUtilizationPercentage: 70
+
+probes:
+  liveness: ${app.health.liveness || '""'}
+  readiness: ${app.health.readiness || '""'}
+
+resources:
+  requests:
+    cpu: 100m
+    memory: 64Mi
+  limits:
+    memory: 256Mi
+
+# Non-secret settings (from .env.example), rendered into a ConfigMap
+env:${yamlMap(app.config, 2)}
+# Rendered into a Secret; set real values with --set or a private values file
+secretEnv:${yamlMap(app.secrets, 2)}`;
+}
+
+const helpers = `{{- define "app.fullname" -}}
+{{- .Release.Name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
+{{- define "app.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+`;
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "app.fullname" . }}
+  labels:
+    {{- include "app.labels" . | nindent 4 }}
+spec:
+  {{- if not .Values.autoscaling.enabled }}
+  replicas: {{ .Values.replicaCount }}
+  {{- end }}
+  selector:
+    matchLabels:
+      {{- include "app.labels" . | nindent 6 }}
+  template:
+    metadata:
+      labels:
+        {{- include "app.labels" . | nindent 8 }}
+      annotations:
+        checksum/config: {{ include (print $.Template.BasePath "/configmap.yaml") . | sha256sum }}
+    spec:
+      securityContext:
+        runAsNonRoot: true
+      containers:
+        - name: {{ .Chart.Name }}
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          imagePullPolicy: {{ .Values.image.pullPolicy }}
+          ports:
+            - name: http
+              containerPort: {{ .Values.containerPort }}
+          env:
+            - name: PORT
+              value: {{ .Values.containerPort | quote }}
+          envFrom:
+            - configMapRef:
+                name: {{ include "app.fullname" . }}-config
+            - secretRef:
+                name: {{ include "app.fullname" . }}-secrets
+          {{- with .Values.probes.liveness }}
+          livenessProbe:
+            httpGet:
+              path: {{ . }}
+              port: http
+          {{- end }}
+          {{- with .Values.probes.readiness }}
+          readinessProbe:
+            httpGet:
+              path: {{ . }}
+              port: http
+          {{- end }}
+          resources:
+            {{- toYaml .Values.resources | nindent 12 }}
+`;
+
+const service = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "app.fullname" . }}
+  labels:
+    {{- include "app.labels" . | nindent 4 }}
+spec:
+  selector:
+    {{- include "app.labels" . | nindent 4 }}
+  ports:
+    - name: http
+      port: {{ .Values.service.port }}
+      targetPort: http
+`;
+
+const ingress = `{{- if .Values.ingress.enabled }}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ include "app.fullname" . }}
+spec:
+  {{- with .Values.ingress.className }}
+  ingressClassName: {{ . }}
+  {{- end }}
+  rules:
+    - host: {{ .Values.ingress.host }}
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: {{ include "app.fullname" . }}
+                port:
+                  name: http
+{{- end }}
+`;
+
+const hpa = `{{- if .Values.autoscaling.enabled }}
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{ include "app.fullname" . }}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: {{ include "app.fullname" . }}
+  minReplicas: {{ .Values.autoscaling.minReplicas }}
+  maxReplicas: {{ .Values.autoscaling.maxReplicas }}
+  metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: {{ .Values.autoscaling.targetCPUUtilizationPercentage }}
+{{- end }}
+`;
+
+const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "app.fullname" . }}-config
+data:
+  {{- range $key, $value := .Values.env }}
+  {{ $key }}: {{ $value | quote }}
+  {{- end }}
+`;
+
+const secret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ include "app.fullname" . }}-secrets
+type: Opaque
+stringData:
+  {{- range $key, $value := .Values.secretEnv }}
+  {{ $key }}: {{ $value | quote }}
+  {{- end }}
+`;
+
+// A Helm chart whose values.yaml is pre-filled from the detected app
+export async function generateHelm(outDir, app) {
+  const files = {
+    'Chart.yaml': chart(app),
+    'values.yaml': values(app),
+    'templates/_helpers.tpl': helpers,
+    'templates/deployment.yaml': deployment,
+    'templates/service.yaml': service,
+    'templates/ingress.yaml': ingress,
+    'templates/hpa.yaml': hpa,
+    'templates/configmap.yaml': configMap,
+    'templates/secret.yaml': secret
+  };
+
+  for (const [file, content] of Object.entries(files)) {
+    await fs.ensureDir(path.dirname(path.join(outDir, file)));
+    await fs.writeFile(path.join(outDir, file), content);
+  }
+  return Object.keys(files);
+}
@@ -0,0 +1,35 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { detectApp } from './detect.js';
+import { generateK8s } from './k8s.js';
+import { generateHelm } from './helm.js';
+
+// Deployment targets. Each writes its files under the returned directory
+// and reports their paths; add new targets (e.g. fly, render) here.
+export const targets = {
+  k8s: {
+    description: 'Kubernetes manifests (Deployment, Service, Ingress, HPA, ConfigMap, Secret)',
+    outDir: (projectDir) => path.join(projectDir, 'deploy', 'k8s'),
+    generate: generateK8s
+  },
+  helm: {
+    description: 'Helm chart with values pre-filled from the app',
+    outDir: (projectDir, app) => path.join(projectDir, 'deploy', 'helm', app.name),
+    generate: generateHelm
+  }
+};
+
+export async function generateDeployment(projectDir, targetName, { force = false } = {}) {
+  const target = targets[targetName];
+  if (!target) {
+    throw new Error(`Unknown deploy target "${targetName}" (expected ${Object.keys(targets).join(', ')})`);
+  }
+
+  const app = await detectApp(projectDir);
+  const outDir = target.outDir(projectDir, app);
+  if (!force && await fs.pathExists(outDir)) {
+    throw new Error(`${path.relative(projectDir, outDir)} already exists (use --force to overwrite)`);
+  }
+  const files = await target.generate(outDir, app);
+  return { app, outDir, files };
+}
@@ -0,0 +1,167 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+
+function yamlMap(map, indent) {
+  const pad = ' '.repeat(indent);
+  const entries = Object.entries(map);
+  if (entries.length === 0) return `${pad}{}\n`;
+  return entries.map(([key, value]) => `${pad}${key}: ${JSON.stringify(value)}\n`).join('');
+}
+
+function probe(pathName) {
+  return `            httpGet:
+              path: ${pathName}
+              port: http
+`;
+}
+
+function deployment(app) {
+  let probes = '';
+  if (app.health.liveness) {
+    probes += `          livenessProbe:
+${probe(app.health.liveness)}            periodSeconds: 10
+`;
+  }
+  if (app.health.readiness) {
+    probes += `          readinessProbe:
+${probe(app.health.readiness)}            periodSeconds: 5
+`;
+  }
+
+  return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ${app.name}
+  labels:
+    app: ${app.name}
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: ${app.name}
+  template:
+    metadata:
+      labels:
+        app: ${app.name}
+    spec:
+      securityContext:
+        runAsNonRoot: true
+      containers:
+        - name: ${app.name}
+          image: ${app.name}:latest
+          ports:
+            - name: http
+              containerPort: ${app.port}
+          env:
+            - name: PORT
+              value: "${app.port}"
+          envFrom:
+            - configMapRef:
+                name: ${app.name}-config
+            - secretRef:
+                name: ${app.name}-secrets
+${probes}          resources:
+            requests:
+              cpu: 100m
+              memory: 64Mi
+            limits:
+              memory: 256Mi
+`;
+}
+
+function service(app) {
+  return `apiVersion: v1
+kind: Service
+metadata:
+  name: ${app.name}
+  labels:
+    app: ${app.name}
+spec:
+  selector:
+    app: ${app.name}
+  ports:
+    - name: http
+      port: 80
+      targetPort: http
+`;
+}
+
+function ingress(app) {
+  return `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ${app.name}
+spec:
+  rules:
+    - host: ${app.name}.example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: ${app.name}
+                port:
+                  name: http
+`;
+}
+
+function hpa(app) {
+  return `apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: ${app.name}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: ${app.name}
+  minReplicas: 2
+  maxReplicas: 10
+  metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: 70
+`;
+}
+
+function configMap(app) {
+  return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ${app.name}-config
+data:
+${yamlMap(app.config, 2)}`;
+}
+
+function secret(app) {
+  return `# Values come from .env.example; fill them in before applying
+apiVersion: v1
+kind: Secret
+metadata:
+  name: ${app.name}-secrets
+type: Opaque
+stringData:
+${yamlMap(app.secrets, 2)}`;
+}
+
+// Plain manifests, one resource per file, applied with `kubectl apply -f`
+export async function generateK8s(outDir, app) {
+  const files = {
+    'deployment.yaml': deployment(app),
+    'service.yaml': service(app),
+    'ingress.yaml': ingress(app),
+    'hpa.yaml': hpa(app),
+    'configmap.yaml': configMap(app),
+    'secret.yaml': secret(app)
+  };
+
+  await fs.ensureDir(outDir);
+  for (const [file, content] of Object.entries(files)) {
+    await fs.writeFile(path.join(outDir, file), content);
+  }
+  return Object.keys(files);
+}
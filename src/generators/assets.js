@@ -0,0 +1,55 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import yaml from 'js-yaml';
+import { hooksFile } from '../utils/hooks.js';
+
+// Set up the npm side of a Go project's asset pipeline (--css tailwind,
+// --bundler esbuild). Each step is { script, output, build, watch,
+// devDependencies }: package.json gets build:<script> and watch:<script>
+// plus build/watch running them all, built outputs are git-ignored, and
+// the post-generation hooks install the tools and run a first build.
+export async function generateAssetPipeline(projectPath, steps, { name }) {
+  const scripts = {
+    build: steps.map(step => `npm run build:${step.script}`).join(' && '),
+    // Watchers run side by side until interrupted
+    watch: steps.length === 1
+      ? `npm run watch:${steps[0].script}`
+      : `${steps.map(step => `npm run watch:${step.script} &`).join(' ')} wait`
+  };
+  for (const step of steps) {
+    scripts[`build:${step.script}`] = step.build;
+    scripts[`watch:${step.script}`] = step.watch;
+  }
+  const devDependencies = Object.assign({}, ...steps.map(step => step.devDependencies));
+  const packageJson = { name, private: true, scripts, devDependencies };
+  await fs.writeFile(path.join(projectPath, 'package.json'), JSON.stringify(packageJson, null, 2));
+
+  // Outputs are rebuilt from web/, so the sample's copies go
+  for (const step of steps) {
+    await fs.remove(path.join(projectPath, step.output));
+  }
+  const gitignore = path.join(projectPath, '.gitignore');
+  const ignored = ['# Asset pipeline (make assets)', 'node_modules/', ...steps.map(step => `/${step.output}`)];
+  const current = (await fs.readFile(gitignore, 'utf8')).trimEnd();
+  await fs.writeFile(gitignore, `${current}\n\n${ignored.join('\n')}\n`);
+
+  await addHooks(projectPath, [
+    { name: 'Install asset tools', run: 'npm install' },
+    { name: 'Build CSS/JS assets', run: 'npm run build' }
+  ]);
+}
+
+// Add hooks before the optional ones (git init), which come last. The
+// file is edited as text so its comments survive.
+async function addHooks(projectPath, hooks) {
+  const file = path.join(projectPath, hooksFile);
+  const text = await fs.readFile(file, 'utf8');
+  const lines = text.split('\n');
+  const entries = lines.flatMap((line, i) => (line.startsWith('  - ') ? [i] : []));
+  const optional = yaml.load(text).hooks.findIndex(hook => hook.optional);
+  const at = optional === -1 ? lines.length - (text.endsWith('\n') ? 1 : 0) : entries[optional];
+
+  const added = hooks.flatMap(hook => [`  - name: ${hook.name}`, `    run: ${hook.run}`]);
+  lines.splice(at, 0, ...added);
+  await fs.writeFile(file, lines.join('\n'));
+}
@@ -0,0 +1,385 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { projectVariables, renderString } from '../templating/index.js';
+
+// CI pipelines for the Go stacks (--ci github|gitlab). The pipelines are
+// written with {{ .Name }} placeholders and rendered with the project
+// variables, so the image names follow the app name and {{ .Registry }}.
+// GitHub's ${{ }} expressions and metadata-action's {{version}} aren't
+// placeholders and pass through untouched.
+
+// The provider a project gets: --ci, or GitHub Actions for the ci feature
+export function ciProvider(features, options = {}) {
+  if (options.ci && options.ci !== 'none') {
+    return options.ci;
+  }
+  return features.includes('ci') ? 'github' : 'none';
+}
+
+// Test databases run as CI service containers; TEST_DATABASE_URL points
+// the store tests at them (they are skipped without it)
+const testDatabases = {
+  postgres: {
+    image: 'postgres:16-alpine',
+    env: { POSTGRES_DB: 'app', POSTGRES_USER: 'app', POSTGRES_PASSWORD: 'app' },
+    port: 5432,
+    health: 'pg_isready -U app -d app',
+    url: host => `postgres://app:app@${host}:5432/app?sslmode=disable`
+  },
+  mysql: {
+    image: 'mysql:8.0',
+    env: { MYSQL_DATABASE: 'app', MYSQL_USER: 'app', MYSQL_PASSWORD: 'app', MYSQL_ROOT_PASSWORD: 'root' },
+    port: 3306,
+    health: 'mysqladmin ping -h 127.0.0.1 -uapp -papp',
+    url: host => `app:app@tcp(${host}:3306)/app`
+  }
+};
+
+const templ = 'go run github.com/a-h/templ/cmd/templ generate';
+
+// The code generation and frontend builds a unit (the project, or one
+// service of a monorepo) needs before it compiles: static/ and web/dist
+// are embedded, and templ and protobuf code are generated
+function prepareSteps(unit) {
+  return [
+    unit.assets.length > 0 && { name: 'Build assets', run: 'make assets' },
+    unit.web && { name: 'Build the React frontend', run: 'make web' },
+    unit.templ && { name: 'Generate templ views', run: templ },
+    unit.proto && { name: 'Lint and generate protobuf code', run: 'make proto' }
+  ].filter(Boolean).map(step => ({ ...step, dir: unit.dir }));
+}
+
+// Image names: <registry>/<app>, or <registry>/<app>-<service> in a
+// monorepo. Without a registry the provider's own is used.
+function imageName(registry, fallback, suffix) {
+  return `${registry ? '{{ .Registry }}' : fallback}/{{ .AppName }}${suffix}`;
+}
+
+// The Playwright suite of a unit generated with --e2e
+function e2eDir(dir) {
+  return dir === '.' ? 'e2e' : `${dir}/e2e`;
+}
+
+// Browser tests (--e2e) run after the unit tests: Chromium is installed
+// with its system libraries, make e2e starts the app and drives it, and
+// the HTML report is kept when they fail
+function githubE2E(units, goVersion) {
+  const suites = units.filter(unit => unit.e2e);
+  if (suites.length === 0) {
+    return '';
+  }
+  const inDir = dir => (dir === '.' ? '' : `
+        working-directory: ${dir}`);
+  return `
+  e2e:
+    name: End-to-end tests
+    needs: test
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: '${goVersion}'
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20${suites.map(unit => `
+      - name: Install Playwright and Chromium
+        run: npm install && npx playwright install --with-deps chromium
+        working-directory: ${e2eDir(unit.dir)}
+      - name: End-to-end tests
+        run: make e2e${inDir(unit.dir)}
+      - uses: actions/upload-artifact@v4
+        if: failure()
+        with:
+          name: playwright-report${unit.dir === '.' ? '' : `-${unit.name}`}
+          path: ${e2eDir(unit.dir)}/playwright-report/`).join('')}
+`;
+}
+
+function github({ units, packages, goVersion, database, docker, monorepo, registry }) {
+  const node = units.some(unit => unit.assets.length > 0 || unit.web);
+  const db = database && testDatabases[database];
+  const steps = units.flatMap(prepareSteps).map(step => `
+      - name: ${step.name}
+        run: ${step.run}${step.dir === '.' ? '' : `
+        working-directory: ${step.dir}`}`).join('');
+
+  const services = db ? `
+    services:
+      db:
+        image: ${db.image}
+        env:
+${Object.entries(db.env).map(([key, value]) => `          ${key}: ${value}`).join('\n')}
+        ports:
+          - ${db.port}:${db.port}
+        options: >-
+          --health-cmd "${db.health}"
+          --health-interval 5s --health-timeout 3s --health-retries 20
+    env:
+      TEST_DATABASE_URL: ${db.url('localhost')}` : '';
+
+  let text = `# Vet, test and build on every push and pull request${docker ? `; images are
+# pushed from main and version tags, and v* tags can deploy` : ''}.
+name: CI
+
+on:
+  push:
+    branches: [main]
+    tags: ['v*']
+  pull_request:
+
+permissions:
+  contents: read
+
+jobs:
+  test:
+    name: Go \${{ matrix.go }}
+    runs-on: ubuntu-latest
+    strategy:
+      fail-fast: false
+      matrix:
+        go: ['${goVersion}', stable]${services}
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: \${{ matrix.go }}
+          cache-dependency-path: ${monorepo ? "'**/go.sum'" : 'go.sum'}${node ? `
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20` : ''}${steps}
+      - name: Vet
+        run: go vet ${packages}
+      - name: Test
+        run: go test -race ${packages}
+      - name: Build
+        run: go build ${packages}
+`;
+  text += githubE2E(units, goVersion);
+  if (!docker) {
+    return text;
+  }
+
+  // GHCR takes the workflow's token; other registries take the
+  // REGISTRY_USERNAME and REGISTRY_PASSWORD secrets
+  const ghcr = !registry || registry.startsWith('ghcr.io/');
+  const image = imageName(registry, 'ghcr.io/${{ github.repository_owner }}', monorepo ? '-${{ matrix.service }}' : '');
+  const login = ghcr
+    ? `registry: ghcr.io
+          username: \${{ github.actor }}
+          password: \${{ secrets.GITHUB_TOKEN }}`
+    : `registry: ${registry.split('/')[0]}
+          username: \${{ secrets.REGISTRY_USERNAME }}
+          password: \${{ secrets.REGISTRY_PASSWORD }}`;
+  const matrix = monorepo ? `
+    strategy:
+      matrix:
+        service: [${units.filter(unit => unit.docker).map(unit => unit.name).join(', ')}]` : '';
+  const scope = monorepo ? '${{ matrix.service }}' : '{{ .AppName }}';
+
+  text += `
+  image:
+    name: Image${monorepo ? ' ${{ matrix.service }}' : ''}
+    needs: test
+    if: github.event_name == 'push'
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+      packages: write${matrix}
+    steps:
+      - uses: actions/checkout@v4
+      - uses: docker/setup-buildx-action@v3
+      - uses: docker/login-action@v3
+        with:
+          ${login}
+      - id: meta
+        uses: docker/metadata-action@v5
+        with:
+          images: ${image}
+          tags: |
+            type=ref,event=branch
+            type=semver,pattern={{version}}
+            type=sha
+      - uses: docker/build-push-action@v6
+        with:
+          context: .
+          file: ${monorepo ? 'services/${{ matrix.service }}/Dockerfile' : 'Dockerfile'}
+          push: true
+          tags: \${{ steps.meta.outputs.tags }}
+          labels: \${{ steps.meta.outputs.labels }}
+          cache-from: type=gha,scope=${scope}
+          cache-to: type=gha,mode=max,scope=${scope}
+
+  # A stub: set the DEPLOY_ENABLED repository variable to true and replace
+  # the step with the rollout (kubectl set image, helm upgrade, ...)
+  deploy:
+    name: Deploy
+    needs: image
+    if: vars.DEPLOY_ENABLED == 'true' && startsWith(github.ref, 'refs/tags/v')
+    runs-on: ubuntu-latest
+    environment: production
+    steps:
+      - uses: actions/checkout@v4
+      - name: Deploy \${{ github.ref_name }}
+        run: echo "Deploy ${imageName(registry, 'ghcr.io/${{ github.repository_owner }}', monorepo ? '-<service>' : '')}:\${GITHUB_REF_NAME#v} here"
+`;
+  return text;
+}
+
+function gitlab({ units, packages, goVersion, database, docker, monorepo, registry }) {
+  const frontends = units.filter(unit => unit.assets.length > 0 || unit.web);
+  const db = database && testDatabases[database];
+  const inDir = (dir, run) => (dir === '.' ? run : `(cd ${dir} && ${run})`);
+  const goSteps = units.flatMap(prepareSteps).filter(step => !/^make (assets|web)$/.test(step.run));
+
+  let text = `# Vet, test and build on every push and merge request${docker ? `; images are
+# pushed from the default branch and version tags, and v* tags can deploy` : ''}.
+stages: [${[frontends.length > 0 && 'prepare', 'test', docker && 'build', docker && 'deploy'].filter(Boolean).join(', ')}]
+
+# Go modules are cached in the project directory, where GitLab can keep them
+.go-cache:
+  variables:
+    GOPATH: $CI_PROJECT_DIR/.go
+  cache:
+    key:${monorepo ? ' go-modules' : `
+      files: [go.sum]`}
+    paths: [.go/pkg/mod/]
+`;
+
+  if (frontends.length > 0) {
+    const outputs = frontends.flatMap(unit => [...unit.assets, ...(unit.web ? ['web/dist/'] : [])]
+      .map(output => (unit.dir === '.' ? output : `${unit.dir}/${output}`)));
+    const runs = units.flatMap(prepareSteps).filter(step => /^make (assets|web)$/.test(step.run));
+    text += `
+# The binary embeds the built assets, so they are built first and handed to
+# the Go jobs as artifacts
+frontend:
+  stage: prepare
+  image: node:20
+  script:
+${runs.map(step => `    - ${inDir(step.dir, step.run)}`).join('\n')}
+  artifacts:
+    paths:
+${outputs.map(output => `      - ${output}`).join('\n')}
+    expire_in: 1 day
+`;
+  }
+
+  const services = db ? `
+  services:
+    - name: ${db.image}
+      alias: db
+  variables:
+${Object.entries(db.env).map(([key, value]) => `    ${key}: ${value}`).join('\n')}
+    TEST_DATABASE_URL: ${db.url('db')}` : '';
+
+  text += `
+test:
+  stage: test
+  extends: .go-cache
+  image: golang:$GO_VERSION
+  parallel:
+    matrix:
+      # "1" is the newest Go release
+      - GO_VERSION: ['${goVersion}', '1']${services}
+  script:
+${goSteps.map(step => `    - ${inDir(step.dir, step.run)}`).join('\n')}${goSteps.length ? '\n' : ''}    - go vet ${packages}
+    - go test -race ${packages}
+    - go build ${packages}
+`;
+  const suites = units.filter(unit => unit.e2e);
+  if (suites.length > 0) {
+    text += `
+# Browser tests: the Go image gets Node, and Playwright installs Chromium
+# with its system libraries. make e2e starts the app and drives it; the
+# HTML report is kept when they fail.
+e2e:
+  stage: test
+  extends: .go-cache
+  image: golang:${goVersion}
+  script:
+    - apt-get update && apt-get install -y --no-install-recommends nodejs npm
+${suites.map(unit => `    - (cd ${e2eDir(unit.dir)} && npm install && npx playwright install --with-deps chromium)
+    - ${inDir(unit.dir, 'make e2e')}`).join('\n')}
+  artifacts:
+    when: on_failure
+    paths:
+${suites.map(unit => `      - ${e2eDir(unit.dir)}/playwright-report/`).join('\n')}
+    expire_in: 1 week
+`;
+  }
+  if (!docker) {
+    return text;
+  }
+
+  // The project's own registry takes the job token; others take the
+  // REGISTRY_USERNAME and REGISTRY_PASSWORD CI/CD variables
+  const own = !registry || registry.startsWith('registry.gitlab.com/');
+  const image = imageName(registry, '$CI_REGISTRY_IMAGE', monorepo ? '-$SERVICE' : '');
+  const login = own
+    ? 'echo "$CI_REGISTRY_PASSWORD" | docker login -u "$CI_REGISTRY_USER" --password-stdin "$CI_REGISTRY"'
+    : `echo "$REGISTRY_PASSWORD" | docker login -u "$REGISTRY_USERNAME" --password-stdin ${registry.split('/')[0]}`;
+  const matrix = monorepo ? `
+  parallel:
+    matrix:
+      - SERVICE: [${units.filter(unit => unit.docker).map(unit => unit.name).join(', ')}]` : '';
+
+  text += `
+# The previous image is pulled so its layers (inline cache) are reused
+image:
+  stage: build
+  image: docker:27
+  services: [docker:27-dind]${matrix}
+  variables:
+    DOCKER_TLS_CERTDIR: /certs
+    IMAGE: ${image}
+  rules:
+    - if: $CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH
+    - if: $CI_COMMIT_TAG =~ /^v/
+  before_script:
+    - ${login}
+  script:
+    - TAG=\${CI_COMMIT_TAG#v}; TAG=\${TAG:-$CI_COMMIT_SHORT_SHA}
+    - docker pull "$IMAGE:latest" || true
+    - docker build --cache-from "$IMAGE:latest" --build-arg BUILDKIT_INLINE_CACHE=1 -f ${monorepo ? 'services/$SERVICE/Dockerfile' : 'Dockerfile'} -t "$IMAGE:$TAG" -t "$IMAGE:latest" .
+    - docker push "$IMAGE:$TAG"
+    - docker push "$IMAGE:latest"
+
+# A stub: set the DEPLOY_ENABLED CI/CD variable to true and replace the
+# script with the rollout (kubectl set image, helm upgrade, ...)
+deploy:
+  stage: deploy
+  image: alpine:3.20
+  environment: production
+  rules:
+    - if: $DEPLOY_ENABLED == "true" && $CI_COMMIT_TAG =~ /^v/
+  script:
+    - echo "Deploy ${imageName(registry, '$CI_REGISTRY_IMAGE', monorepo ? '-<service>' : '')}:\${CI_COMMIT_TAG#v} here"
+`;
+  return text;
+}
+
+const providers = {
+  github: { file: path.join('.github', 'workflows', 'ci.yml'), generate: github },
+  gitlab: { file: '.gitlab-ci.yml', generate: gitlab }
+};
+
+// Write the CI pipeline of a Go project or workspace. units are the
+// project, or each service of a monorepo, as generateGo describes them:
+// { name, dir, templ, proto, assets, web, e2e, docker }. packages are the go
+// vet/test/build patterns, database the backend the store tests run
+// against (postgres and mysql get a service container), and docker adds
+// the image and deploy jobs.
+export async function generateGoCI(projectPath, provider, { units, packages = './...', database = null, docker = false, monorepo = false, options = {} }) {
+  if (provider === 'none') {
+    return;
+  }
+  const vars = await projectVariables(projectPath, options);
+  const goFile = monorepo ? 'go.work' : 'go.mod';
+  const goVersion = /^go (\S+)$/m.exec(await fs.readFile(path.join(projectPath, goFile), 'utf8'))[1];
+
+  const { file, generate } = providers[provider];
+  const text = generate({ units, packages, goVersion, database, docker, monorepo, registry: vars.Registry });
+  await fs.ensureDir(path.dirname(path.join(projectPath, file)));
+  await fs.writeFile(path.join(projectPath, file), renderString(text, vars));
+}
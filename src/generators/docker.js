@@ -0,0 +1,361 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+
+// Database services for docker-compose, keyed by --database. `url` is the
+// DATABASE_URL the app container connects with.
+const databaseServices = {
+  postgres: {
+    url: 'postgres://app:app@db:5432/app?sslmode=disable',
+    service: `  db:
+    image: postgres:16-alpine
+    environment:
+      POSTGRES_DB: app
+      POSTGRES_USER: app
+      POSTGRES_PASSWORD: app
+    healthcheck:
+      test: ["CMD-SHELL", "pg_isready -U app -d app"]
+      interval: 5s
+      timeout: 3s
+      retries: 10
+    volumes:
+      - db-data:/var/lib/postgresql/data
+`
+  },
+  mysql: {
+    url: 'app:app@tcp(db:3306)/app',
+    service: `  db:
+    image: mysql:8.0
+    environment:
+      MYSQL_DATABASE: app
+      MYSQL_USER: app
+      MYSQL_PASSWORD: app
+      MYSQL_ROOT_PASSWORD: root
+    healthcheck:
+      test: ["CMD", "mysqladmin", "ping", "-h", "localhost", "-papp", "-uapp"]
+      interval: 5s
+      timeout: 3s
+      retries: 20
+    volumes:
+      - db-data:/var/lib/mysql
+`
+  },
+  sqlite: {
+    url: 'file:/data/app.db'
+  }
+};
+
+// Multi-stage build: compile a static binary (running `templ generate`
+// first when the stack has templ views, and building static/ in a Node
+// stage when it has an asset pipeline), then run it as a non-root user
+// on a small Alpine image. /data is writable for uploads and SQLite.
+// With a React frontend (web) a Node stage builds web/dist, which the
+// binary embeds.
+// A monorepo service (dir) is built from the workspace root, with the
+// shared pkg/ module its go.mod replaces in at ../../pkg. With --jobs
+// (worker) the image also has the worker binary, run by its own service.
+function goDockerfile({ templ, port, assets, web, worker, dir }) {
+  const generate = templ
+    ? `
+# Compile the .templ views with the templ version pinned in go.mod
+RUN go run github.com/a-h/templ/cmd/templ generate
+
+`
+    : '';
+  const assetStage = assets
+    ? `FROM node:20-alpine AS assets
+WORKDIR /src
+
+COPY ${dir ? `${dir}/` : ''}package*.json ./
+RUN npm install
+
+COPY ${dir || '.'} .
+RUN npm run build
+
+`
+    : '';
+  const webStage = web
+    ? `FROM node:20-alpine AS web
+WORKDIR /src/web
+
+COPY web/package*.json ./
+RUN npm install
+
+COPY web .
+RUN npm run build
+
+`
+    : '';
+  const copyAssets = assets
+    ? `# Built CSS/JS, embedded into the binary
+COPY --from=assets /src/static ./static
+`
+    : '';
+  const copyWeb = web
+    ? `# The React build, embedded into the binary
+COPY --from=web /src/web/dist ./web/dist
+`
+    : '';
+  const sources = dir
+    ? `WORKDIR /src/${dir}
+
+COPY pkg/go.* /src/pkg/
+COPY ${dir}/go.* ./
+RUN go mod download
+
+COPY pkg /src/pkg
+COPY ${dir} .
+`
+    : `WORKDIR /src
+
+COPY go.* ./
+RUN go mod download
+
+COPY . .
+`;
+
+  return `# syntax=docker/dockerfile:1
+
+${assetStage}${webStage}FROM golang:1.22-alpine AS build
+${sources}${copyAssets}${copyWeb}${generate}RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/app .
+${worker ? 'RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/worker ./cmd/worker\n' : ''}
+FROM alpine:3.19
+RUN adduser -D -H app && mkdir /data && chown app /data
+COPY --from=build /out/app /usr/local/bin/app
+${worker ? 'COPY --from=build /out/worker /usr/local/bin/worker\n' : ''}
+USER app
+WORKDIR /data
+ENV PORT=${port}
+EXPOSE ${port}
+CMD ["app"]
+`;
+}
+
+// Redis for the options that need it (--cache redis, --jobs asynq), each
+// pointed at it by its own variable. The worker (--jobs) runs from the
+// app's image with the app's settings.
+const redisService = `  redis:
+    image: redis:7-alpine
+    healthcheck:
+      test: ["CMD", "redis-cli", "ping"]
+      interval: 5s
+      timeout: 3s
+      retries: 10
+`;
+
+// --observability otel: Jaeger (traces, over OTLP) and Prometheus
+// (scraping /metrics) in the observability profile, so a plain
+// docker compose up leaves them out. The app only exports to Jaeger when
+// OTEL_EXPORTER_OTLP_ENDPOINT is passed in, as make docker-observe does.
+const observabilityServices = `  jaeger:
+    image: jaegertracing/all-in-one:1.57
+    profiles: ["observability"]
+    environment:
+      COLLECTOR_OTLP_ENABLED: "true"
+    ports:
+      - "16686:16686"
+
+  prometheus:
+    image: prom/prometheus:v2.52.0
+    profiles: ["observability"]
+    volumes:
+      - ./prometheus.yml:/etc/prometheus/prometheus.yml:ro
+    ports:
+      - "9090:9090"
+`;
+
+function prometheusConfig({ port }) {
+  return `# Scrape config of the prometheus service (docker compose --profile observability)
+global:
+  scrape_interval: 15s
+
+scrape_configs:
+  - job_name: app
+    static_configs:
+      - targets: ["app:${port}"]
+`;
+}
+
+function goCompose({ database, port, uploads, worker, redis = [], telemetry, name }) {
+  const db = databaseServices[database];
+  const env = [];
+  if (db) env.push(`DATABASE_URL: "${db.url}"`);
+  if (uploads) env.push('UPLOAD_DIR: /data/uploads');
+  for (const name of redis) env.push(`${name}: redis://redis:6379/0`);
+
+  const depends = [];
+  if (db && db.service) depends.push('db');
+  if (redis.length > 0) depends.push('redis');
+  const dependsOn = depends.length > 0
+    ? `    depends_on:
+${depends.map(name => `      ${name}:\n        condition: service_healthy`).join('\n')}
+`
+    : '';
+
+  const volumes = [];
+  if (db && db.service) volumes.push('db-data');
+  if (uploads || database === 'sqlite') volumes.push('app-data');
+  const mounts = volumes.includes('app-data')
+    ? `    volumes:
+      - app-data:/data
+`
+    : '';
+
+  let compose = `services:
+  app:
+    build: .
+    ports:
+      - "${port}:${port}"
+    environment:
+${[`PORT: "${port}"`, ...env, ...(telemetry ? [`OTEL_SERVICE_NAME: ${name}`, 'OTEL_EXPORTER_OTLP_ENDPOINT: ${OTEL_EXPORTER_OTLP_ENDPOINT:-}'] : [])].map(line => `      ${line}`).join('\n')}
+${mounts}${dependsOn}`;
+  if (worker) {
+    // The app applies migrations (River's tables included) on startup
+    compose += `
+  worker:
+    build: .
+    command: ["worker"]
+${env.length > 0 ? `    environment:
+${[...env, ...(db ? ['RUN_MIGRATIONS: "false"'] : [])].map(line => `      ${line}`).join('\n')}
+` : ''}${mounts}    depends_on:
+      app:
+        condition: service_started
+${depends.map(name => `      ${name}:\n        condition: service_healthy`).join('\n')}${depends.length > 0 ? '\n' : ''}`;
+  }
+  const services = [db && db.service, redis.length > 0 && redisService, telemetry && observabilityServices].filter(Boolean);
+  if (services.length > 0) {
+    compose += `\n${services.join('\n')}`;
+  }
+  if (volumes.length > 0) {
+    compose += `
+volumes:
+${volumes.map(name => `  ${name}:`).join('\n')}
+`;
+  }
+  return compose;
+}
+
+const dockerignore = `.git
+.github
+.env
+.env.*
+!.env.example
+*.db
+uploads/
+bin/
+tmp/
+dist/
+node_modules/
+web/dist/
+web/node_modules/
+e2e/
+loadtest/
+Dockerfile
+docker-compose.yml
+`;
+
+// Write the Dockerfile, docker-compose.yml (with the selected database)
+// and .dockerignore for a Go stack, plus prometheus.yml for the
+// observability profile. The Makefile's (or Taskfile's) docker targets come
+// from tasks.js. A monorepo service (dir) gets only its Dockerfile;
+// generateGoWorkspaceDocker writes the rest at the workspace root.
+export async function generateGoDocker(projectPath, { database = 'memory', port, templ = false, uploads = false, assets = false, web = false, worker = false, redis = [], telemetry = false, name, dir }) {
+  await fs.writeFile(path.join(projectPath, 'Dockerfile'), goDockerfile({ templ, port, assets, web, worker, dir }));
+  if (dir) return;
+  await fs.writeFile(path.join(projectPath, 'docker-compose.yml'), goCompose({ database, port, uploads, worker, redis, telemetry, name }));
+  if (telemetry) {
+    await fs.writeFile(path.join(projectPath, 'prometheus.yml'), prometheusConfig({ port }));
+  }
+  await fs.writeFile(path.join(projectPath, '.dockerignore'), dockerignore);
+}
+
+// One compose file for every service of a monorepo, built from the
+// workspace root and sharing one database. Only the first service with a
+// store applies migrations (two running them at once would race); the
+// others wait for its healthcheck and start with RUN_MIGRATIONS=false.
+// Workers get API_URL pointing at the first REST API.
+function goWorkspaceCompose({ database, services }) {
+  const db = databaseServices[database];
+  const stores = services.filter(service => service.store);
+  const migrator = db && stores[0];
+  const api = services.find(service => service.api);
+  const shared = database === 'sqlite' || services.some(service => service.uploads);
+
+  let compose = 'services:\n';
+  for (const service of services) {
+    const env = [`PORT: "${service.port}"`];
+    const depends = [];
+    if (service.store && db) {
+      env.push(`DATABASE_URL: "${db.url}"`);
+      if (db.service) depends.push(['db', 'service_healthy']);
+      if (service !== migrator) {
+        env.push('RUN_MIGRATIONS: "false"');
+        depends.push([migrator.name, 'service_healthy']);
+      }
+    }
+    if (service.uploads) env.push(`UPLOAD_DIR: /data/uploads/${service.name}`);
+    if (service.worker && api) {
+      env.push(`API_URL: "http://${api.name}:${api.port}"`);
+      depends.push([api.name, 'service_started']);
+    }
+
+    compose += `  ${service.name}:
+    build:
+      context: .
+      dockerfile: ${service.dir}/Dockerfile
+    ports:
+      - "${service.port}:${service.port}"
+    environment:
+${env.map(line => `      ${line}`).join('\n')}
+`;
+    if (service === migrator) {
+      compose += `    healthcheck:
+      test: ["CMD", "wget", "-qO-", "http://localhost:${service.port}/readyz"]
+      interval: 5s
+      timeout: 3s
+      retries: 10
+`;
+    }
+    if (service.uploads || (service.store && database === 'sqlite')) {
+      compose += `    volumes:
+      - app-data:/data
+`;
+    }
+    if (depends.length > 0) {
+      compose += `    depends_on:
+${depends.map(([name, condition]) => `      ${name}:\n        condition: ${condition}`).join('\n')}
+`;
+    }
+    compose += '\n';
+  }
+
+  const volumes = [];
+  if (db && db.service) {
+    compose += db.service;
+    volumes.push('db-data');
+  } else {
+    compose = compose.replace(/\n$/, '');
+  }
+  if (shared) volumes.push('app-data');
+  if (volumes.length > 0) {
+    compose += `
+volumes:
+${volumes.map(name => `  ${name}:`).join('\n')}
+`;
+  }
+  return compose;
+}
+
+// The monorepo builds from its root, so the ignore patterns apply in
+// every service directory
+const workspaceDockerignore = dockerignore.trim().split('\n')
+  .filter(line => !['Dockerfile', 'docker-compose.yml'].includes(line))
+  .map(line => (line.startsWith('!') ? `!**/${line.slice(1)}` : `**/${line}`))
+  .join('\n') + '\n';
+
+// Write the shared docker-compose.yml and .dockerignore of a monorepo.
+// services are { name, dir, port, store, uploads, api, worker }; each
+// has its own Dockerfile from generateGoDocker.
+export async function generateGoWorkspaceDocker(projectPath, { database = 'memory', services }) {
+  await fs.writeFile(path.join(projectPath, 'docker-compose.yml'), goWorkspaceCompose({ database, services }));
+  await fs.writeFile(path.join(projectPath, '.dockerignore'), workspaceDockerignore);
+}
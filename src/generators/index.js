@@ -1,11 +1,17 @@
 import fs from 'fs-extra';
 import path from 'node:path';
 import { fileURLToPath } from 'node:url';
+import { generateGoHTMX, generateGoREST, generateGoGRPC, generateFullstackReactGo } from './go.js';
+import { generateGoMonorepo } from './monorepo.js';
+import { generateNodeHTMX } from './node.js';
+import { generatePythonFastAPI } from './python.js';
+import { generateRemote } from './remote.js';
+import { generatePluginTemplate } from '../utils/plugins.js';
 
 const __filename = fileURLToPath(import.meta.url);
 const __dirname = path.dirname(__filename);
 
-export async function generateProject(projectPath, templateId, templateConfig, features) {
+export async function generateProject(projectPath, templateId, templateConfig, features, options = {}) {
   try {
     console.log(`\n📝 Generating ${templateConfig.name} (${templateId})...`);
     
@@ -19,7 +25,8 @@ export async function generateProject(projectPath, templateId, templateConfig, f
       await generateDockerFiles(projectPath, templateConfig.language);
     }
     
-    if (features.includes('ci')) {
+    // Stacks with a --ci option generate their own pipeline
+    if (features.includes('ci') && !(templateConfig.options && templateConfig.options.ci)) {
       console.log('  → Creating GitHub Actions CI/CD...');
       await generateGitHubActions(projectPath, templateConfig.language);
     }
@@ -41,9 +48,15 @@ export async function generateProject(projectPath, templateId, templateConfig, f
       case 'node-express-api':
         await generateNodeExpressAPI(projectPath, features);
         break;
+      case 'node-htmx':
+        await generateNodeHTMX(projectPath, features, options);
+        break;
       case 'fastapi-modern':
         await generateFastAPI(projectPath, features);
         break;
+      case 'python-fastapi':
+        await generatePythonFastAPI(projectPath, features, options);
+        break;
       case 'rust-axum':
         await generateRustAxum(projectPath, features);
         break;
@@ -54,7 +67,19 @@ export async function generateProject(projectPath, templateId, templateConfig, f
         await generateGoFiber(projectPath, features);
         break;
       case 'go-htmx':
-        await generateGoHTMX(projectPath, features);
+        await generateGoHTMX(projectPath, features, options);
+        break;
+      case 'go-rest':
+        await generateGoREST(projectPath, features, options);
+        break;
+      case 'go-grpc':
+        await generateGoGRPC(projectPath, features, options);
+        break;
+      case 'fullstack-react-go':
+        await generateFullstackReactGo(projectPath, features, options);
+        break;
+      case 'go-monorepo':
+        await generateGoMonorepo(projectPath, features, options);
         break;
       case 'ai-saas-nextjs':
         await generateAISaaS(projectPath, features);
@@ -79,6 +104,16 @@ export async function generateProject(projectPath, templateId, templateConfig, f
         break;
       // Add more template generators as needed
       default:
+        // Remote templates (registered or git-url@version) bring their own files
+        if (templateConfig.source) {
+          await generateRemote(projectPath, templateConfig.source, options);
+          break;
+        }
+        // Plugin templates (acme:api) are written by their plugin
+        if (templateConfig.plugin) {
+          await generatePluginTemplate(projectPath, templateConfig.plugin, features, options);
+          break;
+        }
         console.log(`  ⚠️  No specific generator for ${templateId}, using basic structure...`);
         await generateBasicStructure(projectPath, templateConfig);
     }
@@ -2232,7 +2267,8 @@ API_URL=http://localhost:3001
 }
 
 async function generateGoFiber(projectPath, features) {
-  const goMod = `module ${path.basename(projectPath)}
+  // Imports use the myapp/ prefix, so the module path must match
+  const goMod = `module myapp
 
 go 1.21
 
@@ -2249,6 +2285,7 @@ require (
   await fs.ensureDir(path.join(projectPath, 'models'));
   await fs.ensureDir(path.join(projectPath, 'middleware'));
   await fs.ensureDir(path.join(projectPath, 'config'));
+  await fs.ensureDir(path.join(projectPath, 'store'));
 
   // Main application
   const mainGo = `package main
@@ -2335,23 +2372,179 @@ type ErrorResponse struct {
   await fs.ensureDir(path.join(projectPath, 'models'));
   await fs.writeFile(path.join(projectPath, 'models', 'models.go'), modelsGo);
 
+  // Handlers
+  // Item storage: one store shared by every request, so it locks its
+  // slice and hands out UUIDs instead of a shared counter
+  const storeGo = `package store
+
+import (
+    "errors"
+    "sync"
+    "github.com/google/uuid"
+    "myapp/models"
+)
+
+var ErrNotFound = errors.New("item not found")
+
+// MemoryStore keeps items in process memory (replace with database in
+// production). It is safe for concurrent use.
+type MemoryStore struct {
+    mu    sync.RWMutex
+    items []models.Item
+}
+
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{}
+}
+
+// List returns a copy, so callers can't race with later writes.
+func (s *MemoryStore) List() []models.Item {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    items := make([]models.Item, len(s.items))
+    copy(items, s.items)
+    return items
+}
+
+func (s *MemoryStore) Get(id string) (models.Item, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, item := range s.items {
+        if item.ID == id {
+            return item, nil
+        }
+    }
+    return models.Item{}, ErrNotFound
+}
+
+// Create stores item under a new random ID.
+func (s *MemoryStore) Create(item models.Item) models.Item {
+    item.ID = uuid.NewString()
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.items = append(s.items, item)
+    return item
+}
+
+func (s *MemoryStore) Update(item models.Item) (models.Item, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for i := range s.items {
+        if s.items[i].ID == item.ID {
+            s.items[i] = item
+            return item, nil
+        }
+    }
+    return models.Item{}, ErrNotFound
+}
+
+func (s *MemoryStore) Delete(id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for i, item := range s.items {
+        if item.ID == id {
+            s.items = append(s.items[:i], s.items[i+1:]...)
+            return nil
+        }
+    }
+    return ErrNotFound
+}`;
+
+  await fs.writeFile(path.join(projectPath, 'store', 'memory.go'), storeGo);
+
+  if (features.includes('testing')) {
+    const storeTestGo = `package store
+
+import (
+    "fmt"
+    "sync"
+    "testing"
+    "myapp/models"
+)
+
+// Fiber serves requests concurrently. Run with go test -race to catch
+// unsynchronized access; on its own the test checks no write is lost and
+// every ID is unique.
+func TestMemoryStoreConcurrentUse(t *testing.T) {
+    s := NewMemoryStore()
+
+    const workers, perWorker = 8, 25
+    ids := make(chan string, workers*perWorker)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := 0; i < perWorker; i++ {
+                item := s.Create(models.Item{Title: fmt.Sprintf("item %d-%d", w, i)})
+                ids <- item.ID
+                item.Description = "updated"
+                if _, err := s.Update(item); err != nil {
+                    t.Errorf("Update: %v", err)
+                }
+                s.List()
+            }
+        }(w)
+    }
+    wg.Wait()
+    close(ids)
+
+    seen := map[string]bool{}
+    for id := range ids {
+        if seen[id] {
+            t.Errorf("ID %s was handed out twice", id)
+        }
+        seen[id] = true
+    }
+    items := s.List()
+    if len(items) != workers*perWorker {
+        t.Fatalf("List has %d items, want %d", len(items), workers*perWorker)
+    }
+    for _, item := range items {
+        if item.Description != "updated" {
+            t.Errorf("item %s lost its update: %+v", item.ID, item)
+        }
+    }
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+    s := NewMemoryStore()
+    item := s.Create(models.Item{Title: "First"})
+
+    if err := s.Delete(item.ID); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    if _, err := s.Get(item.ID); err != ErrNotFound {
+        t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+    }
+    if err := s.Delete(item.ID); err != ErrNotFound {
+        t.Errorf("second Delete = %v, want ErrNotFound", err)
+    }
+}`;
+
+    await fs.writeFile(path.join(projectPath, 'store', 'memory_test.go'), storeTestGo);
+  }
+
   // Handlers
   const handlersGo = `package handlers
 
 import (
-    "log"
     "github.com/gofiber/fiber/v2"
-    "github.com/google/uuid"
     "myapp/models"
+    "myapp/store"
 )
 
-// In-memory storage (replace with database in production)
-var items []models.Item
+// Item persistence, shared by all requests
+var items = store.NewMemoryStore()
 
 func init() {
-    items = []models.Item{
-        {ID: "1", Title: "Sample Item", Description: "A sample item"},
-    }
+    items.Create(models.Item{Title: "Sample Item", Description: "A sample item"})
 }
 
 func HealthCheck(c *fiber.Ctx) error {
@@ -2370,24 +2563,21 @@ func InfoHandler(c *fiber.Ctx) error {
 }
 
 func GetItems(c *fiber.Ctx) error {
+    all := items.List()
     return c.JSON(fiber.Map{
-        "items": items,
-        "count": len(items),
+        "items": all,
+        "count": len(all),
     })
 }
 
 func GetItem(c *fiber.Ctx) error {
-    id := c.Params("id")
-    
-    for _, item := range items {
-        if item.ID == id {
-            return c.JSON(item)
-        }
+    item, err := items.Get(c.Params("id"))
+    if err != nil {
+        return c.Status(404).JSON(fiber.Map{
+            "error": "Item not found",
+        })
     }
-    
-    return c.Status(404).JSON(fiber.Map{
-        "error": "Item not found",
-    })
+    return c.JSON(item)
 }
 
 func CreateItem(c *fiber.Ctx) error {
@@ -2399,19 +2589,15 @@ func CreateItem(c *fiber.Ctx) error {
         })
     }
     
-    item := models.Item{
-        ID: uuid.New().String(),
+    item := items.Create(models.Item{
         Title: req.Title,
         Description: req.Description,
-    }
-    
-    items = append(items, item)
+    })
     
     return c.Status(201).JSON(item)
 }
 
 func UpdateItem(c *fiber.Ctx) error {
-    id := c.Params("id")
     req := new(models.CreateItemRequest)
     
     if err := c.BodyParser(req); err != nil {
@@ -2420,32 +2606,26 @@ func UpdateItem(c *fiber.Ctx) error {
         })
     }
     
-    for i, item := range items {
-        if item.ID == id {
-            items[i].Title = req.Title
-            items[i].Description = req.Description
-            return c.JSON(items[i])
-        }
-    }
-    
-    return c.Status(404).JSON(fiber.Map{
-        "error": "Item not found",
+    item, err := items.Update(models.Item{
+        ID: c.Params("id"),
+        Title: req.Title,
+        Description: req.Description,
     })
+    if err != nil {
+        return c.Status(404).JSON(fiber.Map{
+            "error": "Item not found",
+        })
+    }
+    return c.JSON(item)
 }
 
 func DeleteItem(c *fiber.Ctx) error {
-    id := c.Params("id")
-    
-    for i, item := range items {
-        if item.ID == id {
-            items = append(items[:i], items[i+1:]...)
-            return c.SendStatus(204)
-        }
+    if err := items.Delete(c.Params("id")); err != nil {
+        return c.Status(404).JSON(fiber.Map{
+            "error": "Item not found",
+        })
     }
-    
-    return c.Status(404).JSON(fiber.Map{
-        "error": "Item not found",
-    })
+    return c.SendStatus(204)
 }`;
 
   await fs.writeFile(path.join(projectPath, 'handlers', 'handlers.go'), handlersGo);
@@ -2588,6 +2768,14 @@ go build -o app main.go
 ./app
 \`\`\`
 
+### Tests
+
+\`\`\`bash
+go test -race ./...
+\`\`\`
+
+The race detector needs cgo. Fiber serves requests concurrently, so shared state such as the item store must be safe for concurrent use.
+
 ## API Endpoints
 
 ### Health
@@ -2609,6 +2797,7 @@ go build -o app main.go
 ├── go.mod            # Go module file
 ├── handlers/         # HTTP handlers
 ├── models/           # Data models
+├── store/            # Concurrency-safe in-memory item store
 ├── middleware/       # Custom middleware
 ├── config/           # Configuration
 ├── .env.example      # Example environment variables
@@ -2699,444 +2888,6 @@ services:
   await fs.writeFile(path.join(projectPath, 'docker-compose.yml'), dockerCompose);
 }
 
-async function generateGoHTMX(projectPath, features) {
-  const goMod = `module ${path.basename(projectPath)}
-
-go 1.21
-
-require (
-    github.com/a-h/templ v0.2.543
-    github.com/go-chi/chi/v5 v5.0.11
-    github.com/joho/godotenv v1.5.1
-)`;
-
-  await fs.writeFile(path.join(projectPath, 'go.mod'), goMod);
-
-  // Create directory structure
-  await fs.ensureDir(path.join(projectPath, 'handlers'));
-  await fs.ensureDir(path.join(projectPath, 'models'));
-  await fs.ensureDir(path.join(projectPath, 'middleware'));
-  await fs.ensureDir(path.join(projectPath, 'views'));
-  await fs.ensureDir(path.join(projectPath, 'static'));
-
-  // Main application
-  const mainGo = `package main
-
-import (
-    "log"
-    "net/http"
-    "os"
-    "github.com/go-chi/chi/v5"
-    "github.com/go-chi/chi/v5/middleware"
-    "github.com/joho/godotenv"
-    "myapp/handlers"
-)
-
-const (
-    itemDetailRoute = "/items/{id}"
-    itemEditRoute   = "/items/{id}/edit"
-)
-
-func main() {
-    // Load environment variables
-    godotenv.Load()
-
-    // Create Chi router
-    r := chi.NewRouter()
-
-    // Global middleware
-    r.Use(middleware.Logger)
-    r.Use(middleware.Recoverer)
-    r.Use(middleware.SetHeader("Content-Type", "text/html"))
-
-    // Static files
-    r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
-
-    // Health check
-    r.Get("/health", handlers.HealthCheck)
-
-    // HTMX routes
-    r.Get("/", handlers.HomePage)
-    r.Get("/items", handlers.ListItems)
-    r.Post("/items", handlers.CreateItem)
-    r.Get(itemDetailRoute, handlers.GetItem)
-    r.Put(itemDetailRoute, handlers.UpdateItem)
-    r.Delete(itemDetailRoute, handlers.DeleteItem)
-    r.Get(itemEditRoute, handlers.EditItemForm)
-
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "3000"
-    }
-
-    log.Println("🚀 Server running on http://localhost:" + port)
-    if err := http.ListenAndServe(":"+port, r); err != nil {
-        log.Panic(err)
-    }
-}`;
-
-  await fs.writeFile(path.join(projectPath, 'main.go'), mainGo);
-
-  // Models
-  const modelsGo = `package models
-
-type Item struct {
-    ID          string
-    Title       string
-    Description string
-}
-
-type ItemRequest struct {
-    Title       string
-    Description string
-}`;
-
-  await fs.writeFile(path.join(projectPath, 'models', 'models.go'), modelsGo);
-
-  // Handlers
-  const handlersGo = `package handlers
-
-import (
-    "fmt"
-    "net/http"
-    "github.com/go-chi/chi/v5"
-    "myapp/models"
-    "myapp/views"
-)
-
-// In-memory storage (replace with database in production)
-var items []models.Item
-var nextID int
-
-func init() {
-    nextID = 1
-    items = []models.Item{
-        {ID: "1", Title: "Sample Item", Description: "A sample item"},
-    }
-    nextID = 2
-}
-
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
-    w.Header().Set("Content-Type", "application/json")
-    fmt.Fprintf(w, \`{"status":"healthy","service":"Go HTMX App"}\`)
-}
-
-func HomePage(w http.ResponseWriter, r *http.Request) {
-    component := views.Home()
-    component.Render(r.Context(), w)
-}
-
-func ListItems(w http.ResponseWriter, r *http.Request) {
-    component := views.ItemList(items)
-    component.Render(r.Context(), w)
-}
-
-func GetItem(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    
-    for _, item := range items {
-        if item.ID == id {
-            component := views.ItemDetail(item)
-            component.Render(r.Context(), w)
-            return
-        }
-    }
-    
-    w.WriteHeader(http.StatusNotFound)
-    fmt.Fprintf(w, "<p>Item not found</p>")
-}
-
-func CreateItem(w http.ResponseWriter, r *http.Request) {
-    r.ParseForm()
-    title := r.FormValue("title")
-    description := r.FormValue("description")
-    
-    item := models.Item{
-        ID: fmt.Sprintf("%d", nextID),
-        Title: title,
-        Description: description,
-    }
-    nextID++
-    
-    items = append(items, item)
-    
-    w.Header().Set("HX-Redirect", "/items")
-    w.WriteHeader(http.StatusCreated)
-}
-
-func EditItemForm(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    
-    for _, item := range items {
-        if item.ID == id {
-            component := views.EditItemForm(item)
-            component.Render(r.Context(), w)
-            return
-        }
-    }
-    
-    w.WriteHeader(http.StatusNotFound)
-}
-
-func UpdateItem(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    r.ParseForm()
-    title := r.FormValue("title")
-    description := r.FormValue("description")
-    
-    for i, item := range items {
-        if item.ID == id {
-            items[i].Title = title
-            items[i].Description = description
-            component := views.ItemDetail(items[i])
-            component.Render(r.Context(), w)
-            return
-        }
-    }
-    
-    w.WriteHeader(http.StatusNotFound)
-}
-
-func DeleteItem(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-    
-    for i, item := range items {
-        if item.ID == id {
-            items = append(items[:i], items[i+1:]...)
-            w.WriteHeader(http.StatusOK)
-            return
-        }
-    }
-    
-    w.WriteHeader(http.StatusNotFound)
-}`;
-
-  await fs.writeFile(path.join(projectPath, 'handlers', 'handlers.go'), handlersGo);
-
-  // Views (Templ templates)
-  const viewsTempl = `package views
-
-templ Home() {
-    <!DOCTYPE html>
-    <html>
-    <head>
-        <title>Go HTMX App</title>
-        <script src="https://unpkg.com/htmx.org"></script>
-        <style>
-            body { font-family: sans-serif; margin: 2em; }
-            .container { max-width: 700px; margin: 0 auto; }
-            form { margin: 1em 0; padding: 1em; border: 1px solid #ddd; border-radius: 4px; }
-            input, textarea { display: block; width: 100%; margin: 0.5em 0; padding: 0.5em; }
-            button { padding: 0.5em 1em; background: #007bff; color: white; border: none; border-radius: 4px; cursor: pointer; }
-            button:hover { background: #0056b3; }
-            .item { padding: 1em; margin: 0.5em 0; border: 1px solid #e0e0e0; border-radius: 4px; }
-            .item-actions { margin-top: 0.5em; }
-            .item-actions button { margin-right: 0.5em; padding: 0.25em 0.5em; font-size: 0.9em; }
-        </style>
-    </head>
-    <body>
-        <div class="container">
-            <h1>📝 Go HTMX App</h1>
-            
-            <div>
-                <h2>Add New Item</h2>
-                <form hx-post="/items" hx-target="#items">
-                    <input type="text" name="title" placeholder="Title" required />
-                    <textarea name="description" placeholder="Description"></textarea>
-                    <button type="submit">Add Item</button>
-                </form>
-            </div>
-            
-            <div>
-                <h2>Items</h2>
-                <div id="items" hx-get="/items" hx-trigger="load">
-                    <p>Loading...</p>
-                </div>
-            </div>
-        </div>
-    </body>
-    </html>
-}
-
-templ ItemList(items []Item) {
-    { for i, item := range items { }
-        <div class="item" id={ "item-" + item.ID }>
-            <h3>{ item.Title }</h3>
-            <p>{ item.Description }</p>
-            <div class="item-actions">
-                <button hx-get={ "/items/" + item.ID + "/edit" } hx-target={ "#item-" + item.ID } hx-swap="outerHTML">Edit</button>
-                <button hx-delete={ "/items/" + item.ID } hx-confirm="Are you sure?" hx-target={ "#item-" + item.ID } hx-swap="outerHTML swap:1s">Delete</button>
-            </div>
-        </div>
-    { } }
-}
-
-templ ItemDetail(item Item) {
-    <div class="item" id={ "item-" + item.ID }>
-        <h3>{ item.Title }</h3>
-        <p>{ item.Description }</p>
-        <div class="item-actions">
-            <button hx-get={ "/items/" + item.ID + "/edit" } hx-target={ "#item-" + item.ID } hx-swap="outerHTML">Edit</button>
-            <button hx-delete={ "/items/" + item.ID } hx-confirm="Are you sure?" hx-target={ "#item-" + item.ID } hx-swap="outerHTML swap:1s">Delete</button>
-        </div>
-    </div>
-}
-
-templ EditItemForm(item Item) {
-    <form hx-put={ "/items/" + item.ID } hx-target={ "#item-" + item.ID } hx-swap="outerHTML" id={ "item-" + item.ID }>
-        <input type="text" name="title" value={ item.Title } required />
-        <textarea name="description">{ item.Description }</textarea>
-        <button type="submit">Update Item</button>
-        <button type="button" hx-get={ "/items/" + item.ID } hx-target={ "#item-" + item.ID } hx-swap="outerHTML">Cancel</button>
-    </form>
-}`;
-
-  await fs.writeFile(path.join(projectPath, 'views', 'views.templ'), viewsTempl);
-
-  // Generate HTML/CSS for Tailwind
-  const tailwindCss = `@tailwind base;
-@tailwind components;
-@tailwind utilities;
-
-[hx-request] {
-    opacity: 0.6;
-}`;
-
-  await fs.writeFile(path.join(projectPath, 'static', 'style.css'), tailwindCss);
-
-  // .env.example
-  const envExample = `PORT=3000
-NODE_ENV=development`;
-
-  await fs.writeFile(path.join(projectPath, '.env.example'), envExample);
-  await fs.writeFile(path.join(projectPath, '.env'), envExample);
-
-  // README
-  const readmeMd = `# ${path.basename(projectPath)}
-
-Go + HTMX Server-Side Rendering Application
-
-## Features
-
-- **Chi Router** - Lightweight HTTP router
-- **HTMX** - Interactive server-rendered components
-- **Templ** - Type-safe HTML templating
-- **PostgreSQL** ready - Database integration
-- **Tailwind CSS** - Utility-first CSS
-
-## Getting Started
-
-### Prerequisites
-
-- Go 1.21+
-- Templ \`go install github.com/a-h/templ/cmd/templ@latest\`
-
-### Installation
-
-\`\`\`bash
-cd ${path.basename(projectPath)}
-go mod download
-templ generate
-\`\`\`
-
-### Running
-
-\`\`\`bash
-go run main.go
-\`\`\`
-
-Visit http://localhost:3000
-
-## API Routes
-
-- \`GET /\` - Home page
-- \`GET /items\` - List all items
-- \`POST /items\` - Create item
-- \`GET /items/:id\` - Get item detail
-- \`PUT /items/:id\` - Update item
-- \`DELETE /items/:id\` - Delete item
-- \`GET /items/:id/edit\` - Edit form
-
-## Project Structure
-
-\`\`\`
-.
-├── main.go          # Entry point
-├── go.mod           # Dependencies
-├── handlers/        # HTTP handlers
-├── models/          # Data models
-├── views/           # Templ templates
-├── static/          # CSS/JS assets
-└── README.md
-\`\`\`
-
-## License
-
-MIT
-`;
-
-  await fs.writeFile(path.join(projectPath, 'README.md'), readmeMd);
-
-  // .gitignore
-  const gitignore = `# Binaries
-*.exe
-*.exe~
-*.dll
-*.so
-*.dylib
-bin/
-dist/
-
-# Go
-*.go.bak
-*.mod.bak
-/vendor/
-
-# IDE
-.vscode/
-.idea/
-*.swp
-*.swo
-*~
-.DS_Store
-
-# Env
-.env
-.env.local`;
-
-  await fs.writeFile(path.join(projectPath, '.gitignore'), gitignore);
-
-  // Dockerfile
-  const dockerfile = `FROM golang:1.21-alpine AS builder
-
-WORKDIR /app
-COPY go.mod go.sum ./
-RUN go mod download
-
-COPY . .
-RUN CGO_ENABLED=0 GOOS=linux go build -o app main.go
-
-FROM alpine:latest
-WORKDIR /root/
-COPY --from=builder /app/app .
-EXPOSE 3000
-CMD ["./app"]`;
-
-  await fs.writeFile(path.join(projectPath, 'Dockerfile'), dockerfile);
-
-  // Docker Compose
-  const dockerCompose = `version: '3.8'
-services:
-  app:
-    build: .
-    ports:
-      - "3000:3000"
-    environment:
-      - PORT=3000`;
-
-  await fs.writeFile(path.join(projectPath, 'docker-compose.yml'), dockerCompose);
-}
-
 async function generateAISaaS(projectPath, features) {
   // Create Next.js specific structure
   await fs.ensureDir(path.join(projectPath, 'src', 'app', 'api', 'chat'));
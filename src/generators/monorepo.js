@@ -0,0 +1,231 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import yaml from 'js-yaml';
+import { fileURLToPath } from 'node:url';
+import { generateGoHTMX, generateGoREST, generateGoGRPC, generateGoWorker } from './go.js';
+import { generateGoWorkspaceDocker } from './docker.js';
+import { generateGoWorkspaceTasks, taskRunner } from './tasks.js';
+import { generateGoCI, ciProvider } from './ci.js';
+import { projectVariables, walk } from '../templating/index.js';
+import { hooksFile, takeHooks } from '../utils/hooks.js';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+// Stacks a monorepo service can be generated from. store marks the ones
+// built on the items store, which share pkg/models and the database;
+// port is the stack's default, bumped past ports earlier services took.
+const stacks = {
+  'go-rest': { generate: generateGoREST, title: 'JSON REST API (Chi, OpenAPI)', port: 8080, store: true, api: true },
+  'go-htmx': { generate: generateGoHTMX, title: 'Server-rendered web app (HTMX, templ)', port: 3000, store: true, uploads: true },
+  'go-grpc': { generate: generateGoGRPC, title: 'gRPC, Connect and REST service (connect-go)', port: 8080, store: true },
+  'go-worker': { generate: generateGoWorker, title: 'Background worker (periodic tasks)', port: 8081, worker: true }
+};
+
+// --services entries: a role below, generated from its stack, or
+// name:stack for any other name (billing:go-rest)
+const roles = { api: 'go-rest', web: 'go-htmx', grpc: 'go-grpc', worker: 'go-worker' };
+
+export const defaultServices = 'api,web';
+
+// Parse a comma-separated --services list into { name, stack } pairs
+export function parseServices(list = defaultServices) {
+  const services = [];
+  for (const entry of String(list).split(',').map(s => s.trim()).filter(Boolean)) {
+    const [name, stack = roles[name]] = entry.split(':').map(s => s.trim());
+    if (!/^[a-z][a-z0-9-]*$/.test(name)) {
+      throw new Error(`Invalid service name "${name}" (lowercase letters, digits and dashes)`);
+    }
+    if (!stack) {
+      throw new Error(`Unknown service "${name}" (expected ${Object.keys(roles).join(', ')}, or name:stack)`);
+    }
+    if (!stacks[stack]) {
+      throw new Error(`Unknown stack "${stack}" for service "${name}" (expected ${Object.keys(stacks).join(', ')})`);
+    }
+    if (services.some(service => service.name === name)) {
+      throw new Error(`Service "${name}" is listed twice`);
+    }
+    services.push({ name, stack });
+  }
+  if (services.length === 0) {
+    throw new Error('--services needs at least one service');
+  }
+  return services;
+}
+
+// Every store-backed sample ships the same models package; the monorepo
+// keeps one copy of it in pkg/
+const sharedModels = path.join(__dirname, '../../generated-samples/go/go-rest-sample/models');
+
+// Generate a Go workspace: each service is a full project from its stack
+// under services/<name> (module <module>/services/<name>), sharing the
+// pkg/ module through go.work and a replace directive, with one compose
+// file, root Makefile, CI pipeline, LICENSE and hooks.yaml for the whole
+// repository.
+export async function generateGoMonorepo(projectPath, features, options = {}) {
+  const vars = await projectVariables(projectPath, options);
+  const module = vars.ModulePath;
+  const serviceOptions = { ...options, author: vars.Author, year: vars.Year };
+
+  const services = [];
+  const hooks = [];
+  const ports = new Set();
+  for (const { name, stack } of parseServices(options.services)) {
+    const { generate, ...traits } = stacks[stack];
+    const dir = `services/${name}`;
+    const servicePath = path.join(projectPath, dir);
+    let port = traits.port;
+    while (ports.has(port)) port++;
+    ports.add(port);
+
+    const ci = await generate(servicePath, features, { ...serviceOptions, module: `${module}/${dir}`, port, service: dir });
+    if (traits.store) {
+      await useSharedModels(servicePath, `${module}/${dir}`, module);
+    }
+    await fs.move(path.join(servicePath, 'LICENSE'), path.join(projectPath, 'LICENSE'), { overwrite: true });
+
+    // Service hooks run from the root; git is initialized once, last
+    for (const hook of await takeHooks(servicePath)) {
+      if (!/\bgit init\b/.test(hook.run)) {
+        hooks.push({ name: `${hook.name} (${name})`, run: `cd ${dir} && ${hook.run}`, ...(hook.optional && { optional: true }) });
+      }
+    }
+    services.push({ name, stack, dir, ...traits, port, ci });
+  }
+
+  // Workers poll the first REST API
+  const api = services.find(service => service.api);
+  for (const worker of services.filter(service => service.worker)) {
+    const envFile = path.join(projectPath, worker.dir, '.env.example');
+    const env = await fs.readFile(envFile, 'utf8');
+    await fs.writeFile(envFile, env.replace(/^API_URL=.*$/m, `API_URL=${api ? `http://localhost:${api.port}` : ''}`));
+  }
+
+  await fs.copy(sharedModels, path.join(projectPath, 'pkg', 'models'));
+  await fs.writeFile(path.join(projectPath, 'pkg', 'go.mod'), `module ${module}/pkg\n\ngo 1.21\n`);
+  await fs.writeFile(path.join(projectPath, 'go.work'), goWork(services));
+
+  hooks.push({ name: 'Initialize git repository', run: 'git rev-parse --is-inside-work-tree || git init --quiet', optional: true });
+  await fs.writeFile(path.join(projectPath, hooksFile), yaml.dump({ hooks }, { lineWidth: -1 }));
+
+  const docker = features.includes('docker');
+  if (docker) {
+    // Services build their own images; there is no root Dockerfile
+    await fs.remove(path.join(projectPath, 'Dockerfile'));
+    await generateGoWorkspaceDocker(projectPath, { database: options.database || 'memory', services });
+  }
+  await generateGoWorkspaceTasks(projectPath, {
+    runner: options.taskRunner,
+    services: services.map(service => service.name),
+    docker
+  });
+  // One pipeline checks the whole workspace, with an image per service
+  await generateGoCI(projectPath, ciProvider(features, options), {
+    units: services.map(service => service.ci),
+    packages: ['./pkg/...', ...services.map(service => `./${service.dir}/...`)].join(' '),
+    database: features.includes('testing') && options.database,
+    docker,
+    monorepo: true,
+    options
+  });
+  await fs.writeFile(path.join(projectPath, 'README.md'), readme(vars, services, { docker, database: options.database || 'memory', runner: taskRunner(options.taskRunner) }));
+}
+
+// Point a service at pkg/models instead of its own copy. The replace
+// directive lets the service build outside the workspace (GOWORK=off, as
+// in its Dockerfile).
+async function useSharedModels(servicePath, serviceModule, module) {
+  await fs.remove(path.join(servicePath, 'models'));
+  for (const file of await walk(servicePath)) {
+    if (!file.endsWith('.go') && !file.endsWith('.templ')) continue;
+    const text = await fs.readFile(file, 'utf8');
+    const next = text.replaceAll(`"${serviceModule}/models"`, `"${module}/pkg/models"`);
+    if (next !== text) {
+      await fs.writeFile(file, next);
+    }
+  }
+
+  const goModPath = path.join(servicePath, 'go.mod');
+  const goMod = (await fs.readFile(goModPath, 'utf8'))
+    .replace(/require \(\n/, match => `${match}    ${module}/pkg v0.0.0\n`);
+  await fs.writeFile(goModPath, `${goMod}\nreplace ${module}/pkg => ../../pkg\n`);
+}
+
+function goWork(services) {
+  const dirs = ['./pkg', ...services.map(service => `./${service.dir}`)];
+  return `go 1.21
+
+use (
+${dirs.map(dir => `    ${dir}`).join('\n')}
+)
+`;
+}
+
+function readme(vars, services, { docker, database, runner }) {
+  const run = runner.command;
+  const rows = services.map(service => `| \`${service.name}\` | ${service.title} | ${service.port} | [\`${service.dir}\`](${service.dir}/README.md) |`);
+  const migrator = database !== 'memory' && services.find(service => service.store);
+  const api = services.find(service => service.api);
+
+  const dockerSection = docker
+    ? `
+## Docker
+
+\`docker-compose.yml\` runs every service, each built by its own \`Dockerfile\` from the repository root (so the image can include \`pkg/\`)${database === 'memory' ? '' : `, together with the ${database === 'sqlite' ? 'SQLite file on a shared volume' : `${database} database`} they share`}:
+
+\`\`\`bash
+${run} docker-up     # build and start everything in the background
+${run} docker-logs   # follow the logs of every service
+${run} docker-down   # stop everything
+\`\`\`
+${migrator ? `
+Only \`${migrator.name}\` applies the database migrations on startup; the other services wait for its healthcheck and run with \`RUN_MIGRATIONS=false\`, so two services never migrate at once.
+` : ''}${api && services.some(service => service.worker) ? `
+Workers reach the API by its service name: \`API_URL\` is \`http://${api.name}:${api.port}\`.
+` : ''}`
+    : '';
+
+  return `# ${vars.AppName}
+
+A Go monorepo: ${services.length} service${services.length === 1 ? '' : 's'} in one [Go workspace](https://go.dev/doc/tutorial/workspaces) (\`go.work\`), sharing the \`pkg/\` module.
+
+| Service | Stack | Port | Directory |
+|---------|-------|------|-----------|
+${rows.join('\n')}
+
+## Getting Started
+
+\`\`\`bash
+cd ${vars.AppName}
+${run} dev
+\`\`\`
+
+The create-stack-app hooks already tidied each service's modules (and ran \`git init\`) unless the project was generated with \`--skip-hooks\`. \`${run} dev\` runs every service's own \`${run} dev\` side by side, under live reload, and Ctrl-C stops them all.
+
+Each service under \`services/\` is a complete project with its own README, \`.env.example\` and \`${runner.file}\`; run one on its own with \`${run === 'make' ? 'make -C' : 'task -d'} services/<name> dev\`${run === 'task' ? ', or from the root as \`task <name>:dev\`' : ''}. From the root, \`${run} build\`, \`${run} test\`, \`${run} cover\` and \`${run} lint\` run those targets in every service.
+
+## Shared Code
+
+\`pkg/\` is its own module, \`${vars.ModulePath}/pkg\`, for code more than one service needs. The services start out sharing the item model, \`${vars.ModulePath}/pkg/models\`${database === 'memory' ? '; with the default in-memory store each keeps its own items, so generate with \`--database\` to share them' : ''}.
+
+\`go.work\` lists every module, so \`go build\`, \`go test\` and gopls resolve \`pkg/\` from the working tree, and a change there is picked up by every service at once. Each service's \`go.mod\` also has \`replace ${vars.ModulePath}/pkg => ../../pkg\`, so it builds on its own with \`GOWORK=off\` too, as its Docker image does.
+
+Add a service by generating it elsewhere and moving it under \`services/\`, then \`go work use ./services/<name>\` and ${run === 'make' ? 'add its name to \`SERVICES\` in the \`Makefile\`' : 'add it to the \`includes\`, \`dev\` and per-service tasks of \`Taskfile.yml\`'}.
+${dockerSection}
+## Project Structure
+
+\`\`\`
+.
+├── go.work              # Go workspace: pkg/ and every service
+├── ${runner.file.padEnd(21)}# build, test, lint and dev across services
+${docker ? '├── docker-compose.yml   # Every service (and the database)\n' : ''}├── pkg/                 # Shared module (${vars.ModulePath}/pkg)
+│   └── models/
+└── services/
+${services.map((service, i) => `    ${i === services.length - 1 ? '└' : '├'}── ${service.name}/`.padEnd(25) + `# ${service.title}`).join('\n')}
+\`\`\`
+
+## License
+
+MIT (see \`LICENSE\`)
+`;
+}
+
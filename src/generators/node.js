@@ -0,0 +1,42 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { fileURLToPath } from 'node:url';
+import { projectVariables, renderProject } from '../templating/index.js';
+import { generateNodeTasks } from './tasks.js';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+// Like the Go stacks, the Node stacks copy a committed sample and replace
+// its literal app name and port (see src/templating). Features add
+// overlays from src/templates/node: container files, a CI workflow that
+// runs npm test, and the tests themselves. The Makefile (or Taskfile)
+// wraps the npm scripts and compose commands.
+const samples = {
+  'node-htmx': {
+    dir: path.join(__dirname, '../../generated-samples/typescript/node-htmx-sample'),
+    vars: { AppName: 'node-htmx-app', Port: '3000' },
+    tests: 'testing/node-htmx'
+  }
+};
+const overlayDir = path.join(__dirname, '../templates/node');
+
+async function generateNode(sampleId, projectPath, features, options) {
+  const sample = samples[sampleId];
+  await fs.copy(sample.dir, projectPath);
+
+  const overlays = [];
+  if (features.includes('docker')) overlays.push('docker');
+  if (features.includes('ci')) overlays.push('ci');
+  if (features.includes('testing')) overlays.push(sample.tests);
+  for (const overlay of overlays) {
+    await fs.copy(path.join(overlayDir, overlay), projectPath);
+  }
+
+  const vars = await projectVariables(projectPath, options, sample.vars);
+  await renderProject(projectPath, sample.vars, vars);
+  await generateNodeTasks(projectPath, { runner: options.taskRunner, test: features.includes('testing'), docker: features.includes('docker') });
+}
+
+export async function generateNodeHTMX(projectPath, features, options = {}) {
+  await generateNode('node-htmx', projectPath, features, options);
+}
@@ -0,0 +1,18 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { manifestName } from '../utils/registry.js';
+import { projectVariables, renderProject } from '../templating/index.js';
+
+// Copy a fetched remote template into the project, leaving out its git
+// metadata and manifest, then render its *.tmpl files. Template files
+// replace the common ones.
+export async function generateRemote(projectPath, source, options = {}) {
+  await fs.copy(source.dir, projectPath, {
+    filter: file => {
+      const name = path.basename(file);
+      return name !== '.git' && !(name === manifestName && path.dirname(file) === source.dir);
+    }
+  });
+
+  await renderProject(projectPath, {}, await projectVariables(projectPath, options));
+}
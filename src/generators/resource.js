@@ -0,0 +1,751 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { humanize, words } from '../utils/inflection.js';
+import { parseResourceSpec } from '../utils/resource.js';
+import { injectFile } from '../utils/inject.js';
+
+// How each field type is held in Go, edited in a form and stored per SQL
+// dialect (keyed by the goose dialect name in store/open.go)
+const fieldTypes = {
+  string: { go: 'string', sql: { postgres: 'TEXT', sqlite3: 'TEXT', mysql: 'VARCHAR(255)' } },
+  text: { go: 'string', sql: { postgres: 'TEXT', sqlite3: 'TEXT', mysql: 'TEXT' } },
+  int: { go: 'int64', sql: { postgres: 'BIGINT', sqlite3: 'INTEGER', mysql: 'BIGINT' } },
+  float: { go: 'float64', sql: { postgres: 'DOUBLE PRECISION', sqlite3: 'REAL', mysql: 'DOUBLE' } },
+  bool: { go: 'bool', sql: { postgres: 'BOOLEAN', sqlite3: 'INTEGER', mysql: 'BOOLEAN' } },
+  time: { go: 'time.Time', sql: { postgres: 'TIMESTAMPTZ', sqlite3: 'DATETIME', mysql: 'DATETIME' } }
+};
+
+const primaryKeys = {
+  postgres: 'id BIGSERIAL PRIMARY KEY',
+  sqlite3: 'id INTEGER PRIMARY KEY AUTOINCREMENT',
+  mysql: 'id BIGINT AUTO_INCREMENT PRIMARY KEY'
+};
+
+function pascal(name) {
+  return words(name).map(w => w[0].toUpperCase() + w.slice(1)).join('');
+}
+
+function camel(name) {
+  const p = pascal(name);
+  return p[0].toLowerCase() + p.slice(1);
+}
+
+function snake(name) {
+  return words(name).join('_');
+}
+
+// Expand the CLI arguments ("product", ["name:string", "price:float"])
+// into the names every generated file uses.
+export function describeResource(name, fieldArgs) {
+  const spec = parseResourceSpec(`${pascal(name)}:${fieldArgs.join(',')}`);
+  if (spec.fields.length === 0) {
+    throw new Error('Give at least one field, e.g. name:string');
+  }
+  for (const field of spec.fields) {
+    if (field.name.toLowerCase() === 'id') {
+      throw new Error('"id" is added automatically; leave it out of the field list');
+    }
+  }
+
+  return {
+    type: spec.name,
+    plural: spec.plural,
+    varName: camel(spec.name),
+    pluralVar: camel(spec.plural),
+    label: spec.displayName,
+    pluralLabel: spec.displayPlural,
+    route: spec.route,
+    table: snake(spec.plural),
+    file: snake(spec.name),
+    fields: spec.fields.map(f => ({
+      ...f,
+      goName: pascal(f.name),
+      column: snake(f.name),
+      label: humanize(f.name),
+      goType: fieldTypes[f.type].go
+    }))
+  };
+}
+
+function hasTime(res) {
+  return res.fields.some(f => f.type === 'time');
+}
+
+function modelFile(res) {
+  const width = Math.max(2, ...res.fields.map(f => f.goName.length));
+  const pad = name => name.padEnd(width);
+  const imports = hasTime(res) ? 'import "time"\n\n' : '';
+
+  return `package models
+
+${imports}type ${res.type} struct {
+    ${pad('ID')} string
+${res.fields.map(f => `    ${pad(f.goName)} ${f.goType}`).join('\n')}
+}
+`;
+}
+
+function storeFile(res, sql) {
+  const { type, plural, pluralVar } = res;
+  const width = Math.max(6, pluralVar.length);
+  const open = sql
+    ? ''
+    : `
+// Open${plural} returns the ${res.label.toLowerCase()} store for this build. Items are kept in
+// memory, so ${res.pluralLabel.toLowerCase()} are too.
+func Open${plural}(items ItemStore) ${type}Store {
+    return New${type}MemoryStore()
+}
+`;
+
+  return `package store
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "${res.module}/models"
+)
+
+// ${type}Store is the persistence boundary used by the ${res.label.toLowerCase()} handlers.
+type ${type}Store interface {
+    List(ctx context.Context) ([]models.${type}, error)
+    Get(ctx context.Context, id string) (models.${type}, error)
+    Create(ctx context.Context, ${res.varName} models.${type}) (models.${type}, error)
+    Update(ctx context.Context, ${res.varName} models.${type}) (models.${type}, error)
+    Delete(ctx context.Context, id string) error
+}
+${open}
+// ${type}MemoryStore keeps ${res.pluralLabel.toLowerCase()} in process memory.
+type ${type}MemoryStore struct {
+    ${'mu'.padEnd(width)} sync.RWMutex
+    ${pluralVar.padEnd(width)} []models.${type}
+    ${'nextID'.padEnd(width)} int
+}
+
+func New${type}MemoryStore() *${type}MemoryStore {
+    return &${type}MemoryStore{nextID: 1}
+}
+
+func (s *${type}MemoryStore) List(ctx context.Context) ([]models.${type}, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    ${pluralVar} := make([]models.${type}, len(s.${pluralVar}))
+    copy(${pluralVar}, s.${pluralVar})
+    return ${pluralVar}, nil
+}
+
+func (s *${type}MemoryStore) Get(ctx context.Context, id string) (models.${type}, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, v := range s.${pluralVar} {
+        if v.ID == id {
+            return v, nil
+        }
+    }
+    return models.${type}{}, ErrNotFound
+}
+
+func (s *${type}MemoryStore) Create(ctx context.Context, v models.${type}) (models.${type}, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    v.ID = fmt.Sprintf("%d", s.nextID)
+    s.nextID++
+    s.${pluralVar} = append(s.${pluralVar}, v)
+    return v, nil
+}
+
+func (s *${type}MemoryStore) Update(ctx context.Context, v models.${type}) (models.${type}, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for i := range s.${pluralVar} {
+        if s.${pluralVar}[i].ID == v.ID {
+            s.${pluralVar}[i] = v
+            return v, nil
+        }
+    }
+    return models.${type}{}, ErrNotFound
+}
+
+func (s *${type}MemoryStore) Delete(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for i, v := range s.${pluralVar} {
+        if v.ID == id {
+            s.${pluralVar} = append(s.${pluralVar}[:i], s.${pluralVar}[i+1:]...)
+            return nil
+        }
+    }
+    return ErrNotFound
+}
+`;
+}
+
+function sqlStoreFile(res) {
+  const { type, plural, table } = res;
+  const columns = res.fields.map(f => f.column);
+  const args = res.fields.map(f => `v.${f.goName}`).join(', ');
+  const marks = columns.map(() => '?').join(', ');
+  const sets = columns.map(c => `${c} = ?`).join(', ');
+  const scans = res.fields.map(f => `&v.${f.goName}`).join(', ');
+
+  return `package store
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "strconv"
+    "${res.module}/models"
+)
+
+const ${res.varName}Columns = "id, ${columns.join(', ')}"
+
+// SQL${type}Store keeps ${res.pluralLabel.toLowerCase()} in the items database.
+type SQL${type}Store struct {
+    base *SQLStore
+}
+
+// Open${plural} returns a ${res.label.toLowerCase()} store sharing the item store's
+// connection, or an in-memory one when items aren't in SQL.
+func Open${plural}(items ItemStore) ${type}Store {
+    if s, ok := items.(*SQLStore); ok {
+        return &SQL${type}Store{base: s}
+    }
+    return New${type}MemoryStore()
+}
+
+func (s *SQL${type}Store) List(ctx context.Context) ([]models.${type}, error) {
+    rows, err := s.base.db.QueryContext(ctx, "SELECT "+${res.varName}Columns+" FROM ${table} ORDER BY id")
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    var ${res.pluralVar} []models.${type}
+    for rows.Next() {
+        v, err := scan${type}(rows)
+        if err != nil {
+            return nil, err
+        }
+        ${res.pluralVar} = append(${res.pluralVar}, v)
+    }
+    return ${res.pluralVar}, rows.Err()
+}
+
+func (s *SQL${type}Store) Get(ctx context.Context, id string) (models.${type}, error) {
+    key, err := strconv.ParseInt(id, 10, 64)
+    if err != nil {
+        return models.${type}{}, ErrNotFound
+    }
+    v, err := scan${type}(s.base.db.QueryRowContext(ctx, s.base.bind("SELECT "+${res.varName}Columns+" FROM ${table} WHERE id = ?"), key))
+    if errors.Is(err, sql.ErrNoRows) {
+        return models.${type}{}, ErrNotFound
+    }
+    return v, err
+}
+
+func (s *SQL${type}Store) Create(ctx context.Context, v models.${type}) (models.${type}, error) {
+    query := "INSERT INTO ${table} (${columns.join(', ')}) VALUES (${marks})"
+    args := []any{${args}}
+
+    var id int64
+    if s.base.dialect.Returning {
+        if err := s.base.db.QueryRowContext(ctx, s.base.bind(query+" RETURNING id"), args...).Scan(&id); err != nil {
+            return models.${type}{}, err
+        }
+    } else {
+        res, err := s.base.db.ExecContext(ctx, s.base.bind(query), args...)
+        if err != nil {
+            return models.${type}{}, err
+        }
+        if id, err = res.LastInsertId(); err != nil {
+            return models.${type}{}, err
+        }
+    }
+    v.ID = strconv.FormatInt(id, 10)
+    return v, nil
+}
+
+func (s *SQL${type}Store) Update(ctx context.Context, v models.${type}) (models.${type}, error) {
+    key, err := strconv.ParseInt(v.ID, 10, 64)
+    if err != nil {
+        return models.${type}{}, ErrNotFound
+    }
+    res, err := s.base.db.ExecContext(ctx, s.base.bind("UPDATE ${table} SET ${sets} WHERE id = ?"), ${args}, key)
+    if err != nil {
+        return models.${type}{}, err
+    }
+    if err := requireRow(res); err != nil {
+        // MySQL reports 0 affected rows when nothing changed
+        if _, getErr := s.Get(ctx, v.ID); getErr != nil {
+            return models.${type}{}, getErr
+        }
+    }
+    return v, nil
+}
+
+func (s *SQL${type}Store) Delete(ctx context.Context, id string) error {
+    key, err := strconv.ParseInt(id, 10, 64)
+    if err != nil {
+        return ErrNotFound
+    }
+    res, err := s.base.db.ExecContext(ctx, s.base.bind("DELETE FROM ${table} WHERE id = ?"), key)
+    if err != nil {
+        return err
+    }
+    return requireRow(res)
+}
+
+func scan${type}(row scanner) (models.${type}, error) {
+    var (
+        v  models.${type}
+        id int64
+    )
+    if err := row.Scan(&id, ${scans}); err != nil {
+        return models.${type}{}, err
+    }
+    v.ID = strconv.FormatInt(id, 10)
+    return v, nil
+}
+`;
+}
+
+function migrationFile(res, dialect) {
+  const width = Math.max(...res.fields.map(f => f.column.length));
+  const columns = [primaryKeys[dialect]].concat(
+    res.fields.map(f => `${f.column.padEnd(width)} ${fieldTypes[f.type].sql[dialect]} NOT NULL`)
+  );
+  return `-- +goose Up
+CREATE TABLE ${res.table} (
+    ${columns.join(',\n    ')}
+);
+
+-- +goose Down
+DROP TABLE ${res.table};
+`;
+}
+
+// Form parsing: strings go through the sanitizer like item fields; other
+// types collect a message when the input doesn't parse
+function parseField(f) {
+  const errors = {
+    int: 'Must be a whole number',
+    float: 'Must be a number',
+    time: 'Must be a date and time'
+  };
+  switch (f.type) {
+    case 'string':
+    case 'text':
+      return `    v.${f.goName} = fields.Clean("${f.name}", r.FormValue("${f.name}"))`;
+    case 'bool':
+      return `    v.${f.goName} = formBool(r, "${f.name}")`;
+    default: {
+      const parser = { int: 'formInt', float: 'formFloat', time: 'formTime' }[f.type];
+      return `    if v.${f.goName}, err = ${parser}(r, "${f.name}"); err != nil {
+        errs.Add("${f.name}", "${errors[f.type]}")
+    }`;
+    }
+  }
+}
+
+function handlersFile(res) {
+  const { type, plural, route } = res;
+  const parsed = res.fields.some(f => ['int', 'float', 'time'].includes(f.type));
+  const decl = parsed ? '    var err error\n' : '';
+
+  return `package handlers
+
+import (
+    "net/http"
+    "github.com/go-chi/chi/v5"
+    "${res.module}/models"
+    "${res.module}/store"
+    "${res.module}/validation"
+    "${res.module}/views"
+)
+
+// ${type} persistence (in-memory unless main wires another backend)
+var ${res.varName}Store store.${type}Store = store.New${type}MemoryStore()
+
+func Use${type}Store(s store.${type}Store) {
+    ${res.varName}Store = s
+}
+
+// ${type}Routes registers the ${res.label.toLowerCase()} pages under ${route}.
+func ${type}Routes(r chi.Router) {
+    r.Get("/", List${plural})
+    r.Post("/", Create${type})
+    r.Get("/{id}", Get${type})
+    r.Put("/{id}", Update${type})
+    r.Delete("/{id}", Delete${type})
+    r.Get("/{id}/edit", Edit${type}Form)
+}
+
+func List${plural}(w http.ResponseWriter, r *http.Request) {
+    ${res.pluralVar}, err := ${res.varName}Store.List(r.Context())
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    component := views.${plural}Page(${res.pluralVar}, themeFor(r), themeToggle)
+    component.Render(r.Context(), w)
+}
+
+func Get${type}(w http.ResponseWriter, r *http.Request) {
+    v, err := ${res.varName}Store.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    component := views.${type}Row(v)
+    component.Render(r.Context(), w)
+}
+
+func Create${type}(w http.ResponseWriter, r *http.Request) {
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+    var v models.${type}
+    if errs := ${res.varName}FromForm(r, &v); !errs.Empty() {
+        writeValidationErrors(w, r, errs, views.${type}Form(v, errs))
+        return
+    }
+
+    if _, err := ${res.varName}Store.Create(r.Context(), v); err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    w.Header().Set("HX-Redirect", views.Path("${route}"))
+    w.WriteHeader(http.StatusCreated)
+}
+
+func Edit${type}Form(w http.ResponseWriter, r *http.Request) {
+    v, err := ${res.varName}Store.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    component := views.Edit${type}Form(v, validation.Errors{})
+    component.Render(r.Context(), w)
+}
+
+func Update${type}(w http.ResponseWriter, r *http.Request) {
+    v, err := ${res.varName}Store.Get(r.Context(), chi.URLParam(r, "id"))
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    if !parseForm(w, r) || !consumeNonce(w, r) {
+        return
+    }
+    if errs := ${res.varName}FromForm(r, &v); !errs.Empty() {
+        writeValidationErrors(w, r, errs, views.Edit${type}Form(v, errs))
+        return
+    }
+
+    v, err = ${res.varName}Store.Update(r.Context(), v)
+    if err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    component := views.${type}Row(v)
+    component.Render(r.Context(), w)
+}
+
+func Delete${type}(w http.ResponseWriter, r *http.Request) {
+    if err := ${res.varName}Store.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+        writeStoreError(w, r, err)
+        return
+    }
+
+    w.WriteHeader(http.StatusOK)
+}
+
+// ${res.varName}FromForm copies the submitted fields onto v, collecting an
+// error for each input that doesn't parse.
+func ${res.varName}FromForm(r *http.Request, v *models.${type}) validation.Errors {
+    errs := validation.Errors{}
+${decl}${res.fields.map(parseField).join('\n')}
+    return errs
+}
+`;
+}
+
+// Shared by every generated resource, so written once per project
+const formHelpersFile = res => `package handlers
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+    "${res.module}/reqctx"
+    "${res.module}/views"
+)
+
+// Form parsers for resources added with \`generate resource\`. Empty
+// inputs are the zero value.
+
+func formInt(r *http.Request, name string) (int64, error) {
+    v := r.FormValue(name)
+    if v == "" {
+        return 0, nil
+    }
+    return strconv.ParseInt(v, 10, 64)
+}
+
+func formFloat(r *http.Request, name string) (float64, error) {
+    v := r.FormValue(name)
+    if v == "" {
+        return 0, nil
+    }
+    return strconv.ParseFloat(v, 64)
+}
+
+// Checkboxes are only submitted when ticked
+func formBool(r *http.Request, name string) bool {
+    return r.FormValue(name) != ""
+}
+
+// formTime reads a datetime-local input in the app's timezone.
+func formTime(r *http.Request, name string) (time.Time, error) {
+    v := r.FormValue(name)
+    if v == "" {
+        return time.Time{}, nil
+    }
+    return time.ParseInLocation(views.DateTimeLocal, v, reqctx.StartTime(r.Context()).Location())
+}
+`;
+
+const viewHelpersFile = `package views
+
+import (
+    "strconv"
+    "time"
+)
+
+// Layout of <input type="datetime-local"> values
+const DateTimeLocal = "2006-01-02T15:04"
+
+// Input values for resources added with \`generate resource\`
+
+func FormatInt(v int64) string {
+    return strconv.FormatInt(v, 10)
+}
+
+func FormatFloat(v float64) string {
+    return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// FormatBool is a message key, translated where it's shown
+func FormatBool(v bool) string {
+    if v {
+        return "Yes"
+    }
+    return "No"
+}
+
+// FormatDateTime leaves unset times blank instead of showing year 1.
+func FormatDateTime(t time.Time) string {
+    if t.IsZero() {
+        return ""
+    }
+    return t.Format(DateTimeLocal)
+}
+`;
+
+// The input for one field, with its error message below it
+function formInput(res, f) {
+  const v = `${res.varName}.${f.goName}`;
+  const label = `i18n.T(ctx, "${f.label}")`;
+  let input;
+  switch (f.type) {
+    case 'text':
+      input = `<textarea name="${f.name}" placeholder={ ${label} }>{ ${v} }</textarea>`;
+      break;
+    case 'int':
+      input = `<input type="number" name="${f.name}" value={ FormatInt(${v}) } placeholder={ ${label} } />`;
+      break;
+    case 'float':
+      input = `<input type="number" step="any" name="${f.name}" value={ FormatFloat(${v}) } placeholder={ ${label} } />`;
+      break;
+    case 'bool':
+      input = `<label><input type="checkbox" name="${f.name}" value="true" checked?={ ${v} } /> { ${label} }</label>`;
+      break;
+    case 'time':
+      input = `<input type="datetime-local" name="${f.name}" value={ FormatDateTime(${v}) } />`;
+      break;
+    default:
+      input = `<input type="text" name="${f.name}" value={ ${v} } placeholder={ ${label} } />`;
+  }
+  return `    ${input}
+    if msg := errs.Get("${f.name}"); msg != "" {
+        <p class="field-error">{ i18n.T(ctx, msg) }</p>
+    }`;
+}
+
+// How a field is shown in a row
+function display(res, f) {
+  const v = `${res.varName}.${f.goName}`;
+  const value = {
+    int: `FormatInt(${v})`,
+    float: `FormatFloat(${v})`,
+    time: `FormatDateTime(${v})`
+  }[f.type];
+  if (f.type === 'bool') {
+    return `        <p><strong>{ i18n.T(ctx, "${f.label}") }:</strong> { i18n.T(ctx, FormatBool(${v})) }</p>`;
+  }
+  return `        <p><strong>{ i18n.T(ctx, "${f.label}") }:</strong> { ${value || v} }</p>`;
+}
+
+function viewsFile(res) {
+  const { type, plural, route, varName } = res;
+  const id = `"${res.file}-" + ${varName}.ID`;
+  const target = `"#${res.file}-" + ${varName}.ID`;
+
+  return `package views
+
+import (
+    "${res.module}/i18n"
+    "${res.module}/models"
+    "${res.module}/validation"
+)
+
+templ ${plural}Page(${res.pluralVar} []models.${type}, theme string, showToggle bool) {
+    @Layout("${res.pluralLabel}", theme, showToggle) {
+        <h1>${res.pluralLabel}</h1>
+
+        <div>
+            <h2>{ i18n.T(ctx, "Add ${res.label}") }</h2>
+            @${type}Form(models.${type}{}, validation.Errors{})
+        </div>
+
+        <div>
+            if len(${res.pluralVar}) == 0 {
+                <p>{ i18n.T(ctx, "No ${res.pluralLabel.toLowerCase()} yet") }</p>
+            }
+            for _, ${varName} := range ${res.pluralVar} {
+                @${type}Row(${varName})
+            }
+        </div>
+    }
+}
+
+templ ${type}Row(${varName} models.${type}) {
+    <div class="item" id={ ${id} }>
+${res.fields.map(f => display(res, f)).join('\n')}
+        <div class="item-actions">
+            <button hx-get={ Path("${route}/" + ${varName}.ID + "/edit") } hx-target={ ${target} } hx-swap="outerHTML">{ i18n.T(ctx, "Edit") }</button>
+            <button hx-delete={ Path("${route}/" + ${varName}.ID) } hx-confirm={ i18n.T(ctx, "Are you sure?") } hx-target={ ${target} } hx-swap="outerHTML swap:1s">{ i18n.T(ctx, "Delete") }</button>
+        </div>
+    </div>
+}
+
+templ ${type}Form(${varName} models.${type}, errs validation.Errors) {
+    <form hx-post={ Path("${route}") } hx-swap="outerHTML">
+        @NonceField()
+        @${varName}Fields(${varName}, errs)
+        <button type="submit">{ i18n.T(ctx, "Add ${res.label}") }</button>
+    </form>
+}
+
+templ Edit${type}Form(${varName} models.${type}, errs validation.Errors) {
+    <form hx-put={ Path("${route}/" + ${varName}.ID) } hx-swap="outerHTML" id={ ${id} }>
+        @NonceField()
+        @${varName}Fields(${varName}, errs)
+        <button type="submit">{ i18n.T(ctx, "Update ${res.label}") }</button>
+        <button type="button" hx-get={ Path("${route}/" + ${varName}.ID) } hx-target={ ${target} } hx-swap="outerHTML">{ i18n.T(ctx, "Cancel") }</button>
+    </form>
+}
+
+templ ${varName}Fields(${varName} models.${type}, errs validation.Errors) {
+${res.fields.map(f => formInput(res, f)).join('\n')}
+}
+`;
+}
+
+// The goose dialect of a SQL build ("" for the in-memory store)
+async function sqlDialect(root) {
+  if (!(await fs.pathExists(path.join(root, 'store', 'sql.go')))) return '';
+  const open = await fs.readFile(path.join(root, 'store', 'open.go'), 'utf8');
+  return (/Goose:\s*"(\w+)"/.exec(open) || [])[1] || '';
+}
+
+// Import path of the project's packages, from go.mod
+async function modulePath(root) {
+  const goMod = await fs.readFile(path.join(root, 'go.mod'), 'utf8').catch(() => '');
+  return (/^module\s+(\S+)/m.exec(goMod) || [])[1] || 'myapp';
+}
+
+// Next sequential goose version, after the highest existing one
+async function nextMigration(dir) {
+  const versions = (await fs.readdir(dir))
+    .map(file => /^(\d+)_/.exec(file))
+    .filter(Boolean)
+    .map(match => Number(match[1]));
+  return String(Math.max(0, ...versions) + 1).padStart(5, '0');
+}
+
+// Add a CRUD resource to a generated go-htmx project: model, store,
+// handlers, templ views and (for SQL builds) a migration, then register
+// its store and routes in main.go. Nothing is written when any target
+// file already exists or main.go lacks the markers.
+export async function generateResource(root, resource) {
+  const res = { ...resource, module: await modulePath(root) };
+  const dialect = await sqlDialect(root);
+  const files = {
+    [`models/${res.file}.go`]: modelFile(res),
+    [`store/${res.file}.go`]: storeFile(res, Boolean(dialect)),
+    [`handlers/${res.file}.go`]: handlersFile(res),
+    [`views/${res.file}.templ`]: viewsFile(res)
+  };
+  if (dialect) {
+    files[`store/${res.file}_sql.go`] = sqlStoreFile(res);
+    const version = await nextMigration(path.join(root, 'migrations'));
+    files[`migrations/${version}_create_${res.table}.sql`] = migrationFile(res, dialect);
+  }
+
+  for (const file of Object.keys(files)) {
+    if (await fs.pathExists(path.join(root, file))) {
+      throw new Error(`${file} already exists`);
+    }
+  }
+  const main = path.join(root, 'main.go');
+  const source = await fs.readFile(main, 'utf8');
+  for (const marker of ['stores', 'routes']) {
+    if (!source.includes(`// stack-app:${marker}`)) {
+      throw new Error(`main.go has no "// stack-app:${marker}" marker to register ${res.pluralLabel.toLowerCase()} at`);
+    }
+  }
+
+  const shared = {
+    'handlers/resourceform.go': formHelpersFile(res),
+    'views/resourceform.go': viewHelpersFile
+  };
+  for (const [file, content] of Object.entries(shared)) {
+    if (!(await fs.pathExists(path.join(root, file)))) {
+      files[file] = content;
+    }
+  }
+
+  for (const [file, content] of Object.entries(files)) {
+    await fs.writeFile(path.join(root, file), content);
+  }
+  await injectFile(main, [
+    { marker: 'stores', code: `handlers.Use${res.type}Store(store.Open${res.plural}(items))` },
+    { marker: 'routes', code: `r.With(mw.MaxBodySize(formLimit)).Route("${res.route}", handlers.${res.type}Routes)` }
+  ]);
+
+  return Object.keys(files).sort();
+}
@@ -0,0 +1,343 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import yaml from 'js-yaml';
+
+// Generated projects get their commands as targets of a task runner:
+// a Makefile, or a Taskfile.yml for Task (https://taskfile.dev) with
+// --task-runner task. Each target is
+//   { name, desc, deps, cmds, watchers?, sources?, generates? }
+// written once and rendered for either runner. Commands use Make's
+// $(VAR) for the runner variables (vars, with their defaults) and
+// $(name) for an argument (make migrate-create name=x, task
+// migrate-create -- x). Targets with sources are files in Make
+// (node_modules: package.json), rebuilt when a source changes.
+// watchers run side by side with the last command until Ctrl-C.
+export const taskRunners = {
+  make: { file: 'Makefile', command: 'make', render: renderMakefile },
+  task: { file: 'Taskfile.yml', command: 'task', render: renderTaskfile }
+};
+
+export function taskRunner(name = 'make') {
+  const runner = taskRunners[name];
+  if (!runner) {
+    throw new Error(`Unknown task runner "${name}" (expected ${Object.keys(taskRunners).join(', ')})`);
+  }
+  return runner;
+}
+
+function renderMakefile(targets, { vars = {}, dotenv = false, header = '' }) {
+  const phony = targets.filter(target => !target.sources).map(target => target.name);
+  const defaults = Object.entries(vars).map(([name, value]) => `${name} := $(or $(${name}),${value})\n`).join('');
+  let text = dotenv || defaults
+    ? `${dotenv ? '-include .env\nexport\n' : ''}${defaults}\n`
+    : '';
+  text += `${header}.PHONY: ${phony.join(' ')}\n`;
+
+  for (const target of targets) {
+    const prereqs = [...(target.sources || []), ...target.deps];
+    text += `\n${target.comment ? `# ${target.comment}\n` : ''}${target.name}:${prereqs.map(dep => ` ${dep}`).join('')}\n`;
+    if (target.watchers) {
+      text += `\t@trap 'kill 0' EXIT; \\\n${target.watchers.map(watcher => `\t${watcher.cmd} & \\\n`).join('')}`;
+    }
+    text += target.cmds.map(cmd => `\t${cmd}\n`).join('');
+    if (target.sources) {
+      text += `\t@touch ${target.name}\n`;
+    }
+  }
+  return text;
+}
+
+// Task names can't be paths, so web/node_modules is web:node_modules
+function taskName(name) {
+  return name.replace(/\//g, ':');
+}
+
+function taskCommand(cmd, vars) {
+  return cmd.replace(/\$\((\w+)\)/g, (match, name) => {
+    if (name === 'name') return '{{.CLI_ARGS}}';
+    return name in vars ? `\${${name}:-${vars[name]}}` : `\${${name}}`;
+  });
+}
+
+// Make's prerequisites become Task's deps, which run in parallel before
+// the commands; watchers become internal dev:<name> tasks run side by
+// side, and file targets are skipped while their sources are unchanged
+function renderTaskfile(targets, { vars = {}, dotenv = false, header = '', includes = null }) {
+  const tasks = {};
+  const entry = (target, extra = {}) => ({
+    ...(target.desc && { desc: target.desc }),
+    ...extra,
+    ...(target.deps.length > 0 && { deps: target.deps.map(taskName) }),
+    ...(target.sources && { sources: target.sources, generates: target.generates }),
+    ...(target.cmds.length > 0 && { cmds: target.cmds.map(cmd => taskCommand(cmd, vars)) })
+  });
+
+  for (const target of targets) {
+    if (target.watchers) {
+      const watchers = [...target.watchers, { name: 'app', cmd: target.cmds[0] }];
+      tasks[taskName(target.name)] = { desc: target.desc, deps: watchers.map(watcher => `${target.name}:${watcher.name}`) };
+      for (const watcher of watchers) {
+        tasks[`${target.name}:${watcher.name}`] = entry({ deps: watcher.deps || [], cmds: [watcher.cmd] }, { internal: true });
+      }
+    } else {
+      tasks[taskName(target.name)] = entry(target);
+    }
+  }
+
+  const taskfile = {
+    version: '3',
+    ...(includes && { includes }),
+    ...(dotenv && { dotenv: ['.env'] }),
+    tasks
+  };
+  return `${header}${spaceTasks(yaml.dump(taskfile, { lineWidth: -1 }))}`;
+}
+
+// A blank line before each top-level section and task, as in a Makefile
+function spaceTasks(text) {
+  const lines = text.split('\n');
+  return lines.map((line, i) => {
+    const section = i > 0 && /^[a-z]/.test(line);
+    const task = /^  [\w:-]+:$/.test(line) && !/^\S.*:$/.test(lines[i - 1]);
+    return section || task ? `\n${line}` : line;
+  }).join('\n');
+}
+
+// Write the targets with the project's task runner, and point its README
+// (and .air.toml) at the runner when it isn't make
+async function writeTasks(projectPath, runnerName, targets, options = {}) {
+  const runner = taskRunner(runnerName);
+  await fs.writeFile(path.join(projectPath, runner.file), runner.render(targets, options));
+  if (runner.command !== 'make') {
+    await useRunnerInDocs(projectPath, runner, targets.map(target => target.name));
+  }
+}
+
+// "make migrate-create name=x" in the docs becomes "task migrate-create
+// -- x", and "make <target>" "task <target>" for the targets there are
+async function useRunnerInDocs(projectPath, runner, names) {
+  const known = new Set(names.map(taskName));
+  for (const file of ['README.md', '.air.toml']) {
+    const full = path.join(projectPath, file);
+    if (!(await fs.pathExists(full))) continue;
+    const text = await fs.readFile(full, 'utf8');
+    const next = text
+      .replace(/\bmake ([a-z][\w-]*) name=(\S+)/g, (match, name, value) => (known.has(name) ? `${runner.command} ${name} -- ${value}` : match))
+      .replace(/\bmake -C (\S+) ([a-z][\w-]*)/g, `${runner.command} -d $1 $2`)
+      .replace(/\bmake ([a-z][\w-]*)\b/g, (match, name) => (known.has(name) ? `${runner.command} ${name}` : match))
+      .replace(/`Makefile`/g, `\`${runner.file}\``);
+    if (next !== text) {
+      await fs.writeFile(full, next);
+    }
+  }
+}
+
+// buf runs through go run, so it needs no separate install
+const buf = 'go run github.com/bufbuild/buf/cmd/buf@v1.34.0';
+const templGenerate = 'go run github.com/a-h/templ/cmd/templ generate';
+
+// static/ is embedded at compile time, so with an asset pipeline the
+// assets are built first; so is a React frontend (web/dist) for build.
+// With --jobs the worker binary is built too. dev runs the watchers side
+// by side and stops them all on Ctrl-C: templ regenerates views, the
+// asset pipeline rebuilds static/, and air (configured in .air.toml)
+// rebuilds and restarts the app on each change. A React frontend runs on
+// the Vite dev server, which proxies the API.
+function goAppTargets({ templ, assets, web, worker }) {
+  const assetDeps = assets ? ['assets'] : [];
+  const targets = [
+    { name: 'run', desc: 'Run the app', deps: assetDeps, cmds: ['go run .'] },
+    {
+      name: 'build',
+      desc: `Build bin/app${worker ? ' and bin/worker' : ''}`,
+      deps: [...assetDeps, ...(web ? ['web'] : [])],
+      cmds: ['go build -o bin/app .', ...(worker ? ['go build -o bin/worker ./cmd/worker'] : [])]
+    },
+    {
+      name: 'dev',
+      desc: 'Live-reload the app with air, next to the templ and asset watchers',
+      deps: [...(assets ? ['node_modules'] : []), ...(web ? ['web/node_modules'] : [])],
+      watchers: [
+        templ && { name: 'templ', cmd: `${templGenerate} --watch` },
+        assets && { name: 'assets', cmd: 'npm run watch', deps: ['node_modules'] },
+        web && { name: 'web', cmd: '(cd web && npm run dev)', deps: ['web/node_modules'] }
+      ].filter(Boolean),
+      cmds: ['go run github.com/air-verse/air@v1.52.3']
+    }
+  ];
+  if (worker) {
+    // The worker runs next to the app (make dev in another terminal)
+    targets.push({ name: 'worker', desc: 'Run the background worker', deps: [], cmds: ['go run ./cmd/worker'] });
+  }
+  return targets;
+}
+
+const protoTargets = [
+  { name: 'proto', desc: 'Lint the .proto files and generate the Go code', deps: [], cmds: [`${buf} lint`, `${buf} generate`] }
+];
+
+// npm installs the pipeline's tools again only when package.json changes
+const assetTargets = [
+  { name: 'node_modules', deps: [], sources: ['package.json'], generates: ['node_modules/.package-lock.json'], cmds: ['npm install'] },
+  { name: 'assets', desc: 'Build the CSS/JS assets into static/', deps: ['node_modules'], cmds: ['npm run build'] },
+  { name: 'assets-watch', desc: 'Rebuild the assets on every change', deps: ['node_modules'], cmds: ['npm run watch'] }
+];
+
+// The React frontend lives in web/ with its own package.json. web-types
+// regenerates its API types from the OpenAPI spec.
+const webTargets = [
+  { name: 'web/node_modules', deps: [], sources: ['web/package.json'], generates: ['web/node_modules/.package-lock.json'], cmds: ['cd web && npm install'] },
+  { name: 'web', desc: 'Build the React frontend into web/dist', deps: ['web/node_modules'], cmds: ['cd web && npm run build'] },
+  { name: 'web-types', desc: 'Regenerate the frontend\'s API types from the OpenAPI spec', deps: ['web/node_modules'], cmds: ['cd web && npm run types'] }
+];
+
+// The Playwright suite (--e2e) lives in e2e/ with its own package.json;
+// installing it also fetches Chromium. e2e starts the app itself, so the
+// views are generated and the assets built first.
+function e2eTargets({ templ, assets }) {
+  return [
+    { name: 'e2e/node_modules', deps: [], sources: ['e2e/package.json'], generates: ['e2e/node_modules/.package-lock.json'], cmds: ['cd e2e && npm install && npx playwright install chromium'] },
+    { name: 'e2e', desc: 'Run the browser tests against the app', deps: [...(assets ? ['assets'] : []), 'e2e/node_modules'], cmds: [...(templ ? [templGenerate] : []), 'cd e2e && npx playwright test'] }
+  ];
+}
+
+// k6 (--loadtest) drives the CRUD routes of a running app at BASE_URL
+// (the local server by default); bench runs the store benchmarks, which
+// come with the tests.
+function loadTestTargets({ bench }) {
+  return [
+    { name: 'loadtest', desc: 'Load test the running app with k6 (BASE_URL)', deps: [], cmds: ['k6 run -e BASE_URL=$(BASE_URL) loadtest/items.js'] },
+    ...(bench ? [{ name: 'bench', desc: 'Benchmark the item store', deps: [], cmds: ['go test -run=NONE -bench=. -benchmem ./store/'] }] : [])
+  ];
+}
+
+// templ views are generated code, so test, cover and lint regenerate
+// them first
+function goCheckTargets({ templ }) {
+  const generate = templ ? [templGenerate] : [];
+  return [
+    { name: 'test', desc: 'Run the tests', deps: [], cmds: [...generate, 'go test ./...'] },
+    { name: 'test-race', desc: 'Run the tests with the race detector', comment: 'The race detector needs cgo (a C toolchain)', deps: [], cmds: [...generate, 'CGO_ENABLED=1 go test -race ./...'] },
+    { name: 'cover', desc: 'Run the tests and summarize coverage per function', deps: [], cmds: [...generate, 'go test -coverprofile=coverage.out ./...', 'go tool cover -func=coverage.out'] },
+    { name: 'lint', desc: 'Report suspicious code (go vet)', deps: [], cmds: [...generate, 'go vet ./...'] },
+    { name: 'fmt', desc: 'Format the Go code', deps: [], cmds: ['gofmt -w .'] }
+  ];
+}
+
+function dockerTargets({ logs = 'app', telemetry = false } = {}) {
+  const targets = [
+    { name: 'docker-build', desc: 'Build the images', deps: [], cmds: ['docker compose build'] },
+    { name: 'docker-up', desc: 'Build and start everything in the background', deps: [], cmds: ['docker compose up -d --build'] },
+    { name: 'docker-down', desc: 'Stop everything', deps: [], cmds: ['docker compose down'] },
+    { name: 'docker-logs', desc: 'Follow the logs', deps: [], cmds: [`docker compose logs -f${logs ? ` ${logs}` : ''}`] }
+  ];
+  // With --observability otel: the app plus Jaeger and Prometheus (the
+  // compose profile), with spans exported to Jaeger
+  if (telemetry) {
+    targets.push({ name: 'docker-observe', desc: 'Start the app with Jaeger and Prometheus', deps: [], cmds: ['OTEL_EXPORTER_OTLP_ENDPOINT=http://jaeger:4318 docker compose --profile observability up -d --build'] });
+  }
+  return targets;
+}
+
+// goose reads the same DATABASE_URL as the app, loaded from .env when
+// present (an empty value there falls back to the local default).
+// migrate applies pending migrations; migrate-down rolls back one at a
+// time.
+function migrationTargets({ driver }) {
+  const goose = `goose -dir migrations ${driver} "$(DATABASE_URL)"`;
+  return [
+    { name: 'migrate', desc: 'Apply pending migrations (migrate-up)', deps: ['migrate-up'], cmds: [] },
+    { name: 'migrate-up', desc: 'Apply pending migrations', deps: [], cmds: [`${goose} up`] },
+    { name: 'migrate-down', desc: 'Roll back the latest migration', deps: [], cmds: [`${goose} down`] },
+    { name: 'migrate-status', desc: 'List the migrations and whether each is applied', deps: [], cmds: [`${goose} status`] },
+    { name: 'migrate-create', desc: 'Create migrations/<timestamp>_<name>.sql', deps: [], cmds: ['goose -dir migrations create $(name) sql'] }
+  ];
+}
+
+// cmd/seed, which comes with the SQL backends, adds SEED_COUNT fake items;
+// seed-reset removes every item first.
+const seedTargets = [
+  { name: 'seed', desc: 'Add fake items to the database (SEED_COUNT of them, 50 by default)', deps: [], cmds: ['go run ./cmd/seed -count $(SEED_COUNT)'] },
+  { name: 'seed-reset', desc: 'Remove every item, then add SEED_COUNT fake ones', deps: [], cmds: ['go run ./cmd/seed -truncate -count $(SEED_COUNT)'] }
+];
+
+// Write the Makefile (or Taskfile) of a Go stack: run/build/dev, the
+// test, cover and lint targets, plus worker, proto, docker, goose
+// migration and seed, asset pipeline, frontend, browser and load test
+// targets when those are generated. docker is false or { telemetry };
+// loadtest is null or { url, bench }.
+export async function generateGoTasks(projectPath, { runner = 'make', templ = false, proto = false, docker = false, migrate = null, assets = false, web = false, worker = false, e2e = false, loadtest = null }) {
+  const targets = [
+    ...goAppTargets({ templ, assets, web, worker }),
+    ...(proto ? protoTargets : []),
+    ...(assets ? assetTargets : []),
+    ...(web ? webTargets : []),
+    ...goCheckTargets({ templ }),
+    ...(e2e ? e2eTargets({ templ, assets }) : []),
+    ...(loadtest ? loadTestTargets(loadtest) : []),
+    ...(docker ? dockerTargets({ telemetry: docker.telemetry }) : []),
+    ...(migrate ? [...migrationTargets(migrate), ...seedTargets] : [])
+  ];
+  const vars = {
+    ...(migrate && { DATABASE_URL: migrate.url, SEED_COUNT: 50 }),
+    ...(loadtest && { BASE_URL: loadtest.url })
+  };
+  await writeTasks(projectPath, runner, targets, migrate ? { vars, dotenv: true } : { vars });
+}
+
+// Write the root Makefile (or Taskfile) of a monorepo. build, lint and the
+// test targets run in every service (stopping at the first failure), dev
+// runs every service's dev target side by side, and the docker targets
+// drive the shared compose file. Each service keeps its own Makefile for
+// the rest; the root Taskfile includes them as <service>:<target>.
+export async function generateGoWorkspaceTasks(projectPath, { runner = 'make', services, docker = false }) {
+  const each = ['build', 'test', 'test-race', 'cover', 'lint'];
+  const docs = { build: 'Build every service', test: 'Run every service\'s tests', 'test-race': 'Run every service\'s tests with the race detector', cover: 'Summarize every service\'s coverage', lint: 'Vet every service' };
+  const dockerOnes = docker ? dockerTargets({ logs: '' }) : [];
+
+  if (runner === 'task') {
+    const targets = [
+      ...each.map(name => ({ name, desc: docs[name], deps: [], cmds: [] })),
+      { name: 'dev', desc: 'Run every service\'s dev target side by side', deps: services.map(service => `${service}:dev`), cmds: [] },
+      ...dockerOnes
+    ];
+    const includes = Object.fromEntries(services.map(service => [service, { taskfile: `services/${service}/Taskfile.yml`, dir: `services/${service}` }]));
+    const text = renderTaskfile(targets, { includes });
+    // Services run one after another, in the order they are listed
+    const taskfile = yaml.load(text);
+    for (const name of each) {
+      taskfile.tasks[name].cmds = services.map(service => ({ task: `${service}:${name}` }));
+    }
+    await fs.writeFile(path.join(projectPath, 'Taskfile.yml'), spaceTasks(yaml.dump(taskfile, { lineWidth: -1 })));
+    return;
+  }
+
+  const targets = [
+    ...each.map(name => ({ name, deps: [], cmds: [`@for s in $(SERVICES); do $(MAKE) -C services/$$s $@ || exit 1; done`] })),
+    { name: 'dev', deps: [], cmds: [`@trap 'kill 0' EXIT; \\\n\tfor s in $(SERVICES); do $(MAKE) -C services/$$s dev & done; \\\n\twait`] },
+    ...dockerOnes
+  ];
+  await fs.writeFile(path.join(projectPath, 'Makefile'), renderMakefile(targets, { header: `SERVICES := ${services.join(' ')}\n\n` }));
+}
+
+// The Node and Python stacks: install, run and dev, plus test with the
+// testing feature and the docker targets with the docker feature
+export async function generateNodeTasks(projectPath, { runner = 'make', test = false, docker = false }) {
+  await writeTasks(projectPath, runner, [
+    { name: 'install', desc: 'Install the dependencies', deps: [], cmds: ['npm install'] },
+    { name: 'run', desc: 'Run the app', deps: [], cmds: ['npm start'] },
+    { name: 'dev', desc: 'Run the app, restarting on file changes', deps: [], cmds: ['npm run dev'] },
+    ...(test ? [{ name: 'test', desc: 'Run the tests', deps: [], cmds: ['npm test'] }] : []),
+    ...(docker ? dockerTargets() : [])
+  ]);
+}
+
+export async function generatePythonTasks(projectPath, { runner = 'make', test = false, docker = false }) {
+  await writeTasks(projectPath, runner, [
+    { name: 'install', desc: 'Install the dependencies', deps: [], cmds: [`pip install -r ${test ? 'requirements-dev.txt' : 'requirements.txt'}`] },
+    { name: 'run', desc: 'Run the app', deps: [], cmds: ['ENVIRONMENT=production python -m app'] },
+    { name: 'dev', desc: 'Run the app, reloading on file changes', deps: [], cmds: ['ENVIRONMENT=development python -m app'] },
+    ...(test ? [{ name: 'test', desc: 'Run the tests', deps: [], cmds: ['pytest'] }] : []),
+    ...(docker ? dockerTargets() : [])
+  ]);
+}
@@ -5,7 +5,24 @@ import chalk from 'chalk';
 import figlet from 'figlet';
 import gradient from 'gradient-string';
 import { createProject } from './commands/create.js';
-import { listTemplates } from './commands/list.js';
+import { initProject } from './commands/init.js';
+import { listTemplates, listStacks } from './commands/list.js';
+import { describeStack, describeProject } from './commands/describe.js';
+import { previewProject } from './commands/preview.js';
+import { scaffoldApiVersion } from './commands/api-version.js';
+import { runDoctor } from './commands/doctor.js';
+import { deployGenerate } from './commands/deploy.js';
+import { generateResourceCommand } from './commands/generate.js';
+import { listAllTemplates, addTemplate, lintTemplateCommand } from './commands/templates.js';
+import { upgradeProject } from './commands/upgrade.js';
+import { addFeature } from './commands/add.js';
+import { regenProject } from './commands/regen.js';
+import { validateOpenAPI } from './commands/openapi.js';
+import { printCompletion, writeManPages, shells } from './commands/completion.js';
+import { selfUpdate, showVersion } from './commands/self-update.js';
+import { listPlugins } from './commands/plugins.js';
+import { findPlugin, runPlugin } from './utils/plugins.js';
+import { cliVersion } from './utils/project-manifest.js';
 
 const program = new Command();
 
@@ -25,30 +42,272 @@ function displayBanner() {
 program
   .name('create-stack-app')
   .description('Generate production-ready boilerplates across multiple programming languages')
-  .version('1.0.0');
+  .version(await cliVersion());
 
 program
   .command('new [project-name]')
   .description('Create a new project with interactive prompts')
-  .option('-t, --template <template>', 'Use a specific template')
+  .option('-t, --template <template>', 'Use a specific template: built-in id, registered name, or git-url@version')
   .option('-s, --skip-install', 'Skip dependency installation')
+  .option('--skip-hooks', 'Don\'t run the template\'s post-generation hooks (go mod tidy, templ generate, git init, ...)')
+  .option('--hooks-dry-run', 'List the post-generation hooks instead of running them')
+  .option('--dry-run', 'Print the files that would be generated (and which differ in an existing directory) without writing anything')
+  .option('--diff', 'Generate into an existing directory: show each differing file\'s diff and ask whether to overwrite it')
+  .option('--database <db>', 'Store backend for stacks that support it (memory, postgres, sqlite, mysql)')
+  .option('--auth <mode>', 'Login flow for stacks that support it (none, session, jwt, oauth)')
+  .option('--logging <logger>', 'Structured logger for stacks that support it (slog, zerolog)')
+  .option('--log <format>', 'Access log format for stacks that support it (text, json, clf, combined)')
+  .option('--css <setup>', 'Stylesheet setup for stacks that support it (plain, tailwind)')
+  .option('--bundler <bundler>', 'JavaScript bundler for stacks that support it (none, esbuild)')
+  .option('--frontend <approach>', 'Client-side approach for stacks that support it (htmx, alpine, svelte)')
+  .option('--error-format <format>', 'Error response bodies for stacks that support it (html, json, problem)')
+  .option('--trailing-slash <mode>', 'How /items/ is handled for stacks that support it (strip, redirect, strict)')
+  .option('--search-empty <items>', 'What an empty search lists, including the first render, for stacks that support it (all, none)')
+  .option('--theme-toggle [store]', 'Light/dark switch in the layout for stacks that support it (none, cookie; a bare --theme-toggle is cookie)')
+  .option('--realtime <transport>', 'Live updates for stacks that support them (none, sse, websocket)')
+  .option('--cache <backend>', 'Item read cache for Go stacks (memory, redis)')
+  .option('--observability <provider>', 'Tracing and metrics for Go stacks (none, otel)')
+  .option('--jobs <queue>', 'Background job queue for stacks that support it (none, asynq, river)')
+  .option('--storage <backend>', 'Attachment storage for stacks that support it (local, s3)')
+  .option('--mailer <transport>', 'Outgoing email for stacks that support it (console, smtp)')
+  .option('--flags <provider>', 'Feature flag provider for stacks that support it (env, openfeature)')
+  .option('--i18n <library>', 'Translation catalogs for stacks that support them (x-text, go-i18n)')
+  .option('--ratelimit [limiter]', 'Rate limiting for Go stacks (none, token-bucket; a bare --ratelimit is token-bucket)')
+  .option('--admin [ui]', 'Back office under /admin for stacks that support it (none, htmx; a bare --admin is htmx; needs --auth)')
+  .option('--cleanup [job]', 'Background purge of expired sessions and old soft-deleted items for stacks that support it (none, ticker; a bare --cleanup is ticker)')
+  .option('--response-cache [store]', 'Cache item reads for RESPONSE_CACHE_TTL (30s), invalidated by writes, for stacks that support it (none, memory; a bare --response-cache is memory)')
+  .option('--e2e [runner]', 'Browser tests for stacks that support them (none, playwright; a bare --e2e is playwright)')
+  .option('--secrets <store>', 'Secret store the config loads credentials from at startup, for Go stacks that support it (none, vault, aws-sm, sops)')
+  .option('--loadtest [tool]', 'Load test scripts and store benchmarks for Go stacks that support them (none, k6; a bare --loadtest is k6)')
+  .option('--task-runner <runner>', 'Write the project\'s run, dev, build, test, lint, migrate and docker-* targets as a Makefile (make) or a Taskfile.yml (task)', 'make')
+  .option('--ci [provider]', 'CI pipeline for Go stacks (none, github, gitlab; a bare --ci is github)')
+  .option('--layout <layout>', 'single (one app) or monorepo (a Go workspace of services sharing a pkg/ module)')
+  .option('--services <list>', 'Monorepo services: roles (api, web, grpc, worker) or name:stack, e.g. api,web,worker (default: api,web)')
+  .option('--module <path>', 'Go module path (default: the project name)')
+  .option('--port <port>', 'Default HTTP port of the generated app')
+  .option('--author <name>', 'Author for the LICENSE (default: git config user.name)')
+  .option('--registry <registry>', 'Container registry the CI pipeline pushes images to (default: ghcr.io/<owner> or registry.gitlab.com/<path> from a github.com or gitlab.com module path)')
+  .option('--docker', 'Add a multi-stage Dockerfile, docker-compose.yml with the selected database, .dockerignore and Makefile targets')
+  .option('--features <list>', 'Comma-separated extras (docker, ci, linting, testing, hooks, vscode) or "none"; skips the features prompt')
+  .option('-y, --yes', 'Skip the confirmation prompt')
   .action(async (projectName, options) => {
     displayBanner();
     await createProject(projectName, options);
   });
 
 program
+  .command('init')
+  .description('Walk through language, framework, database and deployment target, then generate the project')
+  .option('-s, --skip-install', 'Skip dependency installation')
+  .option('--skip-hooks', 'Don\'t run the template\'s post-generation hooks')
+  .action(async (options) => {
+    displayBanner();
+    await initProject(options);
+  });
+
+program
+  .command('list [what]')
+  .description('List all available templates, or "stacks" for configurable stacks')
+  .action((what) => {
+    displayBanner();
+    if (what === 'stacks') {
+      listStacks();
+    } else {
+      listTemplates();
+    }
+  });
+
+const templatesCommand = program
+  .command('templates')
+  .description('Manage remote templates');
+
+templatesCommand
   .command('list')
-  .description('List all available templates')
-  .action(() => {
+  .description('List built-in and registered templates')
+  .action(async () => {
+    await listAllTemplates();
+  });
+
+templatesCommand
+  .command('add <git-url>')
+  .description('Register a remote template (append @version to pin a tag or branch)')
+  .action(async (gitUrl) => {
+    await addTemplate(gitUrl);
+  });
+
+templatesCommand
+  .command('lint [path]')
+  .description('Check a template checkout before publishing: stack-app.json, {{ .Name }} placeholders, declared files (provides) and that the rendered Go code parses and builds')
+  .option('--skip-build', 'Don\'t run the template\'s hooks and go build on the rendered project')
+  .option('--strict', 'Fail on warnings too')
+  .action(async (dir, options) => {
+    await lintTemplateCommand(dir, options);
+  });
+
+program
+  .command('describe [kind] [id]')
+  .description('Show the options and defaults of a stack ("describe stack go-htmx"), or how a generated project was made ("describe", "describe project ./my-app")')
+  .action(async (kind = 'project', id) => {
+    if (kind === 'stack' && id) {
+      describeStack(id);
+    } else if (kind === 'project') {
+      await describeProject(id);
+    } else {
+      console.log(chalk.red(`\n❌ Cannot describe "${kind}". Try "describe stack <id>" or "describe project [dir]".`));
+      process.exit(1);
+    }
+  });
+
+program
+  .command('preview')
+  .description('Generate a stack into a temp directory and serve it without writing to disk')
+  .option('--stack <template>', 'Template to preview', 'go-htmx')
+  .option('-p, --port <port>', 'Port to serve on', '3000')
+  .option('--zip <file>', 'Write the generated project to a zip file instead of serving it')
+  .action(async (options) => {
     displayBanner();
-    listTemplates();
+    await previewProject(options);
+  });
+
+program
+  .command('doctor')
+  .description('Check for the toolchains a template needs; exits non-zero when required ones are missing')
+  .option('-t, --template <template>', 'Template to check for (default: survey every toolchain)')
+  .option('--database <db>', 'Also check for migration tools used with a SQL backend')
+  .action(async (options) => {
+    await runDoctor(options);
+  });
+
+const deployCommand = program
+  .command('deploy')
+  .description('Generate deployment configuration for a generated project');
+
+deployCommand
+  .command('generate [project-dir]')
+  .description('Write Kubernetes manifests or a Helm chart using the app\'s port, health checks and env vars')
+  .option('--target <target>', 'k8s or helm', 'k8s')
+  .option('--force', 'Overwrite previously generated files')
+  .action(async (projectDir, options) => {
+    await deployGenerate(projectDir, options);
+  });
+
+const generateCommand = program
+  .command('generate')
+  .description('Add code to an existing generated project');
+
+generateCommand
+  .command('resource <name> [fields...]')
+  .description('Add a CRUD resource (model, store, handlers, templ views, routes) to a go-htmx project, e.g. "product name:string price:float"')
+  .option('-d, --dir <project-dir>', 'Project directory', '.')
+  .action(async (name, fields, options) => {
+    await generateResourceCommand(name, fields, options);
+  });
+
+program
+  .command('add <feature> [value]')
+  .description('Turn on a feature (docker, ci, ...) or stack option (auth, observability, ...) in a generated project, merging it into the existing code, e.g. "add auth session"; plugin features are plugin:feature')
+  .option('-d, --dir <project-dir>', 'Project directory', '.')
+  .option('--dry-run', 'Show what would change without writing anything')
+  .option('--force', 'Add even if the project has uncommitted changes')
+  .action(async (feature, value, options) => {
+    await addFeature(feature, value, options);
+  });
+
+program
+  .command('regen [project-dir]')
+  .description('Generate the project again from its .stackapp.yaml (same template version and choices) into a fresh directory, and compare it with the recorded scaffold')
+  .requiredOption('-o, --output <dir>', 'Directory for the regenerated project')
+  .option('--check', 'Exit 1 when the output differs from the recorded scaffold')
+  .option('--force', 'Replace a non-empty output directory')
+  .action(async (projectDir, options) => {
+    await regenProject(projectDir, options);
+  });
+
+program
+  .command('api-version [project-dir]')
+  .description('Add the next JSON API version (api/vN) to a generated Go project')
+  .action(async (projectDir) => {
+    await scaffoldApiVersion(projectDir);
+  });
+
+program
+  .command('upgrade [project-dir]')
+  .description('Merge a newer version of the project\'s template (recorded in .stackapp.yaml) into it, listing conflicts')
+  .option('--to <version>', 'Tag or branch of a remote template to upgrade to (default: its default branch)')
+  .option('--dry-run', 'Show what would change without writing anything')
+  .option('--force', 'Upgrade even if the project has uncommitted changes')
+  .action(async (projectDir, options) => {
+    await upgradeProject(projectDir, options);
+  });
+
+const openapiCommand = program
+  .command('openapi')
+  .description('Work with a generated project\'s OpenAPI spec');
+
+openapiCommand
+  .command('validate [file]')
+  .description('Lint the spec (default: openapi/openapi.yaml in the project): version, info, path parameters, operationIds and $refs')
+  .option('-d, --dir <project-dir>', 'Project directory', '.')
+  .option('--strict', 'Fail on warnings too')
+  .action(async (file, options) => {
+    await validateOpenAPI(file, options);
+  });
+
+const pluginsCommand = program
+  .command('plugins')
+  .description('Work with stack-app-<name> plugins: extra commands, templates (plugin:template) and features');
+
+pluginsCommand
+  .command('list')
+  .description('List plugins found in ~/.stack-app/plugins and on PATH, with the templates and features they offer')
+  .action(async () => {
+    await listPlugins();
+  });
+
+program
+  .command('version')
+  .description('Print the CLI version; --check also reports whether a newer release is available')
+  .option('--check', 'Look up the newest release on the channel (nothing is installed)')
+  .option('--channel <channel>', 'Release channel: stable or beta (default: beta for prerelease versions)')
+  .action(async (options) => {
+    await showVersion(options);
+  });
+
+program
+  .command('self-update')
+  .description('Update the CLI to the newest GitHub release on a channel, verifying its checksum before replacing the install')
+  .option('--channel <channel>', 'Release channel: stable or beta (default: beta for prerelease versions)')
+  .option('--public-key <file>', 'Also require SHA256SUMS.sig to be a valid Ed25519 signature for this PEM public key')
+  .option('--force', 'Reinstall even when already up to date')
+  .action(async (options) => {
+    await selfUpdate(options);
+  });
+
+program
+  .command('completion <shell>')
+  .description(`Print a shell completion script (${shells.join(', ')}), e.g. source <(create-stack-app completion bash)`)
+  .action((shell) => {
+    printCompletion(program, shell);
+  });
+
+program
+  .command('man')
+  .description('Print the man page, or write one page per command into a directory for packaging')
+  .option('-o, --output <dir>', 'Write create-stack-app.1, create-stack-app-new.1, ... into this directory')
+  .action(async (options) => {
+    await writeManPages(program, options);
   });
 
-// Default command (no subcommand)
+// Default command (no subcommand); commands the CLI doesn't know run the
+// stack-app-<name> plugin of that name, if there is one
+const [command, ...args] = process.argv.slice(2);
+const builtin = name => name === 'help' || program.commands.some(cmd => cmd.name() === name || cmd.aliases().includes(name));
+const plugin = command && !command.startsWith('-') && !builtin(command) && await findPlugin(command);
 if (process.argv.length === 2) {
   displayBanner();
   createProject();
+} else if (plugin) {
+  process.exitCode = await runPlugin(plugin, args);
 } else {
   program.parse();
 }
@@ -0,0 +1,46 @@
+package admin
+
+import (
+    "strings"
+    "myapp/auth"
+    "myapp/views"
+)
+
+// userAccounts manages the accounts of the app's login flow. Only users
+// whose email is in ADMIN_EMAILS, and verified, may use the admin area:
+// anyone can register an address, and accounts are lost on restart.
+type userAccounts struct {
+    users  *auth.Users
+    admins map[string]bool
+}
+
+func newAccounts(authn *auth.Auth, emails []string) accounts {
+    admins := make(map[string]bool, len(emails))
+    for _, email := range emails {
+        admins[strings.ToLower(strings.TrimSpace(email))] = true
+    }
+    return &userAccounts{users: authn.Users(), admins: admins}
+}
+
+func (u *userAccounts) Enabled() bool { return true }
+
+func (u *userAccounts) Allowed(userID string) bool {
+    user, ok := u.users.Get(userID)
+    return ok && u.admin(user)
+}
+
+func (u *userAccounts) List() []views.AdminUser {
+    var list []views.AdminUser
+    for _, user := range u.users.List() {
+        list = append(list, views.AdminUser{ID: user.ID, Email: user.Email, Admin: u.admin(user)})
+    }
+    return list
+}
+
+func (u *userAccounts) Remove(id string) error {
+    return u.users.Delete(id)
+}
+
+func (u *userAccounts) admin(user auth.User) bool {
+    return user.Verified && u.admins[user.Email]
+}
@@ -0,0 +1,226 @@
+package admin
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "time"
+    "github.com/go-chi/chi/v5"
+    "myapp/auth"
+    "myapp/clock"
+    "myapp/config"
+    "myapp/events"
+    "myapp/handlers"
+    "myapp/logging"
+    "myapp/models"
+    "myapp/reqctx"
+    "myapp/store"
+    "myapp/views"
+)
+
+// Rows per page of the item table
+const pageSize = 50
+
+// Admin is the /admin back office: metrics cards read from the store, an
+// item table with bulk actions and, with --auth, user management. Its
+// routes sit behind the app's sign-in; the accounts directory decides
+// who among the signed-in users may open them.
+type Admin struct {
+    items    store.ItemStore
+    bus      *events.Bus
+    accounts accounts
+    clk      clock.Clock
+}
+
+// accounts backs the users page and the admin check. Without a login
+// flow there are no accounts (see users.go).
+type accounts interface {
+    // Enabled reports whether the app has user accounts at all
+    Enabled() bool
+    // Allowed reports whether the signed-in user may use the admin area
+    Allowed(userID string) bool
+    List() []views.AdminUser
+    Remove(id string) error
+}
+
+func New(cfg config.Admin, items store.ItemStore, bus *events.Bus, authn *auth.Auth, clk clock.Clock) *Admin {
+    return &Admin{items: items, bus: bus, accounts: newAccounts(authn, cfg.Emails), clk: clk}
+}
+
+// Routes mounts the /admin pages.
+func (a *Admin) Routes(r chi.Router) {
+    r.Route("/admin", func(r chi.Router) {
+        r.Use(a.require)
+        r.Get("/", a.dashboard)
+        r.Get("/items", a.itemsPage)
+        r.Get("/items/table", a.itemsTable)
+        r.Post("/items/bulk", a.bulk)
+        if a.accounts.Enabled() {
+            r.Get("/users", a.usersPage)
+            r.Delete("/users/{id}", a.deleteUser)
+        }
+    })
+}
+
+// require answers 403 to signed-in users who aren't admins.
+func (a *Admin) require(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if !a.accounts.Allowed(reqctx.User(r.Context())) {
+            http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func (a *Admin) dashboard(w http.ResponseWriter, r *http.Request) {
+    page, err := a.items.Find(r.Context(), store.ListQuery{IncludeDeleted: true})
+    if err != nil {
+        a.fail(w, r, err)
+        return
+    }
+
+    week := a.clk.Now().Add(-7 * 24 * time.Hour)
+    var m views.AdminMetrics
+    for _, item := range page.Items {
+        if item.Deleted() {
+            m.Deleted++
+            continue
+        }
+        m.Items++
+        if item.Attachment != nil {
+            m.Attachments++
+        }
+        if item.CreatedAt.After(week) {
+            m.NewThisWeek++
+        }
+    }
+    if a.accounts.Enabled() {
+        m.Users = len(a.accounts.List())
+    }
+
+    theme, showToggle := handlers.Theme(r)
+    views.AdminDashboard(theme, showToggle, a.accounts.Enabled(), m).Render(r.Context(), w)
+}
+
+func (a *Admin) itemsPage(w http.ResponseWriter, r *http.Request) {
+    table, err := a.table(r)
+    if err != nil {
+        a.fail(w, r, err)
+        return
+    }
+    theme, showToggle := handlers.Theme(r)
+    views.AdminItemsPage(theme, showToggle, a.accounts.Enabled(), table).Render(r.Context(), w)
+}
+
+// itemsTable re-renders the table for the search box, the deleted-items
+// toggle and the pager.
+func (a *Admin) itemsTable(w http.ResponseWriter, r *http.Request) {
+    table, err := a.table(r)
+    if err != nil {
+        a.fail(w, r, err)
+        return
+    }
+    views.AdminItemTable(table).Render(r.Context(), w)
+}
+
+// table reads the page of items the query string asks for: ?q searches,
+// ?deleted=1 includes soft-deleted items and ?page picks the page.
+func (a *Admin) table(r *http.Request) (views.AdminTable, error) {
+    q := r.URL.Query()
+    pageNum, _ := strconv.Atoi(q.Get("page"))
+    if pageNum < 1 {
+        pageNum = 1
+    }
+    t := views.AdminTable{Search: q.Get("q"), Deleted: q.Get("deleted") == "1", Page: pageNum}
+
+    page, err := a.items.Find(r.Context(), store.ListQuery{
+        Search:         t.Search,
+        Sort:           "-id",
+        Offset:         (pageNum - 1) * pageSize,
+        Limit:          pageSize,
+        IncludeDeleted: t.Deleted,
+    })
+    if err != nil {
+        return t, err
+    }
+    t.Items = page.Items
+    t.Pages = (page.Total + pageSize - 1) / pageSize
+    return t, nil
+}
+
+// bulk applies the chosen action to every selected item and re-renders
+// the table. Each item is published on the event bus like the app's own
+// changes.
+func (a *Admin) bulk(w http.ResponseWriter, r *http.Request) {
+    if err := r.ParseForm(); err != nil {
+        http.Error(w, "Invalid form submission", http.StatusBadRequest)
+        return
+    }
+    ids := r.PostForm["id"]
+    if len(ids) == 0 {
+        http.Error(w, "No items selected", http.StatusBadRequest)
+        return
+    }
+
+    switch r.PostFormValue("action") {
+    case "delete":
+        for _, id := range ids {
+            err := a.items.Delete(r.Context(), id)
+            if errors.Is(err, store.ErrNotFound) {
+                continue
+            }
+            if err != nil {
+                a.fail(w, r, err)
+                return
+            }
+            a.bus.Publish(r.Context(), events.ItemDeleted{ID: id})
+        }
+    case "clear-description":
+        updated, err := a.items.UpdateMany(r.Context(), ids, func(item *models.Item) {
+            item.Description = ""
+        })
+        if errors.Is(err, store.ErrNotFound) {
+            http.Error(w, "Some selected items no longer exist", http.StatusNotFound)
+            return
+        }
+        if err != nil {
+            a.fail(w, r, err)
+            return
+        }
+        for _, item := range updated {
+            a.bus.Publish(r.Context(), events.ItemUpdated{Item: item})
+        }
+    default:
+        http.Error(w, "Unknown bulk action", http.StatusBadRequest)
+        return
+    }
+
+    // The form carries the table's filters, so the same page comes back
+    r.URL.RawQuery = r.PostForm.Encode()
+    a.itemsTable(w, r)
+}
+
+func (a *Admin) usersPage(w http.ResponseWriter, r *http.Request) {
+    theme, showToggle := handlers.Theme(r)
+    views.AdminUsersPage(theme, showToggle, a.accounts.List(), reqctx.User(r.Context())).Render(r.Context(), w)
+}
+
+// deleteUser removes an account; admins can't remove their own.
+func (a *Admin) deleteUser(w http.ResponseWriter, r *http.Request) {
+    id := chi.URLParam(r, "id")
+    if id == reqctx.User(r.Context()) {
+        http.Error(w, "You can't delete your own account", http.StatusConflict)
+        return
+    }
+    if err := a.accounts.Remove(id); err != nil {
+        http.Error(w, "User not found", http.StatusNotFound)
+        return
+    }
+    w.WriteHeader(http.StatusOK)
+}
+
+func (a *Admin) fail(w http.ResponseWriter, r *http.Request, err error) {
+    logging.Error(r.Context(), "admin", "err", err)
+    http.Error(w, "Something went wrong", http.StatusInternalServerError)
+}
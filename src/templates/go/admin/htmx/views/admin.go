@@ -0,0 +1,47 @@
+package views
+
+import (
+    "net/url"
+    "strconv"
+    "myapp/models"
+)
+
+// AdminMetrics are the dashboard cards. Users is only shown when the app
+// has accounts.
+type AdminMetrics struct {
+    Items       int
+    Deleted     int
+    Attachments int
+    NewThisWeek int
+    Users       int
+}
+
+// AdminTable is one page of the admin item table and the filters it was
+// read with.
+type AdminTable struct {
+    Items   []models.Item
+    Search  string
+    Deleted bool
+    Page    int
+    Pages   int
+}
+
+// URL links to page n of the table fragment, keeping the filters.
+func (t AdminTable) URL(n int) string {
+    v := url.Values{}
+    if t.Search != "" {
+        v.Set("q", t.Search)
+    }
+    if t.Deleted {
+        v.Set("deleted", "1")
+    }
+    v.Set("page", strconv.Itoa(n))
+    return Path("/admin/items/table?" + v.Encode())
+}
+
+// AdminUser is a row of the admin users page.
+type AdminUser struct {
+    ID    string
+    Email string
+    Admin bool
+}
@@ -0,0 +1,7 @@
+package views
+
+// scripts are the URLs of the scripts every page loads: the esbuild bundle
+// of web/js/app.js, which includes htmx.
+func scripts() []string {
+    return []string{Asset("app.js")}
+}
@@ -0,0 +1,5 @@
+// Bundled into static/app.js by make assets (npm run build:js); the layout
+// loads it on every page. Import extensions and your own modules here.
+import htmx from 'htmx.org';
+
+window.htmx = htmx;
@@ -0,0 +1,16 @@
+/** @type {import('tailwindcss').Config} */
+module.exports = {
+  // Class names are picked up from the views; generated _templ.go files
+  // are scanned too so classes built in Go code are kept
+  content: ['./views/**/*.templ', './views/**/*.go'],
+  // The layout puts "dark" on <html> from the theme cookie
+  darkMode: 'class',
+  // The layout's own .container rule sets the page width
+  corePlugins: {
+    container: false
+  },
+  theme: {
+    extend: {}
+  },
+  plugins: []
+};
@@ -0,0 +1,218 @@
+package auth
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+    "github.com/go-chi/chi/v5"
+    "myapp/clock"
+    "myapp/config"
+    "myapp/handlers"
+    "myapp/logging"
+    "myapp/mailer"
+    "myapp/reqctx"
+    "myapp/views"
+)
+
+// tokens issues and checks the credential that keeps a user signed in
+// (a server-side session or a signed JWT, chosen at generation time).
+type tokens interface {
+    Issue(w http.ResponseWriter, r *http.Request, userID string) error
+    User(r *http.Request) string
+    Revoke(w http.ResponseWriter, r *http.Request)
+}
+
+// purger is implemented by tokens kept server-side, whose expired entries
+// the cleanup job removes.
+type purger interface {
+    PurgeExpired(now time.Time) int
+}
+
+// Auth wires the login, logout and register flow into the router.
+type Auth struct {
+    users  *Users
+    tokens tokens
+
+    // OAuth providers offered on the login page, and their routes
+    providers   []string
+    extraRoutes func(r chi.Router)
+
+    onRegister func(ctx context.Context, email string) error
+
+    // Verification and password reset emails (see email.go); appURL is
+    // the public URL their links start with
+    mail   mailer.Mailer
+    links  *links
+    appURL string
+}
+
+func newAuth(t tokens, cfg config.Auth, clk clock.Clock) *Auth {
+    views.UseSignOut(true)
+    return &Auth{users: NewUsers(), tokens: t, links: newLinks(clk), appURL: cfg.AppURL}
+}
+
+// Routes mounts the login, logout and register pages.
+func (a *Auth) Routes(r chi.Router) {
+    r.Get("/login", a.loginPage)
+    r.Post("/login", a.login)
+    r.Get("/register", a.registerPage)
+    r.Post("/register", a.register)
+    r.Post("/logout", a.logout)
+    r.Get("/verify", a.verify)
+    r.Get("/forgot", a.forgotPage)
+    r.Post("/forgot", a.forgot)
+    r.Get("/reset", a.resetPage)
+    r.Post("/reset", a.reset)
+    if a.extraRoutes != nil {
+        a.extraRoutes(r)
+    }
+}
+
+// Users returns the account store, for the admin users page.
+func (a *Auth) Users() *Users {
+    return a.users
+}
+
+// PurgeExpired removes expired sessions and returns how many. A signed
+// JWT is never stored, so with --auth jwt there is nothing to remove.
+func (a *Auth) PurgeExpired(now time.Time) int {
+    if p, ok := a.tokens.(purger); ok {
+        return p.PurgeExpired(now)
+    }
+    return 0
+}
+
+// OnRegister sets a function called after each new account is created,
+// such as enqueueing a welcome email. Its error is logged; the account
+// stays created and the user is signed in regardless.
+func (a *Auth) OnRegister(fn func(ctx context.Context, email string) error) {
+    a.onRegister = fn
+}
+
+// Middleware stores the signed-in user, if any, in the request context.
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if user := a.tokens.User(r); user != "" {
+            r = r.WithContext(reqctx.WithUser(r.Context(), user))
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// Require protects routes that need a signed-in user. Page loads are
+// redirected to the login form and come back afterwards; HTMX requests
+// get 401 with HX-Redirect so the whole page navigates there.
+func (a *Auth) Require(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if reqctx.User(r.Context()) != "" {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        login := views.Path("/login")
+        if r.Header.Get("HX-Request") != "" {
+            w.Header().Set("HX-Redirect", login)
+        } else if r.Method == http.MethodGet {
+            back := views.Path(r.URL.RequestURI())
+            http.Redirect(w, r, login+"?next="+url.QueryEscape(back), http.StatusSeeOther)
+            return
+        }
+        http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+    })
+}
+
+func (a *Auth) loginPage(w http.ResponseWriter, r *http.Request) {
+    a.renderLogin(w, r, http.StatusOK, views.AuthForm{Next: r.URL.Query().Get("next")})
+}
+
+func (a *Auth) login(w http.ResponseWriter, r *http.Request) {
+    form := formFrom(r)
+    user, err := a.users.Authenticate(form.Email, r.PostFormValue("password"))
+    if err != nil {
+        form.Error = "Invalid email or password"
+        a.renderLogin(w, r, http.StatusUnauthorized, form)
+        return
+    }
+    a.signIn(w, r, user, form.Next)
+}
+
+func (a *Auth) registerPage(w http.ResponseWriter, r *http.Request) {
+    a.renderRegister(w, r, http.StatusOK, views.AuthForm{Next: r.URL.Query().Get("next")})
+}
+
+func (a *Auth) register(w http.ResponseWriter, r *http.Request) {
+    form := formFrom(r)
+    user, err := a.users.Register(form.Email, r.PostFormValue("password"))
+    switch {
+    case errors.Is(err, ErrEmailTaken):
+        form.Error = "An account with this email already exists"
+    case errors.Is(err, ErrInvalidEmail):
+        form.Error = "Enter a valid email address"
+    case errors.Is(err, ErrWeakPassword):
+        form.Error = "Password must be 8 to 72 characters"
+    case err != nil:
+        logging.Error(r.Context(), "register", "err", err)
+        form.Error = "Something went wrong"
+    }
+    if err != nil {
+        a.renderRegister(w, r, http.StatusUnprocessableEntity, form)
+        return
+    }
+    a.sendVerification(r, user)
+    if a.onRegister != nil {
+        if err := a.onRegister(r.Context(), user.Email); err != nil {
+            logging.Error(r.Context(), "on register", "err", err)
+        }
+    }
+    a.signIn(w, r, user, form.Next)
+}
+
+func (a *Auth) logout(w http.ResponseWriter, r *http.Request) {
+    a.tokens.Revoke(w, r)
+    http.Redirect(w, r, views.Path("/login"), http.StatusSeeOther)
+}
+
+func (a *Auth) signIn(w http.ResponseWriter, r *http.Request, user User, next string) {
+    if err := a.tokens.Issue(w, r, user.ID); err != nil {
+        logging.Error(r.Context(), "sign in", "err", err)
+        http.Error(w, "Something went wrong", http.StatusInternalServerError)
+        return
+    }
+    http.Redirect(w, r, safeNext(next), http.StatusSeeOther)
+}
+
+func (a *Auth) renderLogin(w http.ResponseWriter, r *http.Request, status int, form views.AuthForm) {
+    theme, showToggle := handlers.Theme(r)
+    w.WriteHeader(status)
+    views.LoginPage(theme, showToggle, form, a.providers).Render(r.Context(), w)
+}
+
+func (a *Auth) renderRegister(w http.ResponseWriter, r *http.Request, status int, form views.AuthForm) {
+    theme, showToggle := handlers.Theme(r)
+    w.WriteHeader(status)
+    views.RegisterPage(theme, showToggle, form).Render(r.Context(), w)
+}
+
+func formFrom(r *http.Request) views.AuthForm {
+    return views.AuthForm{
+        Email: strings.TrimSpace(r.PostFormValue("email")),
+        Next:  r.PostFormValue("next"),
+    }
+}
+
+// safeNext only follows local paths after sign-in so the login form can't
+// be used as an open redirect.
+func safeNext(next string) string {
+    if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+        return views.Path("/")
+    }
+    return next
+}
+
+// secure reports whether cookies should carry the Secure flag.
+func secure(r *http.Request) bool {
+    return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
+    "net/http"
+    "sync"
+    "time"
+    "myapp/clock"
+    "myapp/handlers"
+    "myapp/logging"
+    "myapp/mailer"
+    "myapp/views"
+)
+
+// How long emailed links work
+const (
+    verifyLinkTTL = 24 * time.Hour
+    resetLinkTTL  = time.Hour
+)
+
+// UseMailer sets the mailer verification and password reset emails are
+// sent with; without one they are skipped.
+func (a *Auth) UseMailer(m mailer.Mailer) {
+    a.mail = m
+}
+
+// linkData is what the verify and reset email templates render.
+type linkData struct {
+    Email    string
+    URL      string
+    ValidFor string
+}
+
+// sendVerification emails a new account the link that confirms its
+// address. Failures are logged: the account works without it.
+func (a *Auth) sendVerification(r *http.Request, user User) {
+    token, err := a.links.Issue("verify", user.ID, verifyLinkTTL)
+    if err != nil {
+        logging.Error(r.Context(), "verification link", "err", err)
+        return
+    }
+    a.send(r, "verify", user.Email, linkData{Email: user.Email, URL: a.linkURL(r, "/verify?token="+token), ValidFor: "24 hours"})
+}
+
+func (a *Auth) send(r *http.Request, template, to string, data any) {
+    if a.mail == nil {
+        return
+    }
+    msg, err := mailer.Render(template, to, data)
+    if err == nil {
+        err = a.mail.Send(r.Context(), msg)
+    }
+    if err != nil {
+        logging.Error(r.Context(), "send email", "template", template, "err", err)
+    }
+}
+
+// linkURL is the absolute URL of path, under APP_URL when it is set and
+// otherwise the host the request came in on.
+func (a *Auth) linkURL(r *http.Request, path string) string {
+    if a.appURL != "" {
+        return a.appURL + views.Path(path)
+    }
+    scheme := "http"
+    if secure(r) {
+        scheme = "https"
+    }
+    return scheme + "://" + r.Host + views.Path(path)
+}
+
+func (a *Auth) verify(w http.ResponseWriter, r *http.Request) {
+    userID, ok := a.links.Consume("verify", r.URL.Query().Get("token"))
+    if !ok || a.users.MarkVerified(userID) != nil {
+        a.renderNotice(w, r, http.StatusBadRequest, "Email not confirmed", "This link is invalid or has expired.")
+        return
+    }
+    a.renderNotice(w, r, http.StatusOK, "Email confirmed", "Thanks, your email address is confirmed.")
+}
+
+func (a *Auth) forgotPage(w http.ResponseWriter, r *http.Request) {
+    theme, showToggle := handlers.Theme(r)
+    views.ForgotPasswordPage(theme, showToggle, views.AuthForm{}).Render(r.Context(), w)
+}
+
+// forgot emails a reset link when the address has an account. The answer
+// is the same either way, so the form doesn't reveal who is registered.
+func (a *Auth) forgot(w http.ResponseWriter, r *http.Request) {
+    form := formFrom(r)
+    if user, ok := a.users.Lookup(form.Email); ok {
+        token, err := a.links.Issue("reset", user.ID, resetLinkTTL)
+        if err != nil {
+            logging.Error(r.Context(), "reset link", "err", err)
+        } else {
+            a.send(r, "reset", user.Email, linkData{Email: user.Email, URL: a.linkURL(r, "/reset?token="+token), ValidFor: "1 hour"})
+        }
+    }
+    a.renderNotice(w, r, http.StatusOK, "Check your email", "If an account uses that address, we've sent it a link to reset the password.")
+}
+
+func (a *Auth) resetPage(w http.ResponseWriter, r *http.Request) {
+    token := r.URL.Query().Get("token")
+    if _, ok := a.links.Check("reset", token); !ok {
+        a.renderNotice(w, r, http.StatusBadRequest, "Reset your password", "This link is invalid or has expired.")
+        return
+    }
+    a.renderReset(w, r, http.StatusOK, views.AuthForm{Token: token})
+}
+
+// reset sets the new password. The link is only used up once the
+// password is accepted, so a too-short one can be corrected.
+func (a *Auth) reset(w http.ResponseWriter, r *http.Request) {
+    token := r.PostFormValue("token")
+    userID, ok := a.links.Check("reset", token)
+    if !ok {
+        a.renderNotice(w, r, http.StatusBadRequest, "Reset your password", "This link is invalid or has expired.")
+        return
+    }
+
+    err := a.users.SetPassword(userID, r.PostFormValue("password"))
+    if errors.Is(err, ErrWeakPassword) {
+        a.renderReset(w, r, http.StatusUnprocessableEntity, views.AuthForm{Token: token, Error: "Password must be 8 to 72 characters"})
+        return
+    }
+    if err != nil {
+        logging.Error(r.Context(), "reset password", "err", err)
+        http.Error(w, "Something went wrong", http.StatusInternalServerError)
+        return
+    }
+    a.links.Consume("reset", token)
+    http.Redirect(w, r, views.Path("/login"), http.StatusSeeOther)
+}
+
+func (a *Auth) renderReset(w http.ResponseWriter, r *http.Request, status int, form views.AuthForm) {
+    theme, showToggle := handlers.Theme(r)
+    w.WriteHeader(status)
+    views.ResetPasswordPage(theme, showToggle, form).Render(r.Context(), w)
+}
+
+func (a *Auth) renderNotice(w http.ResponseWriter, r *http.Request, status int, title, message string) {
+    theme, showToggle := handlers.Theme(r)
+    w.WriteHeader(status)
+    views.AuthNotice(theme, showToggle, title, message).Render(r.Context(), w)
+}
+
+type link struct {
+    purpose string
+    userID  string
+    expires time.Time
+}
+
+// links are the one-time tokens in emailed URLs, kept in memory like the
+// accounts they belong to.
+type links struct {
+    mu      sync.Mutex
+    byToken map[string]link
+    clk     clock.Clock
+}
+
+func newLinks(clk clock.Clock) *links {
+    return &links{byToken: make(map[string]link), clk: clk}
+}
+
+// Issue returns a new token for purpose ("verify" or "reset") that
+// identifies userID for ttl.
+func (l *links) Issue(purpose, userID string, ttl time.Duration) (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    token := hex.EncodeToString(b)
+
+    now := l.clk.Now()
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    for t, lk := range l.byToken {
+        if !now.Before(lk.expires) {
+            delete(l.byToken, t)
+        }
+    }
+    l.byToken[token] = link{purpose: purpose, userID: userID, expires: now.Add(ttl)}
+    return token, nil
+}
+
+// Check returns the user a live token for purpose identifies.
+func (l *links) Check(purpose, token string) (string, bool) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    return l.check(purpose, token)
+}
+
+// Consume is Check, then retires the token so it works only once.
+func (l *links) Consume(purpose, token string) (string, bool) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    userID, ok := l.check(purpose, token)
+    if ok {
+        delete(l.byToken, token)
+    }
+    return userID, ok
+}
+
+// check looks token up; callers hold l.mu.
+func (l *links) check(purpose, token string) (string, bool) {
+    lk, ok := l.byToken[token]
+    if !ok || lk.purpose != purpose || !l.clk.Now().Before(lk.expires) {
+        return "", false
+    }
+    return lk.userID, true
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+    "errors"
+    "net/mail"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "golang.org/x/crypto/bcrypt"
+)
+
+var (
+    ErrEmailTaken         = errors.New("auth: email already registered")
+    ErrInvalidEmail       = errors.New("auth: invalid email")
+    ErrWeakPassword       = errors.New("auth: password must be 8-72 bytes")
+    ErrInvalidCredentials = errors.New("auth: invalid email or password")
+    ErrUnknownUser        = errors.New("auth: no such user")
+)
+
+type User struct {
+    ID    string
+    Email string
+    // Set once the emailed verification link is followed (OAuth accounts
+    // start verified: the provider vouched for the address)
+    Verified bool
+
+    // bcrypt hash; empty for accounts created through OAuth
+    passwordHash []byte
+}
+
+// Users keeps accounts in memory, so they are lost on restart. Back it
+// with the store's database before relying on it in production.
+type Users struct {
+    mu      sync.RWMutex
+    byEmail map[string]*User
+    nextID  int
+}
+
+func NewUsers() *Users {
+    return &Users{byEmail: make(map[string]*User), nextID: 1}
+}
+
+// Compared against when the email is unknown so both paths cost one bcrypt
+// comparison and response timing doesn't reveal which accounts exist.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+
+func (u *Users) Register(email, password string) (User, error) {
+    email, err := normalizeEmail(email)
+    if err != nil {
+        return User{}, err
+    }
+    // bcrypt only looks at the first 72 bytes
+    if len(password) < 8 || len(password) > 72 {
+        return User{}, ErrWeakPassword
+    }
+    hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+    if err != nil {
+        return User{}, err
+    }
+
+    u.mu.Lock()
+    defer u.mu.Unlock()
+    if _, ok := u.byEmail[email]; ok {
+        return User{}, ErrEmailTaken
+    }
+    return u.add(email, hash), nil
+}
+
+func (u *Users) Authenticate(email, password string) (User, error) {
+    email, _ = normalizeEmail(email)
+
+    u.mu.RLock()
+    user, ok := u.byEmail[email]
+    u.mu.RUnlock()
+
+    hash := dummyHash
+    if ok && len(user.passwordHash) > 0 {
+        hash = user.passwordHash
+    }
+    if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || !ok || len(user.passwordHash) == 0 {
+        return User{}, ErrInvalidCredentials
+    }
+    return *user, nil
+}
+
+// FindOrCreate returns the account for a verified email, creating one
+// without a password if needed (used for OAuth sign-ins). An unverified
+// account for the address may have been registered by someone else, who
+// knows its password and may still be signed in to it, so it is replaced
+// by a new account rather than handed over.
+func (u *Users) FindOrCreate(email string) (User, error) {
+    email, err := normalizeEmail(email)
+    if err != nil {
+        return User{}, err
+    }
+
+    u.mu.Lock()
+    defer u.mu.Unlock()
+    if user, ok := u.byEmail[email]; ok && user.Verified {
+        return *user, nil
+    }
+    user := u.add(email, nil)
+    u.byEmail[email].Verified = true
+    user.Verified = true
+    return user, nil
+}
+
+// Lookup returns the account registered with email.
+func (u *Users) Lookup(email string) (User, bool) {
+    email, err := normalizeEmail(email)
+    if err != nil {
+        return User{}, false
+    }
+    u.mu.RLock()
+    defer u.mu.RUnlock()
+    user, ok := u.byEmail[email]
+    if !ok {
+        return User{}, false
+    }
+    return *user, true
+}
+
+// MarkVerified records that the account's email address is confirmed.
+func (u *Users) MarkVerified(id string) error {
+    return u.update(id, func(user *User) error {
+        user.Verified = true
+        return nil
+    })
+}
+
+// SetPassword replaces the account's password, as after a reset.
+func (u *Users) SetPassword(id, password string) error {
+    if len(password) < 8 || len(password) > 72 {
+        return ErrWeakPassword
+    }
+    hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+    if err != nil {
+        return err
+    }
+    return u.update(id, func(user *User) error {
+        user.passwordHash = hash
+        return nil
+    })
+}
+
+// update applies fn to the account with the given ID under the lock.
+func (u *Users) update(id string, fn func(*User) error) error {
+    u.mu.Lock()
+    defer u.mu.Unlock()
+    for _, user := range u.byEmail {
+        if user.ID == id {
+            return fn(user)
+        }
+    }
+    return ErrUnknownUser
+}
+
+// Get returns the account with the given ID.
+func (u *Users) Get(id string) (User, bool) {
+    u.mu.RLock()
+    defer u.mu.RUnlock()
+    for _, user := range u.byEmail {
+        if user.ID == id {
+            return *user, true
+        }
+    }
+    return User{}, false
+}
+
+// List returns every account in the order they were created.
+func (u *Users) List() []User {
+    u.mu.RLock()
+    list := make([]User, 0, len(u.byEmail))
+    for _, user := range u.byEmail {
+        list = append(list, *user)
+    }
+    u.mu.RUnlock()
+
+    sort.Slice(list, func(i, j int) bool {
+        a, _ := strconv.Atoi(list[i].ID)
+        b, _ := strconv.Atoi(list[j].ID)
+        return a < b
+    })
+    return list
+}
+
+// Delete removes an account. Credentials already issued to it stay valid
+// until they expire, but it can no longer sign in.
+func (u *Users) Delete(id string) error {
+    u.mu.Lock()
+    defer u.mu.Unlock()
+    for email, user := range u.byEmail {
+        if user.ID == id {
+            delete(u.byEmail, email)
+            return nil
+        }
+    }
+    return ErrUnknownUser
+}
+
+// add stores a new account; callers hold u.mu.
+func (u *Users) add(email string, hash []byte) User {
+    user := &User{ID: strconv.Itoa(u.nextID), Email: email, passwordHash: hash}
+    u.nextID++
+    u.byEmail[email] = user
+    return *user
+}
+
+func normalizeEmail(email string) (string, error) {
+    addr, err := mail.ParseAddress(strings.TrimSpace(email))
+    if err != nil || addr.Name != "" {
+        return "", ErrInvalidEmail
+    }
+    return strings.ToLower(addr.Address), nil
+}
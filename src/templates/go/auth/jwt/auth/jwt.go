@@ -0,0 +1,85 @@
+package auth
+
+import (
+    "errors"
+    "net/http"
+    "strings"
+    "time"
+    "github.com/golang-jwt/jwt/v5"
+    "myapp/clock"
+    "myapp/views"
+)
+
+// sessionCookie is also the cookie the response cache treats as private.
+const sessionCookie = "session"
+
+// jwtTokens signs the user ID into an HS256 JWT. Browsers carry it in an
+// HttpOnly cookie; API clients may send it as "Authorization: Bearer".
+// Tokens are stateless: signing out clears the cookie, but a copied token
+// stays valid until it expires, so keep AUTH_SESSION_TTL short.
+type jwtTokens struct {
+    secret []byte
+    ttl    time.Duration
+    clk    clock.Clock
+}
+
+func newJWTTokens(secret string, ttl time.Duration, clk clock.Clock) (*jwtTokens, error) {
+    if len(secret) < 32 {
+        return nil, errors.New("JWT_SECRET must be at least 32 characters")
+    }
+    return &jwtTokens{secret: []byte(secret), ttl: ttl, clk: clk}, nil
+}
+
+func (t *jwtTokens) Issue(w http.ResponseWriter, r *http.Request, userID string) error {
+    now := t.clk.Now()
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+        Subject:   userID,
+        IssuedAt:  jwt.NewNumericDate(now),
+        ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+    }).SignedString(t.secret)
+    if err != nil {
+        return err
+    }
+
+    http.SetCookie(w, &http.Cookie{
+        Name:     sessionCookie,
+        Value:    token,
+        Path:     views.Path("/"),
+        MaxAge:   int(t.ttl.Seconds()),
+        HttpOnly: true,
+        Secure:   secure(r),
+        SameSite: http.SameSiteLaxMode,
+    })
+    return nil
+}
+
+func (t *jwtTokens) User(r *http.Request) string {
+    raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+    if !ok {
+        c, err := r.Cookie(sessionCookie)
+        if err != nil {
+            return ""
+        }
+        raw = c.Value
+    }
+
+    var claims jwt.RegisteredClaims
+    _, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) {
+        return t.secret, nil
+    }, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(t.clk.Now), jwt.WithExpirationRequired())
+    if err != nil {
+        return ""
+    }
+    return claims.Subject
+}
+
+func (t *jwtTokens) Revoke(w http.ResponseWriter, r *http.Request) {
+    http.SetCookie(w, &http.Cookie{
+        Name:     sessionCookie,
+        Path:     views.Path("/"),
+        MaxAge:   -1,
+        HttpOnly: true,
+        Secure:   secure(r),
+        SameSite: http.SameSiteLaxMode,
+    })
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+    "myapp/clock"
+    "myapp/config"
+)
+
+// Open sets up email/password sign-in with JWTs signed by JWT_SECRET.
+func Open(cfg config.Auth, clk clock.Clock) (*Auth, error) {
+    tokens, err := newJWTTokens(cfg.JWTSecret, cfg.SessionTTL, clk)
+    if err != nil {
+        return nil, err
+    }
+    return newAuth(tokens, cfg, clk), nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+    "errors"
+    "net/http"
+    "strings"
+    "github.com/go-chi/chi/v5"
+    gsessions "github.com/gorilla/sessions"
+    "github.com/markbates/goth"
+    "github.com/markbates/goth/gothic"
+    "github.com/markbates/goth/providers/github"
+    "github.com/markbates/goth/providers/google"
+    "myapp/config"
+    "myapp/logging"
+    "myapp/views"
+)
+
+// useProviders registers the OAuth providers whose client ID is set and
+// returns their names. Callback URLs are APP_URL + /auth/{provider}/callback.
+func useProviders(cfg config.Auth) ([]string, error) {
+    appURL := cfg.AppURL
+    callback := func(provider string) string {
+        return appURL + views.Path("/auth/"+provider+"/callback")
+    }
+
+    var providers []goth.Provider
+    if cfg.GitHubClientID != "" {
+        providers = append(providers, github.New(cfg.GitHubClientID, cfg.GitHubClientSecret, callback("github"), "user:email"))
+    }
+    if cfg.GoogleClientID != "" {
+        providers = append(providers, google.New(cfg.GoogleClientID, cfg.GoogleClientSecret, callback("google"), "email"))
+    }
+    if len(providers) == 0 {
+        return nil, nil
+    }
+    if appURL == "" {
+        return nil, errors.New("APP_URL is required for OAuth callbacks")
+    }
+
+    // gothic keeps the OAuth state between redirect and callback in a
+    // signed cookie
+    secret := cfg.SessionSecret
+    if len(secret) < 32 {
+        return nil, errors.New("SESSION_SECRET must be at least 32 characters")
+    }
+    store := gsessions.NewCookieStore([]byte(secret))
+    store.Options.Path = views.Path("/")
+    store.Options.HttpOnly = true
+    store.Options.Secure = strings.HasPrefix(appURL, "https://")
+    store.Options.SameSite = http.SameSiteLaxMode
+    gothic.Store = store
+
+    goth.UseProviders(providers...)
+    names := make([]string, len(providers))
+    for i, p := range providers {
+        names[i] = p.Name()
+    }
+    return names, nil
+}
+
+func (a *Auth) oauthRoutes(r chi.Router) {
+    r.Get("/auth/{provider}", a.beginOAuth)
+    r.Get("/auth/{provider}/callback", a.completeOAuth)
+}
+
+func (a *Auth) beginOAuth(w http.ResponseWriter, r *http.Request) {
+    gothic.BeginAuthHandler(w, gothic.GetContextWithProvider(r, chi.URLParam(r, "provider")))
+}
+
+// completeOAuth signs in the account matching the provider's email,
+// creating it on first use.
+func (a *Auth) completeOAuth(w http.ResponseWriter, r *http.Request) {
+    r = gothic.GetContextWithProvider(r, chi.URLParam(r, "provider"))
+    external, err := gothic.CompleteUserAuth(w, r)
+    if err != nil || external.Email == "" {
+        logging.Warn(r.Context(), "oauth sign-in failed", "provider", chi.URLParam(r, "provider"), "err", err)
+        a.renderLogin(w, r, http.StatusUnauthorized, views.AuthForm{Error: "Sign-in with that provider failed"})
+        return
+    }
+
+    user, err := a.users.FindOrCreate(external.Email)
+    if err != nil {
+        a.renderLogin(w, r, http.StatusUnauthorized, views.AuthForm{Error: "Sign-in with that provider failed"})
+        return
+    }
+    a.signIn(w, r, user, "")
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+    "myapp/clock"
+    "myapp/config"
+)
+
+// Open sets up email/password sign-in with server-side sessions, plus
+// GitHub and/or Google OAuth when their client IDs are configured.
+func Open(cfg config.Auth, clk clock.Clock) (*Auth, error) {
+    providers, err := useProviders(cfg)
+    if err != nil {
+        return nil, err
+    }
+
+    a := newAuth(newSessions(cfg.SessionTTL, clk), cfg, clk)
+    a.providers = providers
+    a.extraRoutes = a.oauthRoutes
+    return a, nil
+}
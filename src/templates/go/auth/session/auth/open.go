@@ -0,0 +1,11 @@
+package auth
+
+import (
+    "myapp/clock"
+    "myapp/config"
+)
+
+// Open sets up email/password sign-in with server-side sessions.
+func Open(cfg config.Auth, clk clock.Clock) (*Auth, error) {
+    return newAuth(newSessions(cfg.SessionTTL, clk), cfg, clk), nil
+}
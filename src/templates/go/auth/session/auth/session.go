@@ -0,0 +1,117 @@
+package auth
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "net/http"
+    "sync"
+    "time"
+    "myapp/clock"
+    "myapp/views"
+)
+
+// sessionCookie is also the cookie the response cache treats as private.
+const sessionCookie = "session"
+
+type session struct {
+    userID  string
+    expires time.Time
+}
+
+// sessions keeps server-side sessions in memory, keyed by a random token
+// stored in an HttpOnly cookie. Signing out deletes the session, so a
+// copied cookie stops working immediately.
+type sessions struct {
+    mu      sync.Mutex
+    byToken map[string]session
+    ttl     time.Duration
+    clk     clock.Clock
+}
+
+func newSessions(ttl time.Duration, clk clock.Clock) *sessions {
+    return &sessions{byToken: make(map[string]session), ttl: ttl, clk: clk}
+}
+
+func (s *sessions) Issue(w http.ResponseWriter, r *http.Request, userID string) error {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return err
+    }
+    token := hex.EncodeToString(b)
+
+    // Drop the session the browser had before, if any
+    s.Revoke(w, r)
+
+    now := s.clk.Now()
+    s.mu.Lock()
+    s.purge(now)
+    s.byToken[token] = session{userID: userID, expires: now.Add(s.ttl)}
+    s.mu.Unlock()
+
+    http.SetCookie(w, &http.Cookie{
+        Name:     sessionCookie,
+        Value:    token,
+        Path:     views.Path("/"),
+        MaxAge:   int(s.ttl.Seconds()),
+        HttpOnly: true,
+        Secure:   secure(r),
+        SameSite: http.SameSiteLaxMode,
+    })
+    return nil
+}
+
+func (s *sessions) User(r *http.Request) string {
+    c, err := r.Cookie(sessionCookie)
+    if err != nil {
+        return ""
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    sess, ok := s.byToken[c.Value]
+    if !ok {
+        return ""
+    }
+    if !s.clk.Now().Before(sess.expires) {
+        delete(s.byToken, c.Value)
+        return ""
+    }
+    return sess.userID
+}
+
+func (s *sessions) Revoke(w http.ResponseWriter, r *http.Request) {
+    if c, err := r.Cookie(sessionCookie); err == nil {
+        s.mu.Lock()
+        delete(s.byToken, c.Value)
+        s.mu.Unlock()
+    }
+    http.SetCookie(w, &http.Cookie{
+        Name:     sessionCookie,
+        Path:     views.Path("/"),
+        MaxAge:   -1,
+        HttpOnly: true,
+        Secure:   secure(r),
+        SameSite: http.SameSiteLaxMode,
+    })
+}
+
+// PurgeExpired drops the sessions past their expiry, for the cleanup job,
+// and returns how many it removed.
+func (s *sessions) PurgeExpired(now time.Time) int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.purge(now)
+}
+
+// purge drops sessions past their expiry so ones never looked up again
+// don't accumulate; callers hold s.mu.
+func (s *sessions) purge(now time.Time) int {
+    n := 0
+    for token, sess := range s.byToken {
+        if !now.Before(sess.expires) {
+            delete(s.byToken, token)
+            n++
+        }
+    }
+    return n
+}
@@ -0,0 +1,29 @@
+package cache
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "github.com/redis/go-redis/v9"
+    "myapp/config"
+)
+
+// Open connects to Redis at CACHE_REDIS_URL and checks it answers, so a
+// wrong URL stops startup. Without CACHE_REDIS_URL (local development)
+// the cache stays in process.
+func Open(ctx context.Context, cfg config.Cache) (Cache, error) {
+    if cfg.RedisURL == "" {
+        log.Println("CACHE_REDIS_URL is not set; caching items in process")
+        return NewMemory(), nil
+    }
+    opts, err := redis.ParseURL(cfg.RedisURL)
+    if err != nil {
+        return nil, fmt.Errorf("CACHE_REDIS_URL: %w", err)
+    }
+    client := redis.NewClient(opts)
+    if err := client.Ping(ctx).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("redis: %w", err)
+    }
+    return NewRedis(client), nil
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+    "context"
+    "errors"
+    "time"
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis is a Cache shared by every replica, so an invalidation made
+// through one is seen by all.
+type Redis struct {
+    client *redis.Client
+}
+
+func NewRedis(client *redis.Client) *Redis {
+    return &Redis{client: client}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+    value, err := r.client.Get(ctx, key).Bytes()
+    if errors.Is(err, redis.Nil) {
+        return nil, false, nil
+    }
+    if err != nil {
+        return nil, false, err
+    }
+    return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+    return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *Redis) Ping(ctx context.Context) error {
+    return r.client.Ping(ctx).Err()
+}
+
+func (r *Redis) Close() error {
+    return r.client.Close()
+}
@@ -0,0 +1,96 @@
+package cleanup
+
+import (
+    "context"
+    "log"
+    "sync"
+    "time"
+    "myapp/clock"
+)
+
+// Task purges one kind of stale data (expired sessions, soft-deleted rows
+// past retention, expired cache entries) and reports how many it removed.
+type Task struct {
+    Name string
+    Run  func(ctx context.Context, now time.Time) (int, error)
+}
+
+// Job runs its tasks periodically in the background. Runs never overlap:
+// a tick that arrives while the previous run is still going is skipped.
+type Job struct {
+    interval time.Duration
+    clock    clock.Clock
+    tasks    []Task
+
+    running sync.Mutex
+    cancel  context.CancelFunc
+    done    chan struct{}
+}
+
+func NewJob(interval time.Duration, clk clock.Clock) *Job {
+    return &Job{interval: interval, clock: clk}
+}
+
+func (j *Job) Add(t Task) {
+    j.tasks = append(j.tasks, t)
+}
+
+// Start launches the ticker loop. It returns immediately.
+func (j *Job) Start(ctx context.Context) error {
+    ctx, j.cancel = context.WithCancel(context.WithoutCancel(ctx))
+    j.done = make(chan struct{})
+
+    go func() {
+        defer close(j.done)
+        ticker := time.NewTicker(j.interval)
+        defer ticker.Stop()
+
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+                j.RunOnce(ctx)
+            }
+        }
+    }()
+    return nil
+}
+
+// Stop cancels the loop and waits for an in-progress run to finish or for
+// ctx to expire, whichever comes first.
+func (j *Job) Stop(ctx context.Context) error {
+    if j.cancel == nil {
+        return nil
+    }
+    j.cancel()
+    select {
+    case <-j.done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// RunOnce executes every task now unless a run is already in progress.
+func (j *Job) RunOnce(ctx context.Context) {
+    if !j.running.TryLock() {
+        return
+    }
+    defer j.running.Unlock()
+
+    now := j.clock.Now()
+    for _, t := range j.tasks {
+        if ctx.Err() != nil {
+            return
+        }
+        n, err := t.Run(ctx, now)
+        if err != nil {
+            log.Printf("cleanup: %s: %v", t.Name, err)
+            continue
+        }
+        if n > 0 {
+            log.Printf("cleanup: %s removed %d", t.Name, n)
+        }
+    }
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL,
+    owner_id VARCHAR(255) NOT NULL DEFAULT '',
+    attachment_path VARCHAR(255) NOT NULL DEFAULT '',
+    attachment_filename VARCHAR(255) NOT NULL DEFAULT '',
+    attachment_content_type VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+DROP TABLE items;
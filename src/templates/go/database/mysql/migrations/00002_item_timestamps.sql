@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE items
+    ADD COLUMN created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    ADD COLUMN updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    ADD COLUMN deleted_at DATETIME(6) NULL,
+    ADD INDEX items_deleted_at_idx (deleted_at);
+
+-- +goose Down
+ALTER TABLE items
+    DROP INDEX items_deleted_at_idx,
+    DROP COLUMN deleted_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
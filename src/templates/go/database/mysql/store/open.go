@@ -0,0 +1,33 @@
+package store
+
+import (
+    "context"
+    "errors"
+    "strings"
+    _ "github.com/go-sql-driver/mysql"
+    "myapp/config"
+)
+
+var mysql = Dialect{
+    Placeholder: func(int) string { return "?" },
+    Returning:   false,
+    Goose:       "mysql",
+}
+
+// Open connects to MySQL using DATABASE_URL in go-sql-driver form, e.g.
+// app:secret@tcp(localhost:3306)/app. parseTime is turned on so the
+// timestamp columns scan into time.Time.
+func Open(ctx context.Context, cfg config.Database) (ItemStore, func() error, error) {
+    dsn := cfg.URL
+    if dsn == "" {
+        return nil, nil, errors.New("set DATABASE_URL to connect to MySQL")
+    }
+    if !strings.Contains(dsn, "parseTime=") {
+        sep := "?"
+        if strings.Contains(dsn, "?") {
+            sep = "&"
+        }
+        dsn += sep + "parseTime=true"
+    }
+    return openSQL(ctx, "mysql", dsn, mysql, cfg)
+}
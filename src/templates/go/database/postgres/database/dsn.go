@@ -0,0 +1,110 @@
+package database
+
+import (
+    "fmt"
+    "net"
+    "net/url"
+    "os"
+    "strings"
+)
+
+// sslmodes accepted by libpq-compatible drivers
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// BuildDSN returns the Postgres connection URL config.Load hands to
+// store.Open: DATABASE_URL when set, otherwise one assembled from the
+// discrete DB_* variables many platforms inject. getenv looks them up.
+// Connections default to sslmode=require; set DB_SSLMODE=disable for a
+// local database. Invalid TLS settings are reported as errors so the app
+// fails at startup instead of silently connecting in the clear.
+func BuildDSN(getenv func(string) string) (string, error) {
+    if dsn := getenv("DATABASE_URL"); dsn != "" {
+        u, err := url.Parse(dsn)
+        if err != nil {
+            return "", fmt.Errorf("database: invalid DATABASE_URL: %w", err)
+        }
+        q := u.Query()
+        if q.Get("sslmode") == "" {
+            q.Set("sslmode", "require")
+        }
+        if err := validateTLS(q); err != nil {
+            return "", err
+        }
+        u.RawQuery = q.Encode()
+        return u.String(), nil
+    }
+
+    host := getenv("DB_HOST")
+    if host == "" {
+        return "", nil
+    }
+
+    port := getenv("DB_PORT")
+    if port == "" {
+        port = "5432"
+    }
+
+    u := url.URL{
+        Scheme: "postgres",
+        Host:   net.JoinHostPort(host, port),
+        Path:   "/" + getenv("DB_NAME"),
+    }
+
+    if user := getenv("DB_USER"); user != "" {
+        if password := getenv("DB_PASSWORD"); password != "" {
+            u.User = url.UserPassword(user, password)
+        } else {
+            u.User = url.User(user)
+        }
+    }
+
+    q := url.Values{}
+    q.Set("sslmode", getenv("DB_SSLMODE"))
+    if q.Get("sslmode") == "" {
+        q.Set("sslmode", "require")
+    }
+    for param, env := range map[string]string{
+        "sslrootcert": "DB_SSLROOTCERT",
+        "sslcert":     "DB_SSLCERT",
+        "sslkey":      "DB_SSLKEY",
+    } {
+        if v := getenv(env); v != "" {
+            q.Set(param, v)
+        }
+    }
+    if err := validateTLS(q); err != nil {
+        return "", err
+    }
+    u.RawQuery = q.Encode()
+
+    return u.String(), nil
+}
+
+func validateTLS(q url.Values) error {
+    mode := q.Get("sslmode")
+    known := false
+    for _, m := range sslModes {
+        if m == mode {
+            known = true
+        }
+    }
+    if !known {
+        return fmt.Errorf("database: invalid sslmode %q (want one of %s)", mode, strings.Join(sslModes, ", "))
+    }
+
+    if (mode == "verify-ca" || mode == "verify-full") && q.Get("sslrootcert") == "" {
+        return fmt.Errorf("database: sslmode=%s requires DB_SSLROOTCERT", mode)
+    }
+    if (q.Get("sslcert") == "") != (q.Get("sslkey") == "") {
+        return fmt.Errorf("database: DB_SSLCERT and DB_SSLKEY must be set together")
+    }
+
+    for _, param := range []string{"sslrootcert", "sslcert", "sslkey"} {
+        if path := q.Get(param); path != "" {
+            if _, err := os.Stat(path); err != nil {
+                return fmt.Errorf("database: %s: %w", param, err)
+            }
+        }
+    }
+    return nil
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE items
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX items_deleted_at_idx ON items (deleted_at);
+
+-- +goose Down
+DROP INDEX items_deleted_at_idx;
+ALTER TABLE items
+    DROP COLUMN deleted_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
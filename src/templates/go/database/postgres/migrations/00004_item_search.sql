@@ -0,0 +1,11 @@
+-- +goose Up
+-- Title words weigh more than description words when ranking searches
+ALTER TABLE items ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX items_search_idx ON items USING GIN (search);
+
+-- +goose Down
+DROP INDEX items_search_idx;
+ALTER TABLE items DROP COLUMN search;
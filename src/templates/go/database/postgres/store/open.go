@@ -0,0 +1,25 @@
+package store
+
+import (
+    "context"
+    "errors"
+    "strconv"
+    _ "github.com/jackc/pgx/v5/stdlib"
+    "myapp/config"
+)
+
+var postgres = Dialect{
+    Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+    Returning:   true,
+    Goose:       "postgres",
+    FullText:    true,
+}
+
+// Open connects to Postgres (via pgx) using the URL config.Load built
+// from DATABASE_URL or the DB_* variables.
+func Open(ctx context.Context, cfg config.Database) (ItemStore, func() error, error) {
+    if cfg.URL == "" {
+        return nil, nil, errors.New("set DATABASE_URL or DB_HOST to connect to Postgres")
+    }
+    return openSQL(ctx, "pgx", cfg.URL, postgres, cfg)
+}
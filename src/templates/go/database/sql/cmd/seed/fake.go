@@ -0,0 +1,19 @@
+package main
+
+import (
+    "github.com/brianvoe/gofakeit/v6"
+    "myapp/models"
+)
+
+// fakeItems returns n items with product-like titles. About one in four
+// has no description, as real data would.
+func fakeItems(f *gofakeit.Faker, n int) []models.Item {
+    items := make([]models.Item, n)
+    for i := range items {
+        items[i].Title = f.ProductName()
+        if f.Number(1, 4) > 1 {
+            items[i].Description = f.ProductDescription()
+        }
+    }
+    return items
+}
@@ -0,0 +1,55 @@
+// Command seed fills the database with fake items, so list, search and
+// paging have something to show. It reads the app's settings (.env and
+// the environment) and writes through the app's store:
+//
+//  go run ./cmd/seed -count 200
+//  go run ./cmd/seed -truncate -count 20 -seed 42
+package main
+
+import (
+    "context"
+    "flag"
+    "log"
+    "github.com/brianvoe/gofakeit/v6"
+    "myapp/config"
+    "myapp/store"
+)
+
+func main() {
+    count := flag.Int("count", 50, "number of items to create")
+    truncate := flag.Bool("truncate", false, "remove every existing item first")
+    seed := flag.Int64("seed", 0, "random seed, for the same items on every run (0 picks one)")
+    flag.Parse()
+
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    if err := run(context.Background(), cfg.Database, *count, *truncate, *seed); err != nil {
+        log.Fatalf("seed: %v", err)
+    }
+}
+
+func run(ctx context.Context, cfg config.Database, count int, truncate bool, seed int64) error {
+    items, closeStore, err := store.Open(ctx, cfg)
+    if err != nil {
+        return err
+    }
+    defer closeStore()
+
+    if truncate {
+        removed, err := items.(*store.SQLStore).Truncate(ctx)
+        if err != nil {
+            return err
+        }
+        log.Printf("🧹 Removed %d items", removed)
+    }
+
+    for _, item := range fakeItems(gofakeit.New(seed), count) {
+        if _, err := items.Create(ctx, item); err != nil {
+            return err
+        }
+    }
+    log.Printf("🌱 Seeded %d items", count)
+    return nil
+}
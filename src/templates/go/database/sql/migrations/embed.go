@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS holds the goose migrations for the chosen database, applied in
+// filename order. Add new ones with `goose -dir migrations create name sql`.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,19 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pressly/goose/v3"
+    "myapp/migrations"
+)
+
+// migrate applies any pending embedded migrations. openSQL skips it when
+// RUN_MIGRATIONS=false, e.g. when a deploy step runs `make migrate-up`
+// once instead of every replica racing to do it.
+func migrate(ctx context.Context, db *sql.DB, dialect string) error {
+    goose.SetBaseFS(migrations.FS)
+    if err := goose.SetDialect(dialect); err != nil {
+        return err
+    }
+    return goose.UpContext(ctx, db, ".")
+}
@@ -0,0 +1,374 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "strconv"
+    "strings"
+    "time"
+    "myapp/config"
+    "myapp/models"
+)
+
+// Dialect covers the few places where the SQL backends differ.
+type Dialect struct {
+    // Placeholder returns the n-th (1-based) bind parameter
+    Placeholder func(n int) string
+    // Returning is true when INSERT ... RETURNING id is supported
+    Returning bool
+    // Goose is the dialect name goose uses for migrations
+    Goose string
+    // FullText is true when items has the weighted search tsvector column
+    // (Postgres); searches then use it and its GIN index instead of LIKE
+    FullText bool
+}
+
+// SQLStore persists items through database/sql.
+type SQLStore struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx
+type querier interface {
+    ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+    QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+    QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+const itemColumns = "id, title, description, owner_id, attachment_path, attachment_filename, attachment_content_type, created_at, updated_at, deleted_at, version"
+
+// live keeps rows that have not been soft-deleted
+const live = "deleted_at IS NULL"
+
+// openSQL connects, checks the connection and applies migrations.
+func openSQL(ctx context.Context, driver, dsn string, d Dialect, cfg config.Database) (ItemStore, func() error, error) {
+    db, err := sql.Open(driver, dsn)
+    if err != nil {
+        return nil, nil, err
+    }
+    if err := db.PingContext(ctx); err != nil {
+        db.Close()
+        return nil, nil, err
+    }
+    if cfg.RunMigrations {
+        if err := migrate(ctx, db, d.Goose); err != nil {
+            db.Close()
+            return nil, nil, err
+        }
+    }
+    return &SQLStore{db: db, dialect: d}, db.Close, nil
+}
+
+// Ping checks the connection, for the readiness probe.
+func (s *SQLStore) Ping(ctx context.Context) error {
+    return s.db.PingContext(ctx)
+}
+
+// bind rewrites ? placeholders for the dialect.
+func (s *SQLStore) bind(query string) string {
+    var b strings.Builder
+    n := 0
+    for _, r := range query {
+        if r == '?' {
+            n++
+            b.WriteString(s.dialect.Placeholder(n))
+            continue
+        }
+        b.WriteRune(r)
+    }
+    return b.String()
+}
+
+func (s *SQLStore) List(ctx context.Context) ([]models.Item, error) {
+    rows, err := s.db.QueryContext(ctx, "SELECT "+itemColumns+" FROM items WHERE "+live+" ORDER BY id")
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    var items []models.Item
+    for rows.Next() {
+        item, err := scanItem(rows)
+        if err != nil {
+            return nil, err
+        }
+        items = append(items, item)
+    }
+    return items, rows.Err()
+}
+
+// Find filters with LIKE on the lower-cased title and description, or, on
+// FullText dialects, matches each term as a word prefix against the search
+// column. Terms are letters and digits only (ListQuery.Terms), so they need
+// no escaping.
+func (s *SQLStore) Find(ctx context.Context, q ListQuery) (Page, error) {
+    var (
+        where []string
+        args  []any
+    )
+    if !q.IncludeDeleted {
+        where = append(where, live)
+    }
+    terms := q.Terms()
+    fullText := s.dialect.FullText && len(terms) > 0
+    if fullText {
+        where = append(where, "search @@ to_tsquery('simple', ?)")
+        args = append(args, tsquery(terms))
+    } else {
+        for _, term := range terms {
+            where = append(where, "(LOWER(title) LIKE ? OR LOWER(description) LIKE ?)")
+            pattern := "%" + term + "%"
+            args = append(args, pattern, pattern)
+        }
+    }
+    filter := ""
+    if len(where) > 0 {
+        filter = " WHERE " + strings.Join(where, " AND ")
+    }
+
+    var page Page
+    if err := s.db.QueryRowContext(ctx, s.bind("SELECT COUNT(*) FROM items"+filter), args...).Scan(&page.Total); err != nil {
+        return Page{}, err
+    }
+
+    // The sort field comes from SortFields, never straight from the request
+    field, desc := q.order()
+    dir := ""
+    if desc {
+        dir = " DESC"
+    }
+    order := " ORDER BY id" + dir
+    switch field {
+    case "title":
+        order = " ORDER BY LOWER(title)" + dir + ", id" + dir
+    case SortRelevance:
+        if fullText {
+            // The title is weighted A and the description B, so ts_rank
+            // puts title matches first
+            order = " ORDER BY ts_rank(search, to_tsquery('simple', ?)) DESC, id"
+            args = append(args, tsquery(terms))
+            break
+        }
+        // Terms found in the title weigh double those in the description;
+        // unlike the memory store, each term counts once per field
+        var score []string
+        for _, term := range terms {
+            score = append(score,
+                "CASE WHEN LOWER(title) LIKE ? THEN "+strconv.Itoa(titleWeight)+" ELSE 0 END",
+                "CASE WHEN LOWER(description) LIKE ? THEN "+strconv.Itoa(descriptionWeight)+" ELSE 0 END")
+            pattern := "%" + term + "%"
+            args = append(args, pattern, pattern)
+        }
+        order = " ORDER BY " + strings.Join(score, " + ") + " DESC, id"
+    }
+
+    query := "SELECT " + itemColumns + " FROM items" + filter + order
+    if q.Limit > 0 {
+        query += " LIMIT ? OFFSET ?"
+        args = append(args, q.Limit, max(q.Offset, 0))
+    }
+    rows, err := s.db.QueryContext(ctx, s.bind(query), args...)
+    if err != nil {
+        return Page{}, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        item, err := scanItem(rows)
+        if err != nil {
+            return Page{}, err
+        }
+        page.Items = append(page.Items, item)
+    }
+    return page, rows.Err()
+}
+
+// tsquery matches every term as a word prefix, as LIKE would match
+// anywhere in the text. Terms hold only letters and digits, so they need no
+// quoting.
+func tsquery(terms []string) string {
+    lexemes := make([]string, len(terms))
+    for i, term := range terms {
+        lexemes[i] = term + ":*"
+    }
+    return strings.Join(lexemes, " & ")
+}
+
+func (s *SQLStore) Get(ctx context.Context, id string) (models.Item, error) {
+    return s.get(ctx, s.db, id)
+}
+
+func (s *SQLStore) get(ctx context.Context, q querier, id string) (models.Item, error) {
+    key, err := strconv.ParseInt(id, 10, 64)
+    if err != nil {
+        return models.Item{}, ErrNotFound
+    }
+    item, err := scanItem(q.QueryRowContext(ctx, s.bind("SELECT "+itemColumns+" FROM items WHERE id = ? AND "+live), key))
+    if errors.Is(err, sql.ErrNoRows) {
+        return models.Item{}, ErrNotFound
+    }
+    return item, err
+}
+
+func (s *SQLStore) Create(ctx context.Context, item models.Item) (models.Item, error) {
+    item.CreatedAt = now()
+    item.UpdatedAt = item.CreatedAt
+    item.DeletedAt = nil
+    item.Version = 1
+    query := "INSERT INTO items (title, description, owner_id, attachment_path, attachment_filename, attachment_content_type, created_at, updated_at, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)"
+    args := append([]any{item.Title, item.Description, item.OwnerID}, attachmentArgs(item)...)
+    args = append(args, item.CreatedAt, item.UpdatedAt)
+
+    var id int64
+    if s.dialect.Returning {
+        if err := s.db.QueryRowContext(ctx, s.bind(query+" RETURNING id"), args...).Scan(&id); err != nil {
+            return models.Item{}, err
+        }
+    } else {
+        res, err := s.db.ExecContext(ctx, s.bind(query), args...)
+        if err != nil {
+            return models.Item{}, err
+        }
+        if id, err = res.LastInsertId(); err != nil {
+            return models.Item{}, err
+        }
+    }
+    item.ID = strconv.FormatInt(id, 10)
+    return item, nil
+}
+
+func (s *SQLStore) Update(ctx context.Context, item models.Item) (models.Item, error) {
+    return s.update(ctx, s.db, item)
+}
+
+func (s *SQLStore) update(ctx context.Context, q querier, item models.Item) (models.Item, error) {
+    key, err := strconv.ParseInt(item.ID, 10, 64)
+    if err != nil {
+        return models.Item{}, ErrNotFound
+    }
+    // created_at and deleted_at are never written here, so the returned
+    // item keeps the ones it was read with
+    item.UpdatedAt = now()
+    args := append([]any{item.Title, item.Description, item.OwnerID}, attachmentArgs(item)...)
+    res, err := q.ExecContext(ctx, s.bind(
+        "UPDATE items SET title = ?, description = ?, owner_id = ?, attachment_path = ?, attachment_filename = ?, attachment_content_type = ?, updated_at = ?, version = version + 1 WHERE id = ? AND version = ? AND "+live),
+        append(args, item.UpdatedAt, key, item.Version)...)
+    if err != nil {
+        return models.Item{}, err
+    }
+    if err := requireRow(res); err != nil {
+        // No row matched: the item is gone, or it is still there at a
+        // newer version than the caller read
+        if _, getErr := s.get(ctx, q, item.ID); getErr != nil {
+            return models.Item{}, getErr
+        }
+        return models.Item{}, ErrConflict
+    }
+    item.Version++
+    return item, nil
+}
+
+// Delete stamps deleted_at; the row is kept for Find with IncludeDeleted.
+func (s *SQLStore) Delete(ctx context.Context, id string) error {
+    key, err := strconv.ParseInt(id, 10, 64)
+    if err != nil {
+        return ErrNotFound
+    }
+    deleted := now()
+    res, err := s.db.ExecContext(ctx, s.bind("UPDATE items SET deleted_at = ?, updated_at = ?, version = version + 1 WHERE id = ? AND "+live), deleted, deleted, key)
+    if err != nil {
+        return err
+    }
+    return requireRow(res)
+}
+
+// PurgeDeleted removes the items soft-deleted before cutoff for good.
+func (s *SQLStore) PurgeDeleted(ctx context.Context, cutoff time.Time) (int, error) {
+    res, err := s.db.ExecContext(ctx, s.bind("DELETE FROM items WHERE deleted_at IS NOT NULL AND deleted_at < ?"), cutoff.UTC())
+    if err != nil {
+        return 0, err
+    }
+    n, err := res.RowsAffected()
+    return int(n), err
+}
+
+// Truncate removes every item, soft-deleted ones included, and returns
+// how many rows were removed. Only cmd/seed uses it.
+func (s *SQLStore) Truncate(ctx context.Context) (int64, error) {
+    res, err := s.db.ExecContext(ctx, "DELETE FROM items")
+    if err != nil {
+        return 0, err
+    }
+    return res.RowsAffected()
+}
+
+// UpdateMany applies fn to every listed item in one transaction.
+func (s *SQLStore) UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) ([]models.Item, error) {
+    tx, err := s.db.BeginTx(ctx, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer tx.Rollback()
+
+    updated := make([]models.Item, 0, len(ids))
+    for _, id := range ids {
+        item, err := s.get(ctx, tx, id)
+        if err != nil {
+            return nil, err
+        }
+        fn(&item)
+        if item, err = s.update(ctx, tx, item); err != nil {
+            return nil, err
+        }
+        updated = append(updated, item)
+    }
+    return updated, tx.Commit()
+}
+
+type scanner interface {
+    Scan(dest ...any) error
+}
+
+func scanItem(row scanner) (models.Item, error) {
+    var (
+        item    models.Item
+        id      int64
+        att     models.Attachment
+        deleted sql.NullTime
+    )
+    if err := row.Scan(&id, &item.Title, &item.Description, &item.OwnerID, &att.Path, &att.Filename, &att.ContentType,
+        &item.CreatedAt, &item.UpdatedAt, &deleted, &item.Version); err != nil {
+        return models.Item{}, err
+    }
+    item.ID = strconv.FormatInt(id, 10)
+    if att.Path != "" {
+        item.Attachment = &att
+    }
+    item.CreatedAt = item.CreatedAt.UTC()
+    item.UpdatedAt = item.UpdatedAt.UTC()
+    if deleted.Valid {
+        t := deleted.Time.UTC()
+        item.DeletedAt = &t
+    }
+    return item, nil
+}
+
+func attachmentArgs(item models.Item) []any {
+    if item.Attachment == nil {
+        return []any{"", "", ""}
+    }
+    return []any{item.Attachment.Path, item.Attachment.Filename, item.Attachment.ContentType}
+}
+
+func requireRow(res sql.Result) error {
+    n, err := res.RowsAffected()
+    if err != nil {
+        return err
+    }
+    if n == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
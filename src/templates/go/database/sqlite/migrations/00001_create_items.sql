@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id TEXT NOT NULL DEFAULT '',
+    attachment_path TEXT NOT NULL DEFAULT '',
+    attachment_filename TEXT NOT NULL DEFAULT '',
+    attachment_content_type TEXT NOT NULL DEFAULT ''
+);
+
+-- +goose Down
+DROP TABLE items;
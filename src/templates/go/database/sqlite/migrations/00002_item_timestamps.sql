@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQLite can't add a column defaulting to CURRENT_TIMESTAMP, so existing
+-- rows are stamped with the time of the migration instead
+ALTER TABLE items ADD COLUMN created_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE items ADD COLUMN updated_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE items ADD COLUMN deleted_at DATETIME;
+UPDATE items SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP;
+CREATE INDEX items_deleted_at_idx ON items (deleted_at);
+
+-- +goose Down
+DROP INDEX items_deleted_at_idx;
+ALTER TABLE items DROP COLUMN deleted_at;
+ALTER TABLE items DROP COLUMN updated_at;
+ALTER TABLE items DROP COLUMN created_at;
@@ -0,0 +1,30 @@
+package store
+
+import (
+    "context"
+    "strings"
+    _ "modernc.org/sqlite"
+    "myapp/config"
+)
+
+var sqlite = Dialect{
+    Placeholder: func(int) string { return "?" },
+    Returning:   true,
+    Goose:       "sqlite3",
+}
+
+// Open uses the pure-Go SQLite driver, so builds stay CGO-free. The
+// database file is DATABASE_URL, or app.db in the working directory.
+func Open(ctx context.Context, cfg config.Database) (ItemStore, func() error, error) {
+    dsn := cfg.URL
+    if dsn == "" {
+        dsn = "file:app.db"
+    }
+    // Wait on a locked database instead of failing concurrent writes
+    sep := "?"
+    if strings.Contains(dsn, "?") {
+        sep = "&"
+    }
+    dsn += sep + "_pragma=busy_timeout(5000)"
+    return openSQL(ctx, "sqlite", dsn, sqlite, cfg)
+}
@@ -0,0 +1,75 @@
+import { expect, test, type Locator, type Page } from '@playwright/test'
+
+// Each test adds its own item with a unique title and finds it through
+// the search box, so the tests can run in parallel and against a database
+// that already holds items.
+function uniqueTitle(prefix: string): string {
+  return `${prefix} ${Date.now()}-${Math.floor(Math.random() * 1000)}`
+}
+
+// home opens the home page once htmx has loaded the item list
+async function home(page: Page): Promise<void> {
+  await page.goto('/')
+  await expect(page.locator('#items')).not.toContainText('Loading...')
+}
+
+async function addItem(page: Page, title: string, description = ''): Promise<Locator> {
+  await home(page)
+  const form = page.locator('#new-item')
+  await form.getByPlaceholder('Title').fill(title)
+  await form.getByPlaceholder('Description').fill(description)
+  await form.getByRole('button', { name: 'Add Item' }).click()
+
+  // The app redirects to the list once the item is stored
+  await page.waitForURL('**/items')
+  return findItem(page, title)
+}
+
+// findItem searches for title from the home page and returns its row,
+// located by id (item-<id>) so it survives the swaps between view and edit
+async function findItem(page: Page, title: string): Promise<Locator> {
+  await home(page)
+  const search = page.getByPlaceholder('Search items...')
+  await search.fill(title)
+  await search.press('Enter')
+
+  const row = page.locator('#items .item', { hasText: title })
+  await expect(row).toHaveCount(1)
+  return page.locator(`#${await row.getAttribute('id')}`)
+}
+
+test('creates an item', async ({ page }) => {
+  const title = uniqueTitle('Created')
+  const item = await addItem(page, title, 'Added from the browser')
+
+  await expect(item.locator('h3')).toHaveText(title)
+  await expect(item).toContainText('Added from the browser')
+})
+
+test('edits an item', async ({ page }) => {
+  const item = await addItem(page, uniqueTitle('Edited'))
+  const renamed = uniqueTitle('Renamed')
+
+  await item.getByRole('button', { name: 'Edit' }).click()
+  await item.locator('input[name="title"]').fill(renamed)
+  await item.getByRole('button', { name: 'Update Item' }).click()
+  await expect(item.locator('h3')).toHaveText(renamed)
+
+  // The change was stored, not just swapped into the page
+  await findItem(page, renamed)
+})
+
+test('deletes an item', async ({ page }) => {
+  const title = uniqueTitle('Deleted')
+  const item = await addItem(page, title)
+
+  page.once('dialog', dialog => dialog.accept())
+  await item.getByRole('button', { name: 'Delete' }).click()
+  await expect(item).toHaveCount(0)
+
+  await home(page)
+  const search = page.getByPlaceholder('Search items...')
+  await search.fill(title)
+  await search.press('Enter')
+  await expect(page.locator('#items')).toContainText('No matching items')
+})
@@ -0,0 +1,21 @@
+package flags
+
+import (
+    "github.com/open-feature/go-sdk/openfeature"
+    "myapp/config"
+)
+
+// Open returns the flag provider: the OpenFeature SDK, backed by
+// FEATURE_FLAGS until a vendor provider is registered here instead.
+func Open(cfg config.Flags) (Provider, error) {
+    env, err := ParseEnv(cfg.Rules)
+    if err != nil {
+        return nil, err
+    }
+    // Swap envProvider for your vendor's OpenFeature provider (flagd,
+    // LaunchDarkly, Unleash, ...); handlers and views don't change.
+    if err := openfeature.SetProviderAndWait(envProvider{env}); err != nil {
+        return nil, err
+    }
+    return NewOpenFeature("app"), nil
+}
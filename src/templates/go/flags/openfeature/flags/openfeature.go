@@ -0,0 +1,76 @@
+package flags
+
+import (
+    "context"
+    "github.com/open-feature/go-sdk/openfeature"
+)
+
+// OpenFeature evaluates flags with an OpenFeature client, so the answer
+// comes from whichever provider is registered with the SDK.
+type OpenFeature struct {
+    client *openfeature.Client
+}
+
+func NewOpenFeature(domain string) *OpenFeature {
+    return &OpenFeature{client: openfeature.NewClient(domain)}
+}
+
+// Boolean implements Provider. Evaluation errors return def; the SDK
+// reports them to its hooks.
+func (o *OpenFeature) Boolean(ctx context.Context, key string, def bool, target Target) bool {
+    evalCtx := openfeature.NewEvaluationContext(target.Key, map[string]any{"tenant": target.Tenant})
+    on, _ := o.client.BooleanValue(ctx, key, def, evalCtx)
+    return on
+}
+
+// envProvider serves FEATURE_FLAGS as an OpenFeature provider. It only
+// holds boolean flags; other types resolve to a type mismatch.
+type envProvider struct {
+    env *Env
+}
+
+func (p envProvider) Metadata() openfeature.Metadata {
+    return openfeature.Metadata{Name: "FEATURE_FLAGS"}
+}
+
+func (p envProvider) Hooks() []openfeature.Hook {
+    return nil
+}
+
+func (p envProvider) BooleanEvaluation(ctx context.Context, flag string, def bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+    var target Target
+    target.Key, _ = evalCtx[openfeature.TargetingKey].(string)
+    target.Tenant, _ = evalCtx["tenant"].(string)
+
+    reason := openfeature.DefaultReason
+    if _, ok := p.env.rules[flag]; ok {
+        reason = openfeature.TargetingMatchReason
+    }
+    return openfeature.BoolResolutionDetail{
+        Value:                    p.env.Boolean(ctx, flag, def, target),
+        ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: reason},
+    }
+}
+
+func (p envProvider) StringEvaluation(ctx context.Context, flag string, def string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+    return openfeature.StringResolutionDetail{Value: def, ProviderResolutionDetail: typeMismatch()}
+}
+
+func (p envProvider) FloatEvaluation(ctx context.Context, flag string, def float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+    return openfeature.FloatResolutionDetail{Value: def, ProviderResolutionDetail: typeMismatch()}
+}
+
+func (p envProvider) IntEvaluation(ctx context.Context, flag string, def int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+    return openfeature.IntResolutionDetail{Value: def, ProviderResolutionDetail: typeMismatch()}
+}
+
+func (p envProvider) ObjectEvaluation(ctx context.Context, flag string, def any, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+    return openfeature.InterfaceResolutionDetail{Value: def, ProviderResolutionDetail: typeMismatch()}
+}
+
+func typeMismatch() openfeature.ProviderResolutionDetail {
+    return openfeature.ProviderResolutionDetail{
+        ResolutionError: openfeature.NewTypeMismatchResolutionError("FEATURE_FLAGS only holds boolean flags"),
+        Reason:          openfeature.ErrorReason,
+    }
+}
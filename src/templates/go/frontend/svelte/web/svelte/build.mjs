@@ -0,0 +1,27 @@
+// Bundles the Svelte islands (web/svelte/main.js) into static/islands.js:
+// npm run build:svelte once, or with --watch (npm run watch:svelte) on
+// every change. Component styles are injected by the bundle itself.
+import * as esbuild from 'esbuild';
+import sveltePlugin from 'esbuild-svelte';
+
+const watch = process.argv.includes('--watch');
+
+const options = {
+  entryPoints: ['web/svelte/main.js'],
+  bundle: true,
+  format: 'iife',
+  minify: !watch,
+  sourcemap: true,
+  outfile: 'static/islands.js',
+  mainFields: ['svelte', 'browser', 'module', 'main'],
+  conditions: ['svelte', 'browser'],
+  plugins: [sveltePlugin({ compilerOptions: { css: 'injected' } })],
+  logLevel: 'info'
+};
+
+if (watch) {
+  const context = await esbuild.context(options);
+  await context.watch();
+} else {
+  await esbuild.build(options);
+}
@@ -0,0 +1,27 @@
+// Mounts a Svelte component on every <div data-island="Name"
+// data-props="{...}"> (see views/frontend.templ), including those in
+// content htmx swaps in later. Register new components in islands.
+import { mount } from 'svelte';
+import ItemStats from './ItemStats.svelte';
+
+const islands = { ItemStats };
+
+function mountIslands(root) {
+  const targets = [...root.querySelectorAll('[data-island]')];
+  if (root.matches && root.matches('[data-island]')) targets.unshift(root);
+
+  for (const target of targets) {
+    if (target.dataset.mounted) continue;
+    const component = islands[target.dataset.island];
+    if (!component) {
+      console.warn(`No Svelte island named "${target.dataset.island}"`);
+      continue;
+    }
+    target.dataset.mounted = 'true';
+    mount(component, { target, props: JSON.parse(target.dataset.props || '{}') });
+  }
+}
+
+// The script is deferred, so the page is parsed by now
+mountIslands(document);
+document.addEventListener('htmx:load', event => mountIslands(event.detail.elt));
@@ -0,0 +1,156 @@
+import { useCallback, useEffect, useState, type FormEvent } from 'react'
+import { ApiError, createItem, deleteItem, listItems, updateItem, type Item, type ItemList } from './api/client'
+
+const perPage = 10
+
+function App() {
+  const [query, setQuery] = useState('')
+  const [page, setPage] = useState(1)
+  const [list, setList] = useState<ItemList | null>(null)
+  const [error, setError] = useState('')
+
+  const load = useCallback(async () => {
+    try {
+      setList(await listItems({ q: query, page, per_page: perPage }))
+      setError('')
+    } catch (err) {
+      setError(err instanceof Error ? err.message : String(err))
+    }
+  }, [query, page])
+
+  useEffect(() => {
+    load()
+  }, [load])
+
+  const pages = list ? Math.max(1, Math.ceil(list.total / perPage)) : 1
+
+  return (
+    <main>
+      <h1>Items</h1>
+      <NewItem onCreated={load} />
+
+      <input
+        type="search"
+        placeholder="Search"
+        value={query}
+        onChange={(e) => {
+          setQuery(e.target.value)
+          setPage(1)
+        }}
+      />
+      {error && <p className="error">{error}</p>}
+
+      {list && list.count === 0 && <p className="empty">No items yet.</p>}
+      <ul className="items">
+        {list?.data.map((item) => (
+          <ItemRow key={item.id} item={item} onChanged={load} />
+        ))}
+      </ul>
+
+      {pages > 1 && (
+        <nav className="pager">
+          <button disabled={page <= 1} onClick={() => setPage(page - 1)}>
+            Previous
+          </button>
+          <span>
+            Page {page} of {pages}
+          </span>
+          <button disabled={page >= pages} onClick={() => setPage(page + 1)}>
+            Next
+          </button>
+        </nav>
+      )}
+    </main>
+  )
+}
+
+function NewItem({ onCreated }: { onCreated: () => void }) {
+  const [title, setTitle] = useState('')
+  const [description, setDescription] = useState('')
+  const [errors, setErrors] = useState<Record<string, string>>({})
+
+  async function submit(e: FormEvent) {
+    e.preventDefault()
+    try {
+      await createItem({ title, description })
+      setTitle('')
+      setDescription('')
+      setErrors({})
+      onCreated()
+    } catch (err) {
+      setErrors(err instanceof ApiError ? err.fieldErrors : { title: String(err) })
+    }
+  }
+
+  return (
+    <form className="new-item" onSubmit={submit}>
+      <label>
+        Title
+        <input value={title} onChange={(e) => setTitle(e.target.value)} />
+        {errors.title && <span className="error">{errors.title}</span>}
+      </label>
+      <label>
+        Description
+        <textarea value={description} onChange={(e) => setDescription(e.target.value)} />
+        {errors.description && <span className="error">{errors.description}</span>}
+      </label>
+      <button type="submit">Add item</button>
+    </form>
+  )
+}
+
+function ItemRow({ item, onChanged }: { item: Item; onChanged: () => void }) {
+  const [editing, setEditing] = useState(false)
+  const [title, setTitle] = useState(item.title)
+  const [error, setError] = useState('')
+
+  async function save(e: FormEvent) {
+    e.preventDefault()
+    try {
+      // Sending the version read makes a save over someone else's a 409
+      await updateItem(item.id, { title, version: item.version })
+      setEditing(false)
+      setError('')
+      onChanged()
+    } catch (err) {
+      if (err instanceof ApiError && err.status === 409) {
+        setError('Changed elsewhere; reloaded, please retry')
+        onChanged()
+        return
+      }
+      setError(err instanceof ApiError ? err.fieldErrors.title ?? err.message : String(err))
+    }
+  }
+
+  async function remove() {
+    await deleteItem(item.id)
+    onChanged()
+  }
+
+  if (editing) {
+    return (
+      <li>
+        <form onSubmit={save}>
+          <input value={title} onChange={(e) => setTitle(e.target.value)} autoFocus />
+          <button type="submit">Save</button>
+          <button type="button" onClick={() => setEditing(false)}>
+            Cancel
+          </button>
+          {error && <span className="error">{error}</span>}
+        </form>
+      </li>
+    )
+  }
+  return (
+    <li>
+      <div>
+        <strong>{item.title}</strong>
+        {item.description && <p>{item.description}</p>}
+      </div>
+      <button onClick={() => setEditing(true)}>Edit</button>
+      <button onClick={remove}>Delete</button>
+    </li>
+  )
+}
+
+export default App
@@ -0,0 +1,67 @@
+import type { components, operations } from './schema'
+
+// Types shared with the Go API, generated from openapi/openapi.yaml
+// (npm run types regenerates schema.d.ts after the spec changes)
+export type Item = components['schemas']['Item']
+export type ItemList = components['schemas']['ItemList']
+export type ItemInput = components['schemas']['ItemInput']
+export type ItemPatch = components['schemas']['ItemPatch']
+export type Problem = components['schemas']['Problem']
+export type ListQuery = NonNullable<operations['listItems']['parameters']['query']>
+
+// Every API error is an RFC 7807 problem; fieldErrors holds the
+// per-field messages of a 422
+export class ApiError extends Error {
+  status: number
+  fieldErrors: Record<string, string>
+
+  constructor(problem: Problem) {
+    super(problem.detail || problem.title)
+    this.status = problem.status
+    this.fieldErrors = problem.errors ?? {}
+  }
+}
+
+// Requests go to the same origin: the Vite dev server proxies /api to the
+// Go API, which serves the production build itself. VITE_API_URL points
+// a separately hosted frontend at the API (allow it with CORS_ORIGINS).
+const base = import.meta.env.VITE_API_URL ?? ''
+
+async function request<T>(path: string, init: { method?: string; body?: string } = {}): Promise<T> {
+  const res = await fetch(`${base}${path}`, {
+    ...init,
+    headers: init.body ? { 'Content-Type': 'application/json' } : undefined,
+  })
+  if (!res.ok) {
+    const problem: Problem = res.headers.get('Content-Type')?.includes('json')
+      ? await res.json()
+      : { type: 'about:blank', title: res.statusText, status: res.status }
+    throw new ApiError(problem)
+  }
+  return (res.status === 204 ? undefined : await res.json()) as T
+}
+
+export function listItems(query: ListQuery = {}): Promise<ItemList> {
+  const params = new URLSearchParams()
+  for (const [key, value] of Object.entries(query)) {
+    if (value !== undefined && value !== '') params.set(key, String(value))
+  }
+  const search = params.toString()
+  return request<ItemList>(`/api/v1/items${search ? `?${search}` : ''}`)
+}
+
+export function getItem(id: string): Promise<Item> {
+  return request<Item>(`/api/v1/items/${encodeURIComponent(id)}`)
+}
+
+export function createItem(input: ItemInput): Promise<Item> {
+  return request<Item>('/api/v1/items', { method: 'POST', body: JSON.stringify(input) })
+}
+
+export function updateItem(id: string, patch: ItemPatch): Promise<Item> {
+  return request<Item>(`/api/v1/items/${encodeURIComponent(id)}`, { method: 'PATCH', body: JSON.stringify(patch) })
+}
+
+export function deleteItem(id: string): Promise<void> {
+  return request<void>(`/api/v1/items/${encodeURIComponent(id)}`, { method: 'DELETE' })
+}
@@ -0,0 +1,6 @@
+/// <reference types="vite/client" />
+
+interface ImportMetaEnv {
+  // API origin for a frontend hosted apart from the Go API; empty is same-origin
+  readonly VITE_API_URL?: string
+}
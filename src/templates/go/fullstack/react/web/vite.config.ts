@@ -0,0 +1,35 @@
+import { writeFileSync } from 'node:fs'
+import { defineConfig, type Plugin } from 'vite'
+import react from '@vitejs/plugin-react'
+
+// The Go API (make run / make dev) serves these paths; the dev server
+// proxies them so the app calls the API same-origin, as it does once the
+// build is embedded into the binary
+const api = 'http://localhost:8080'
+
+// go:embed needs a file in dist/ even before the first build, so every
+// build puts the committed placeholder back
+const keepDist: Plugin = {
+  name: 'keep-dist',
+  closeBundle() {
+    writeFileSync('dist/.gitkeep', '')
+  }
+}
+
+export default defineConfig({
+  plugins: [react(), keepDist],
+  server: {
+    port: 5173,
+    proxy: {
+      '/api': api,
+      '/healthz': api,
+      '/readyz': api,
+      '/openapi.yaml': api,
+      '/docs': api
+    }
+  },
+  build: {
+    outDir: 'dist',
+    emptyOutDir: true
+  }
+})
@@ -0,0 +1,70 @@
+package web
+
+import (
+    "embed"
+    "io/fs"
+    "net/http"
+    "path"
+    "strings"
+)
+
+// dist is the React production build (npm run build in web/). It holds
+// only .gitkeep until the first build, so a fresh checkout still compiles.
+//
+//go:embed all:dist
+var dist embed.FS
+
+// Fallback handles requests no route matched: files of the embedded build,
+// and index.html for any other page so client-side routes survive a reload.
+// Unknown /api paths, missing assets and non-GET requests get notFound.
+func Fallback(notFound http.HandlerFunc) http.HandlerFunc {
+    files, err := fs.Sub(dist, "dist")
+    if err != nil {
+        panic(err)
+    }
+    return fallback(files, notFound)
+}
+
+func fallback(files fs.FS, notFound http.HandlerFunc) http.HandlerFunc {
+    fileServer := http.FileServer(http.FS(files))
+    return func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodGet && r.Method != http.MethodHead {
+            notFound(w, r)
+            return
+        }
+        if r.URL.Path == "/api" || strings.HasPrefix(r.URL.Path, "/api/") {
+            notFound(w, r)
+            return
+        }
+
+        name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+        if name != "" && name != "index.html" && name != ".gitkeep" {
+            if info, err := fs.Stat(files, name); err == nil && !info.IsDir() {
+                // Vite fingerprints everything under assets/
+                if strings.HasPrefix(name, "assets/") {
+                    w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+                }
+                fileServer.ServeHTTP(w, r)
+                return
+            }
+            // A missing file is a 404 rather than the page
+            if path.Ext(name) != "" {
+                notFound(w, r)
+                return
+            }
+        }
+        serveIndex(w, files)
+    }
+}
+
+func serveIndex(w http.ResponseWriter, files fs.FS) {
+    page, err := fs.ReadFile(files, "index.html")
+    if err != nil {
+        http.Error(w, "The frontend has not been built: run make web, or use the Vite dev server (make dev)", http.StatusServiceUnavailable)
+        return
+    }
+    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+    // index.html names the current assets, so browsers always revalidate it
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Write(page)
+}
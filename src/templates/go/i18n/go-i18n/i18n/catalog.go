@@ -0,0 +1,33 @@
+package i18n
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/fs"
+    goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+    "golang.org/x/text/language"
+    "myapp/locales"
+)
+
+// bundle holds the message catalogs in locales/, one JSON file per
+// locale named after its tag (es.json, pt-BR.json). English strings are
+// the message IDs, so English needs no file.
+var bundle = goi18n.NewBundle(language.English)
+
+// Supported lists English and every locale with a catalog; the first is
+// the fallback.
+var Supported []language.Tag
+
+func init() {
+    bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+    files, err := fs.Glob(locales.FS, "*.json")
+    if err != nil {
+        panic(err)
+    }
+    for _, file := range files {
+        if _, err := bundle.LoadMessageFileFS(locales.FS, file); err != nil {
+            panic(fmt.Sprintf("locales/%s: %v", file, err))
+        }
+    }
+    Supported = bundle.LanguageTags()
+}
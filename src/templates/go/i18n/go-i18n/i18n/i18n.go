@@ -0,0 +1,88 @@
+package i18n
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "sync"
+    goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+    "golang.org/x/text/language"
+)
+
+// LocaleCookie overrides Accept-Language when set.
+const LocaleCookie = "lang"
+
+type localizerKey struct{}
+
+type localeKey struct{}
+
+// matcher is built on first use, once Supported is final.
+var matcher = sync.OnceValue(func() language.Matcher {
+    return language.NewMatcher(Supported)
+})
+
+// Middleware negotiates the request locale from the lang cookie, then
+// Accept-Language, and stores it in the context.
+func Middleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        cookie := ""
+        if c, err := r.Cookie(LocaleCookie); err == nil {
+            cookie = c.Value
+        }
+        tag := Negotiate(cookie, r.Header.Get("Accept-Language"))
+        next.ServeHTTP(w, r.WithContext(withLocale(r.Context(), tag)))
+    })
+}
+
+func withLocale(ctx context.Context, tag language.Tag) context.Context {
+    ctx = context.WithValue(ctx, localeKey{}, tag)
+    return context.WithValue(ctx, localizerKey{}, goi18n.NewLocalizer(bundle, tag.String()))
+}
+
+// Localizer returns the request's go-i18n localizer, or the fallback
+// locale's.
+func Localizer(ctx context.Context) *goi18n.Localizer {
+    if l, ok := ctx.Value(localizerKey{}).(*goi18n.Localizer); ok {
+        return l
+    }
+    return goi18n.NewLocalizer(bundle, Supported[0].String())
+}
+
+// Locale returns the request's Supported locale, or the fallback.
+func Locale(ctx context.Context) language.Tag {
+    if tag, ok := ctx.Value(localeKey{}).(language.Tag); ok {
+        return tag
+    }
+    return Supported[0]
+}
+
+// T translates key (an English message, also the message ID in
+// locales/*.json) for the request's locale, then formats args into it
+// with fmt verbs as in the English text.
+func T(ctx context.Context, key string, args ...any) string {
+    return localize(ctx, key, nil, args)
+}
+
+// N is T for a message with plural forms, chosen by count:
+//
+//    "%d items": {"one": "%d elemento", "other": "%d elementos"}
+//
+// translated with i18n.N(ctx, "%d items", n, n).
+func N(ctx context.Context, key string, count int, args ...any) string {
+    return localize(ctx, key, count, args)
+}
+
+func localize(ctx context.Context, key string, count any, args []any) string {
+    msg, err := Localizer(ctx).Localize(&goi18n.LocalizeConfig{
+        DefaultMessage: &goi18n.Message{ID: key, Other: key},
+        PluralCount:    count,
+    })
+    if err != nil {
+        // Untranslated messages fall back to the English key
+        msg = key
+    }
+    if len(args) == 0 {
+        return msg
+    }
+    return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,7 @@
+// Package locales embeds the message catalogs read by the i18n package.
+package locales
+
+import "embed"
+
+//go:embed *.json
+var FS embed.FS
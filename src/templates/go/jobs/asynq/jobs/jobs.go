@@ -0,0 +1,45 @@
+package jobs
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/hibiken/asynq"
+    "myapp/config"
+)
+
+// Client enqueues jobs on the asynq queue in Redis.
+type Client struct {
+    client     *asynq.Client
+    maxRetries int
+}
+
+// Open sets up the queue at REDIS_URL. Redis is dialed lazily, so an
+// unreachable server shows up as an error on the first enqueue.
+func Open(ctx context.Context, cfg config.Jobs, db config.Database) (*Client, error) {
+    redis, err := asynq.ParseRedisURI(cfg.RedisURL)
+    if err != nil {
+        return nil, fmt.Errorf("REDIS_URL: %w", err)
+    }
+    return &Client{client: asynq.NewClient(redis), maxRetries: cfg.MaxRetries}, nil
+}
+
+// SendWelcomeEmail enqueues the welcome email for a new account.
+func (c *Client) SendWelcomeEmail(ctx context.Context, email string) error {
+    return c.enqueue(ctx, TypeWelcomeEmail, WelcomeEmail{Email: email})
+}
+
+func (c *Client) enqueue(ctx context.Context, kind string, payload any) error {
+    data, err := json.Marshal(payload)
+    if err != nil {
+        return err
+    }
+    if _, err := c.client.EnqueueContext(ctx, asynq.NewTask(kind, data), asynq.MaxRetry(c.maxRetries)); err != nil {
+        return fmt.Errorf("enqueue %s: %w", kind, err)
+    }
+    return nil
+}
+
+func (c *Client) Close() error {
+    return c.client.Close()
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+    "github.com/hibiken/asynq"
+    "myapp/config"
+    "myapp/logging"
+)
+
+// Run processes jobs until ctx is cancelled, then gives the running ones
+// JOBS_SHUTDOWN_TIMEOUT to finish. Register new job types on the mux.
+func Run(ctx context.Context, cfg config.Jobs, db config.Database) error {
+    redis, err := asynq.ParseRedisURI(cfg.RedisURL)
+    if err != nil {
+        return fmt.Errorf("REDIS_URL: %w", err)
+    }
+    srv := asynq.NewServer(redis, asynq.Config{
+        Concurrency:     cfg.Concurrency,
+        ShutdownTimeout: cfg.ShutdownTimeout,
+        // n counts earlier retries, so the first retry is n=0
+        RetryDelayFunc: func(n int, err error, t *asynq.Task) time.Duration {
+            return backoff(cfg, n+1)
+        },
+        ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, t *asynq.Task, err error) {
+            logging.Error(ctx, "job failed", "type", t.Type(), "err", err)
+        }),
+    })
+
+    mux := asynq.NewServeMux()
+    mux.HandleFunc(TypeWelcomeEmail, handle(sendWelcomeEmail))
+
+    if err := srv.Start(mux); err != nil {
+        return err
+    }
+    <-ctx.Done()
+    srv.Shutdown()
+    return nil
+}
+
+// handle decodes a task's JSON payload for fn. A payload that doesn't
+// decode never will, so it is not retried.
+func handle[T any](fn func(context.Context, T) error) func(context.Context, *asynq.Task) error {
+    return func(ctx context.Context, t *asynq.Task) error {
+        var payload T
+        if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+            return fmt.Errorf("%s payload: %v: %w", t.Type(), err, asynq.SkipRetry)
+        }
+        return fn(ctx, payload)
+    }
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+    "context"
+    "log"
+    "os/signal"
+    "syscall"
+    "myapp/config"
+    "myapp/jobs"
+    "myapp/logging"
+    "myapp/mailer"
+)
+
+// The worker runs the jobs the app enqueues. It reads the app's settings
+// (.env and the environment); run as many as the load needs.
+func main() {
+    cfg, err := config.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    logging.Setup(cfg.Env, cfg.LogLevel)
+
+    mail, err := mailer.Open(cfg.Mail)
+    if err != nil {
+        log.Fatalf("mailer: %v", err)
+    }
+    jobs.UseMailer(mail)
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+
+    log.Printf("🛠️  Worker running %d jobs at a time", cfg.Jobs.Concurrency)
+    if err := jobs.Run(ctx, cfg.Jobs, cfg.Database); err != nil {
+        log.Fatalf("worker: %v", err)
+    }
+    log.Println("👋 Worker stopped")
+}
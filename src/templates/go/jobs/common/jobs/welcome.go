@@ -0,0 +1,52 @@
+package jobs
+
+import (
+    "context"
+    "time"
+    "myapp/config"
+    "myapp/logging"
+    "myapp/mailer"
+)
+
+// TypeWelcomeEmail names the welcome email job on the queue.
+const TypeWelcomeEmail = "welcome_email"
+
+// WelcomeEmail is the payload of the welcome email job, enqueued when an
+// account is created.
+type WelcomeEmail struct {
+    Email string `json:"email"`
+}
+
+// mail sends the worker's emails (set by UseMailer)
+var mail mailer.Mailer
+
+// UseMailer sets the mailer jobs send email with; the worker passes the
+// one configured by the MAIL_* and SMTP_* settings.
+func UseMailer(m mailer.Mailer) {
+    mail = m
+}
+
+// sendWelcomeEmail runs the job in the worker, rendering the welcome
+// template. Without a mailer it only logs; returning an error schedules
+// a retry.
+func sendWelcomeEmail(ctx context.Context, job WelcomeEmail) error {
+    if mail == nil {
+        logging.Info(ctx, "welcome email", "to", job.Email)
+        return nil
+    }
+    msg, err := mailer.Render("welcome", job.Email, job)
+    if err != nil {
+        return err
+    }
+    return mail.Send(ctx, msg)
+}
+
+// backoff is the wait before retry n (1 for the first): BackoffBase,
+// doubled for each further retry and capped at BackoffMax.
+func backoff(cfg config.Jobs, n int) time.Duration {
+    d := cfg.BackoffBase
+    for i := 1; i < n && d < cfg.BackoffMax; i++ {
+        d *= 2
+    }
+    return min(d, cfg.BackoffMax)
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "github.com/jackc/pgx/v5"
+    "github.com/jackc/pgx/v5/pgxpool"
+    "github.com/riverqueue/river"
+    "github.com/riverqueue/river/riverdriver/riverpgxv5"
+    "github.com/riverqueue/river/rivermigrate"
+    "myapp/config"
+)
+
+func (WelcomeEmail) Kind() string { return TypeWelcomeEmail }
+
+// Client inserts jobs into river's tables in the app's Postgres
+// database, where the worker picks them up.
+type Client struct {
+    pool        *pgxpool.Pool
+    client      *river.Client[pgx.Tx]
+    maxAttempts int
+}
+
+// Open connects with DATABASE_URL (or the DB_* variables) and, unless
+// RUN_MIGRATIONS=false, creates or upgrades river's tables. The worker
+// leaves migrations to the app.
+func Open(ctx context.Context, cfg config.Jobs, db config.Database) (*Client, error) {
+    pool, err := connect(ctx, db.URL, db.RunMigrations)
+    if err != nil {
+        return nil, err
+    }
+    // Without workers or queues the client only inserts
+    client, err := river.NewClient(riverpgxv5.New(pool), &river.Config{})
+    if err != nil {
+        pool.Close()
+        return nil, err
+    }
+    return &Client{pool: pool, client: client, maxAttempts: cfg.MaxRetries + 1}, nil
+}
+
+// SendWelcomeEmail enqueues the welcome email for a new account.
+func (c *Client) SendWelcomeEmail(ctx context.Context, email string) error {
+    if _, err := c.client.Insert(ctx, WelcomeEmail{Email: email}, &river.InsertOpts{MaxAttempts: c.maxAttempts}); err != nil {
+        return fmt.Errorf("enqueue %s: %w", TypeWelcomeEmail, err)
+    }
+    return nil
+}
+
+func (c *Client) Close() error {
+    c.pool.Close()
+    return nil
+}
+
+func connect(ctx context.Context, dsn string, migrate bool) (*pgxpool.Pool, error) {
+    if dsn == "" {
+        return nil, errors.New("set DATABASE_URL or DB_HOST to queue jobs in Postgres")
+    }
+    pool, err := pgxpool.New(ctx, dsn)
+    if err != nil {
+        return nil, err
+    }
+    if migrate {
+        migrator := rivermigrate.New(riverpgxv5.New(pool), nil)
+        if _, err := migrator.Migrate(ctx, rivermigrate.DirectionUp, nil); err != nil {
+            pool.Close()
+            return nil, fmt.Errorf("river migrations: %w", err)
+        }
+    }
+    return pool, nil
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+    "context"
+    "log/slog"
+    "time"
+    "github.com/riverqueue/river"
+    "github.com/riverqueue/river/riverdriver/riverpgxv5"
+    "github.com/riverqueue/river/rivertype"
+    "myapp/config"
+)
+
+// Run processes jobs until ctx is cancelled, then gives the running ones
+// JOBS_SHUTDOWN_TIMEOUT to finish. Register new job types on workers.
+func Run(ctx context.Context, cfg config.Jobs, db config.Database) error {
+    pool, err := connect(ctx, db.URL, false)
+    if err != nil {
+        return err
+    }
+    defer pool.Close()
+
+    workers := river.NewWorkers()
+    river.AddWorker(workers, river.WorkFunc(func(ctx context.Context, job *river.Job[WelcomeEmail]) error {
+        return sendWelcomeEmail(ctx, job.Args)
+    }))
+
+    client, err := river.NewClient(riverpgxv5.New(pool), &river.Config{
+        Queues:      map[string]river.QueueConfig{river.QueueDefault: {MaxWorkers: cfg.Concurrency}},
+        Workers:     workers,
+        MaxAttempts: cfg.MaxRetries + 1,
+        RetryPolicy: retryPolicy{cfg},
+        Logger:      slog.Default(),
+    })
+    if err != nil {
+        return err
+    }
+
+    // Stopped below rather than by ctx, so running jobs can finish
+    if err := client.Start(context.WithoutCancel(ctx)); err != nil {
+        return err
+    }
+    <-ctx.Done()
+    stop, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+    defer cancel()
+    return client.Stop(stop)
+}
+
+// retryPolicy schedules a failed job's next attempt after backoff.
+type retryPolicy struct {
+    cfg config.Jobs
+}
+
+func (p retryPolicy) NextRetry(job *rivertype.JobRow) time.Time {
+    return time.Now().Add(backoff(p.cfg, job.Attempt))
+}
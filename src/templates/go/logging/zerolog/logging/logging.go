@@ -0,0 +1,86 @@
+package logging
+
+import (
+    "context"
+    "io"
+    "log"
+    "log/slog"
+    "net/http"
+    "os"
+    "time"
+    "github.com/rs/zerolog"
+)
+
+type loggerKey struct{}
+
+var base = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+// Setup installs the app logger: colored console output when env is
+// "development", JSON lines otherwise. The stdlib log package is routed
+// through it, so stray log.Printf calls still come out structured.
+func Setup(env string, level slog.Level) {
+    var out io.Writer = os.Stderr
+    if env == "development" {
+        out = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.TimeOnly}
+    }
+    base = zerolog.New(out).Level(zerologLevel(level)).With().Timestamp().Logger()
+    log.SetFlags(0)
+    log.SetOutput(base)
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+    switch {
+    case level <= slog.LevelDebug:
+        return zerolog.DebugLevel
+    case level <= slog.LevelInfo:
+        return zerolog.InfoLevel
+    case level <= slog.LevelWarn:
+        return zerolog.WarnLevel
+    default:
+        return zerolog.ErrorLevel
+    }
+}
+
+// Middleware stores a logger tagged with the request ID, method and path
+// in each request's context. It must run after the middleware that
+// assigns request IDs; requestID reads the ID back out.
+func Middleware(requestID func(context.Context) string) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            logger := base.With().
+                Str("request_id", requestID(r.Context())).
+                Str("method", r.Method).
+                Str("path", r.URL.Path).
+                Logger()
+            ctx := context.WithValue(r.Context(), loggerKey{}, &logger)
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
+
+// FromContext returns the request's logger, or the base logger outside a
+// request.
+func FromContext(ctx context.Context) *zerolog.Logger {
+    if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+        return logger
+    }
+    return &base
+}
+
+// Debug, Info, Warn and Error log through the request's logger. args are
+// alternating keys and values, e.g. logging.Error(ctx, "save failed", "err", err).
+func Debug(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).Debug().Fields(args).Msg(msg)
+}
+
+func Info(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).Info().Fields(args).Msg(msg)
+}
+
+func Warn(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).Warn().Fields(args).Msg(msg)
+}
+
+func Error(ctx context.Context, msg string, args ...any) {
+    FromContext(ctx).Error().Fields(args).Msg(msg)
+}
@@ -0,0 +1,12 @@
+package mailer
+
+import "myapp/config"
+
+// Open returns the mailer: SMTP through cfg.SMTPHost, or the log when no
+// host is set (local development).
+func Open(cfg config.Mail) (Mailer, error) {
+    if cfg.SMTPHost == "" {
+        return &Console{From: cfg.From}, nil
+    }
+    return NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+}
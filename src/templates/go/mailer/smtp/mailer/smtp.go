@@ -0,0 +1,113 @@
+package mailer
+
+import (
+    "bytes"
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "fmt"
+    "mime"
+    "mime/quotedprintable"
+    "net"
+    "net/mail"
+    "net/smtp"
+    "strings"
+    "time"
+)
+
+// SMTP sends messages through an SMTP server. net/smtp upgrades the
+// connection with STARTTLS when the server offers it, and refuses to send
+// credentials over a connection that isn't encrypted (except to
+// localhost), so a username is only safe with a server that supports it.
+type SMTP struct {
+    addr string
+    auth smtp.Auth
+    from string
+}
+
+func NewSMTP(host, port, username, password, from string) *SMTP {
+    s := &SMTP{addr: net.JoinHostPort(host, port), from: from}
+    if username != "" {
+        s.auth = smtp.PlainAuth("", username, password, host)
+    }
+    return s
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+    if msg.To == "" {
+        return ErrNoRecipient
+    }
+    from, err := mail.ParseAddress(s.from)
+    if err != nil {
+        return fmt.Errorf("mailer: MAIL_FROM: %w", err)
+    }
+    to, err := mail.ParseAddress(msg.To)
+    if err != nil {
+        return fmt.Errorf("mailer: recipient: %w", err)
+    }
+
+    body, err := encode(from, to, msg)
+    if err != nil {
+        return err
+    }
+    // net/smtp takes no context; send in the background and stop waiting
+    // when ctx ends
+    done := make(chan error, 1)
+    go func() {
+        done <- smtp.SendMail(s.addr, s.auth, from.Address, []string{to.Address}, body)
+    }()
+    select {
+    case err := <-done:
+        return err
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// encode writes msg as a MIME message: the text body alone, or text and
+// HTML as multipart/alternative alternatives, both quoted-printable.
+func encode(from, to *mail.Address, msg Message) ([]byte, error) {
+    var b bytes.Buffer
+    fmt.Fprintf(&b, "From: %s\r\n", from.String())
+    fmt.Fprintf(&b, "To: %s\r\n", to.String())
+    fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+    fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+    b.WriteString("MIME-Version: 1.0\r\n")
+
+    if msg.HTML == "" {
+        if err := writePart(&b, "text/plain", msg.Text); err != nil {
+            return nil, err
+        }
+        return b.Bytes(), nil
+    }
+
+    buf := make([]byte, 12)
+    if _, err := rand.Read(buf); err != nil {
+        return nil, err
+    }
+    boundary := hex.EncodeToString(buf)
+    fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+    for _, part := range []struct{ contentType, body string }{{"text/plain", msg.Text}, {"text/html", msg.HTML}} {
+        fmt.Fprintf(&b, "--%s\r\n", boundary)
+        if err := writePart(&b, part.contentType, part.body); err != nil {
+            return nil, err
+        }
+    }
+    fmt.Fprintf(&b, "--%s--\r\n", boundary)
+    return b.Bytes(), nil
+}
+
+// writePart writes the headers and quoted-printable body of one part.
+func writePart(b *bytes.Buffer, contentType, body string) error {
+    fmt.Fprintf(b, "Content-Type: %s; charset=utf-8\r\n", contentType)
+    b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+    w := quotedprintable.NewWriter(b)
+    if _, err := w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+        return err
+    }
+    if err := w.Close(); err != nil {
+        return err
+    }
+    b.WriteString("\r\n")
+    return nil
+}
@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+    "context"
+    "errors"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/trace"
+    "myapp/models"
+    "myapp/store"
+)
+
+// Store wraps an item store with a span per operation, a child of the
+// request's span. A missing item or a version conflict is recorded on the
+// span but is not an error: handlers turn them into a 404 or 409.
+func Store(next store.ItemStore) store.ItemStore {
+    return &tracedStore{next: next, tracer: otel.Tracer("myapp/store")}
+}
+
+type tracedStore struct {
+    next   store.ItemStore
+    tracer trace.Tracer
+}
+
+func (s *tracedStore) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+    return s.tracer.Start(ctx, "store."+op, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
+
+func end(span trace.Span, err error) {
+    switch {
+    case err == nil:
+    case errors.Is(err, store.ErrNotFound):
+        span.SetAttributes(attribute.Bool("item.not_found", true))
+    case errors.Is(err, store.ErrConflict):
+        span.SetAttributes(attribute.Bool("item.conflict", true))
+    default:
+        span.RecordError(err)
+        span.SetStatus(codes.Error, err.Error())
+    }
+    span.End()
+}
+
+func (s *tracedStore) List(ctx context.Context) (items []models.Item, err error) {
+    ctx, span := s.start(ctx, "List")
+    defer func() { end(span, err) }()
+    return s.next.List(ctx)
+}
+
+func (s *tracedStore) Find(ctx context.Context, q store.ListQuery) (page store.Page, err error) {
+    ctx, span := s.start(ctx, "Find")
+    defer func() { end(span, err) }()
+    return s.next.Find(ctx, q)
+}
+
+func (s *tracedStore) Get(ctx context.Context, id string) (item models.Item, err error) {
+    ctx, span := s.start(ctx, "Get", attribute.String("item.id", id))
+    defer func() { end(span, err) }()
+    return s.next.Get(ctx, id)
+}
+
+func (s *tracedStore) Create(ctx context.Context, item models.Item) (created models.Item, err error) {
+    ctx, span := s.start(ctx, "Create")
+    defer func() { end(span, err) }()
+    return s.next.Create(ctx, item)
+}
+
+func (s *tracedStore) Update(ctx context.Context, item models.Item) (updated models.Item, err error) {
+    ctx, span := s.start(ctx, "Update", attribute.String("item.id", item.ID))
+    defer func() { end(span, err) }()
+    return s.next.Update(ctx, item)
+}
+
+func (s *tracedStore) Delete(ctx context.Context, id string) (err error) {
+    ctx, span := s.start(ctx, "Delete", attribute.String("item.id", id))
+    defer func() { end(span, err) }()
+    return s.next.Delete(ctx, id)
+}
+
+func (s *tracedStore) UpdateMany(ctx context.Context, ids []string, fn func(*models.Item)) (updated []models.Item, err error) {
+    ctx, span := s.start(ctx, "UpdateMany", attribute.Int("item.count", len(ids)))
+    defer func() { end(span, err) }()
+    return s.next.UpdateMany(ctx, ids, fn)
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+    "go.opentelemetry.io/otel/exporters/prometheus"
+    "go.opentelemetry.io/otel/propagation"
+    sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "myapp/config"
+)
+
+// Setup installs the global tracer and meter providers and returns a
+// function that flushes and stops them. Spans are exported over OTLP/HTTP
+// when OTEL_EXPORTER_OTLP_ENDPOINT is set; metrics are always served at
+// /metrics for Prometheus, and also pushed over OTLP with
+// OTEL_METRICS_OTLP=true. Incoming trace context (W3C traceparent) is
+// continued.
+func Setup(ctx context.Context, cfg config.Telemetry) (func(context.Context) error, error) {
+    attrs := []attribute.KeyValue{}
+    if cfg.ServiceName != "" {
+        attrs = append(attrs, attribute.String("service.name", cfg.ServiceName))
+    }
+    res, err := resource.New(ctx, resource.WithFromEnv(), resource.WithTelemetrySDK(), resource.WithAttributes(attrs...))
+    if err != nil {
+        return nil, err
+    }
+
+    traceOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+    if cfg.OTLPEndpoint != "" {
+        exporter, err := otlptracehttp.New(ctx)
+        if err != nil {
+            return nil, err
+        }
+        traceOpts = append(traceOpts, sdktrace.WithBatcher(exporter))
+    }
+    tracerProvider := sdktrace.NewTracerProvider(traceOpts...)
+
+    scrape, err := prometheus.New()
+    if err != nil {
+        return nil, err
+    }
+    meterOpts := []sdkmetric.Option{sdkmetric.WithResource(res), sdkmetric.WithReader(scrape)}
+    if cfg.OTLPEndpoint != "" && cfg.OTLPMetrics {
+        exporter, err := otlpmetrichttp.New(ctx)
+        if err != nil {
+            return nil, err
+        }
+        meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
+    }
+    meterProvider := sdkmetric.NewMeterProvider(meterOpts...)
+
+    otel.SetTracerProvider(tracerProvider)
+    otel.SetMeterProvider(meterProvider)
+    otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+    return func(ctx context.Context) error {
+        return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+    }, nil
+}
+
+// Metrics serves every metric in the Prometheus text format.
+func Metrics() http.Handler {
+    return promhttp.Handler()
+}
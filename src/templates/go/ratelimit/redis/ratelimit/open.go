@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+    "context"
+    "fmt"
+    "log"
+    "net/http"
+    "time"
+    "github.com/redis/go-redis/v9"
+    "myapp/config"
+)
+
+// Open returns middleware that limits requests per client and route with
+// token buckets (nil when neither RATE_LIMIT nor RATE_LIMIT_ROUTES is
+// set), plus a function that releases it. The buckets are kept in the
+// cache's Redis at CACHE_REDIS_URL, so every replica shares them;
+// without it (local development) they stay in process.
+func Open(ctx context.Context, cfg config.RateLimit, redisURL string) (func(http.Handler) http.Handler, func() error, error) {
+    rules, err := Rules(cfg)
+    if err != nil || len(rules) == 0 {
+        return nil, func() error { return nil }, err
+    }
+    if redisURL == "" {
+        log.Println("CACHE_REDIS_URL is not set; keeping rate limits in process")
+        l := &limiter{rules: rules, store: NewMemoryStore(time.Now), now: time.Now}
+        return l.Middleware, func() error { return nil }, nil
+    }
+
+    opts, err := redis.ParseURL(redisURL)
+    if err != nil {
+        return nil, nil, fmt.Errorf("CACHE_REDIS_URL: %w", err)
+    }
+    client := redis.NewClient(opts)
+    if err := client.Ping(ctx).Err(); err != nil {
+        client.Close()
+        return nil, nil, fmt.Errorf("redis: %w", err)
+    }
+    l := &limiter{rules: rules, store: NewRedisStore(client), now: time.Now}
+    return l.Middleware, client.Close, nil
+}
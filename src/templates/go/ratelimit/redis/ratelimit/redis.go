@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+    "context"
+    "strconv"
+    "github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes from one bucket atomically, on Redis's
+// clock so replicas with skewed clocks agree. A bucket expires once it
+// would be full again, which is the same as not having one.
+var takeScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1]) or burst
+local last = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + (now - last) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps the buckets in Redis, shared by every replica.
+type RedisStore struct {
+    client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+    return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, rule Rule) (bool, float64, error) {
+    // Tokens per millisecond
+    rate := float64(rule.Limit) / float64(rule.Window.Milliseconds())
+    res, err := takeScript.Run(ctx, s.client, []string{"ratelimit:" + key}, rule.Burst, rate).Slice()
+    if err != nil {
+        return false, 0, err
+    }
+    allowed, _ := res[0].(int64)
+    tokens, err := strconv.ParseFloat(res[1].(string), 64)
+    if err != nil {
+        return false, 0, err
+    }
+    return allowed == 1, tokens, nil
+}
@@ -0,0 +1,21 @@
+package ratelimit
+
+import (
+    "context"
+    "net/http"
+    "time"
+    "myapp/config"
+)
+
+// Open returns middleware that limits requests per client and route with
+// token buckets (nil when neither RATE_LIMIT nor RATE_LIMIT_ROUTES is
+// set), plus a function that releases it. The buckets are kept in
+// process; generate with --cache redis to share them between replicas.
+func Open(ctx context.Context, cfg config.RateLimit, redisURL string) (func(http.Handler) http.Handler, func() error, error) {
+    rules, err := Rules(cfg)
+    if err != nil || len(rules) == 0 {
+        return nil, func() error { return nil }, err
+    }
+    l := &limiter{rules: rules, store: NewMemoryStore(time.Now), now: time.Now}
+    return l.Middleware, func() error { return nil }, nil
+}
@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+    "context"
+    "encoding/json"
+    "math"
+    "net"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+    "myapp/logging"
+)
+
+// Store keeps the token buckets. Take refills the bucket at key for the
+// time since it was last used, then takes a token if one is left.
+// Implementations must be safe for concurrent use.
+type Store interface {
+    Take(ctx context.Context, key string, rule Rule) (allowed bool, tokens float64, err error)
+}
+
+// unlimited paths are never limited, so probes and scrapes, which all
+// come from a few addresses, are not throttled
+var unlimited = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+type limiter struct {
+    rules []Rule
+    store Store
+    now   func() time.Time
+}
+
+func (l *limiter) rule(r *http.Request) (Rule, bool) {
+    for _, rule := range l.rules {
+        if (rule.Method == "" || rule.Method == r.Method) && strings.HasPrefix(r.URL.Path, rule.Prefix) {
+            return rule, true
+        }
+    }
+    return Rule{}, false
+}
+
+// Middleware advertises the client's bucket for the matching rule
+// through the X-RateLimit-* headers, and answers 429 with Retry-After
+// (seconds until a token is back) once it is empty. When the store
+// fails, requests are let through rather than all refused.
+func (l *limiter) Middleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        rule, ok := l.rule(r)
+        if !ok || unlimited[r.URL.Path] {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        allowed, tokens, err := l.store.Take(r.Context(), rule.Name+"|"+clientIP(r), rule)
+        if err != nil {
+            logging.Warn(r.Context(), "rate limit store failed", "err", err)
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        h := w.Header()
+        h.Set("X-RateLimit-Limit", strconv.Itoa(rule.Burst))
+        h.Set("X-RateLimit-Remaining", strconv.Itoa(int(tokens)))
+        h.Set("X-RateLimit-Reset", strconv.FormatInt(l.now().Add(rule.until(rule.Burst, tokens)).Unix(), 10))
+        if !allowed {
+            retry := int(math.Ceil(rule.until(1, tokens).Seconds()))
+            h.Set("Retry-After", strconv.Itoa(max(retry, 1)))
+            h.Set("Content-Type", "application/problem+json")
+            w.WriteHeader(http.StatusTooManyRequests)
+            json.NewEncoder(w).Encode(map[string]any{
+                "type":   "about:blank",
+                "title":  http.StatusText(http.StatusTooManyRequests),
+                "status": http.StatusTooManyRequests,
+                "detail": "Rate limit exceeded; retry after " + strconv.Itoa(max(retry, 1)) + "s",
+            })
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func clientIP(r *http.Request) string {
+    host, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return host
+}
+
+// MemoryStore keeps the buckets in process, so each replica limits on
+// its own.
+type MemoryStore struct {
+    now func() time.Time
+
+    mu      sync.Mutex
+    buckets map[string]*bucket
+    sweep   time.Time
+}
+
+type bucket struct {
+    tokens float64
+    last   time.Time
+    full   time.Time
+}
+
+func NewMemoryStore(now func() time.Time) *MemoryStore {
+    return &MemoryStore{now: now, buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, rule Rule) (bool, float64, error) {
+    now := s.now()
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    // A full bucket is the same as a new one, so those can be dropped
+    if now.After(s.sweep) {
+        for k, b := range s.buckets {
+            if now.After(b.full) {
+                delete(s.buckets, k)
+            }
+        }
+        s.sweep = now.Add(time.Minute)
+    }
+
+    b, ok := s.buckets[key]
+    if !ok {
+        b = &bucket{tokens: float64(rule.Burst), last: now}
+        s.buckets[key] = b
+    }
+    b.tokens = rule.refill(b.tokens, now.Sub(b.last))
+    b.last = now
+
+    allowed := b.tokens >= 1
+    if allowed {
+        b.tokens--
+    }
+    b.full = now.Add(rule.until(rule.Burst, b.tokens))
+    return allowed, b.tokens, nil
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+    "myapp/config"
+)
+
+// Rule is a token bucket per client: it holds up to Burst tokens and is
+// refilled at Limit per Window, and each request takes one. Route rules
+// apply to requests with a matching method (any when empty) and path
+// prefix; the default rule has Prefix "/".
+type Rule struct {
+    Name   string
+    Method string
+    Prefix string
+    Limit  int
+    Window time.Duration
+    Burst  int
+}
+
+// until is how long the bucket takes to refill from tokens to n.
+func (r Rule) until(n int, tokens float64) time.Duration {
+    missing := float64(n) - tokens
+    if missing <= 0 {
+        return 0
+    }
+    return time.Duration(missing / float64(r.Limit) * float64(r.Window))
+}
+
+// refill adds the tokens earned over elapsed, up to Burst.
+func (r Rule) refill(tokens float64, elapsed time.Duration) float64 {
+    tokens += float64(r.Limit) * elapsed.Seconds() / r.Window.Seconds()
+    return min(tokens, float64(r.Burst))
+}
+
+// Rules returns the route rules of RATE_LIMIT_ROUTES in order, then the
+// default rule when RATE_LIMIT is set. The first that matches applies.
+func Rules(cfg config.RateLimit) ([]Rule, error) {
+    var rules []Rule
+    for _, spec := range cfg.Routes {
+        rule, err := ParseRoute(spec)
+        if err != nil {
+            return nil, err
+        }
+        rules = append(rules, rule)
+    }
+    if cfg.Limit > 0 {
+        burst := cfg.Burst
+        if burst == 0 {
+            burst = cfg.Limit
+        }
+        rules = append(rules, Rule{Name: "*", Prefix: "/", Limit: cfg.Limit, Window: cfg.Window, Burst: burst})
+    }
+    return rules, nil
+}
+
+// ParseRoute reads a route rule such as "POST /login=5/1m" (5 requests a
+// minute) or "/api/=100/1m:200" (any method, bursts of up to 200; the
+// burst defaults to the limit).
+func ParseRoute(spec string) (Rule, error) {
+    route, limit, ok := strings.Cut(spec, "=")
+    if !ok {
+        return Rule{}, fmt.Errorf("route rule %q: want [METHOD] /path=limit/window", spec)
+    }
+    rule := Rule{Name: strings.TrimSpace(route)}
+    if method, prefix, ok := strings.Cut(rule.Name, " "); ok {
+        rule.Method, rule.Prefix = strings.ToUpper(method), strings.TrimSpace(prefix)
+    } else {
+        rule.Prefix = rule.Name
+    }
+    if !strings.HasPrefix(rule.Prefix, "/") {
+        return Rule{}, fmt.Errorf("route rule %q: path must start with /", spec)
+    }
+
+    count, window, ok := strings.Cut(strings.TrimSpace(limit), "/")
+    window, burst, hasBurst := strings.Cut(window, ":")
+    var err error
+    if rule.Limit, err = strconv.Atoi(count); !ok || err != nil || rule.Limit < 1 {
+        return Rule{}, fmt.Errorf("route rule %q: want a limit of 1 or more, e.g. 5/1m", spec)
+    }
+    if rule.Window, err = time.ParseDuration(window); err != nil || rule.Window <= 0 {
+        return Rule{}, fmt.Errorf("route rule %q: want a window such as 1s or 1m", spec)
+    }
+    rule.Burst = rule.Limit
+    if hasBurst {
+        if rule.Burst, err = strconv.Atoi(burst); err != nil || rule.Burst < 1 {
+            return Rule{}, fmt.Errorf("route rule %q: want a burst of 1 or more", spec)
+        }
+    }
+    return rule, nil
+}
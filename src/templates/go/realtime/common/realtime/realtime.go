@@ -0,0 +1,106 @@
+package realtime
+
+import (
+    "bytes"
+    "context"
+    "sync"
+    "time"
+    "github.com/a-h/templ"
+    "myapp/events"
+)
+
+// Renderer turns an event into the fragment pushed to a page, rendered
+// with that page's request context (locale, CSRF token). A nil component
+// means the page has nothing to update.
+type Renderer func(ctx context.Context, e events.Event) templ.Component
+
+// Events a stream may have queued before it counts as stalled
+const streamBuffer = 16
+
+// Idle streams are pinged so proxies keep them open
+const heartbeat = 30 * time.Second
+
+// Hub fans item events out to every open /events stream. A stream that
+// falls behind is dropped rather than slowing the others down; the htmx
+// extension on the page reconnects on its own.
+type Hub struct {
+    render Renderer
+
+    mu      sync.Mutex
+    streams map[chan events.Event]struct{}
+    closed  bool
+}
+
+func NewHub(bus *events.Bus, render Renderer) *Hub {
+    h := &Hub{
+        render:  render,
+        streams: make(map[chan events.Event]struct{}),
+    }
+    bus.SubscribeAll(func(ctx context.Context, e events.Event) {
+        h.broadcast(e)
+    })
+    return h
+}
+
+// Close ends every open stream so the server can shut down, and refuses
+// new ones.
+func (h *Hub) Close() {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.closed = true
+    for ch := range h.streams {
+        h.drop(ch)
+    }
+}
+
+// subscribe opens a stream; ok is false once the hub is closed. The
+// channel is closed when the stream is dropped.
+func (h *Hub) subscribe() (ch chan events.Event, ok bool) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if h.closed {
+        return nil, false
+    }
+    ch = make(chan events.Event, streamBuffer)
+    h.streams[ch] = struct{}{}
+    return ch, true
+}
+
+func (h *Hub) unsubscribe(ch chan events.Event) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if _, ok := h.streams[ch]; ok {
+        h.drop(ch)
+    }
+}
+
+func (h *Hub) broadcast(e events.Event) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    for ch := range h.streams {
+        select {
+        case ch <- e:
+        default:
+            h.drop(ch)
+        }
+    }
+}
+
+// drop must be called with mu held.
+func (h *Hub) drop(ch chan events.Event) {
+    delete(h.streams, ch)
+    close(ch)
+}
+
+// fragment renders e for one page; nil when the page has nothing to update.
+func (h *Hub) fragment(ctx context.Context, e events.Event) ([]byte, error) {
+    c := h.render(ctx, e)
+    if c == nil {
+        return nil, nil
+    }
+    var buf bytes.Buffer
+    if err := c.Render(ctx, &buf); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+package realtime
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "net/http"
+    "time"
+    "github.com/go-chi/chi/v5"
+    "myapp/logging"
+)
+
+// Routes mounts the /events stream: Server-Sent Events read by htmx's sse
+// extension (see views.LiveUpdates).
+func (h *Hub) Routes(r chi.Router) {
+    r.Get("/events", h.serve)
+}
+
+func (h *Hub) serve(w http.ResponseWriter, r *http.Request) {
+    updates, ok := h.subscribe()
+    if !ok {
+        http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+        return
+    }
+    defer h.unsubscribe(updates)
+
+    // The stream outlives the server's WriteTimeout
+    rc := http.NewResponseController(w)
+    rc.SetWriteDeadline(time.Time{})
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    // Stop nginx from buffering the stream
+    w.Header().Set("X-Accel-Buffering", "no")
+    w.WriteHeader(http.StatusOK)
+    if err := rc.Flush(); err != nil {
+        return
+    }
+
+    ping := time.NewTicker(heartbeat)
+    defer ping.Stop()
+
+    for {
+        var err error
+        select {
+        case <-r.Context().Done():
+            return
+        case e, open := <-updates:
+            if !open {
+                return
+            }
+            frag, ferr := h.fragment(r.Context(), e)
+            if ferr != nil {
+                logging.Error(r.Context(), "render live update", "event", e.EventName(), "err", ferr)
+                continue
+            }
+            if frag == nil {
+                continue
+            }
+            err = writeEvent(w, "items", frag)
+        case <-ping.C:
+            _, err = io.WriteString(w, ": ping\n\n")
+        }
+        if err == nil {
+            err = rc.Flush()
+        }
+        if err != nil {
+            return
+        }
+    }
+}
+
+// writeEvent writes one SSE message; every line of data gets its own
+// "data:" field, which the browser joins back with newlines.
+func writeEvent(w io.Writer, name string, data []byte) error {
+    var buf bytes.Buffer
+    fmt.Fprintf(&buf, "event: %s\n", name)
+    for _, line := range bytes.Split(data, []byte("\n")) {
+        fmt.Fprintf(&buf, "data: %s\n", line)
+    }
+    buf.WriteString("\n")
+    _, err := w.Write(buf.Bytes())
+    return err
+}
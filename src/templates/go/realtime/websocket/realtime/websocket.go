@@ -0,0 +1,83 @@
+package realtime
+
+import (
+    "context"
+    "net/http"
+    "time"
+    "github.com/coder/websocket"
+    "github.com/go-chi/chi/v5"
+    "myapp/logging"
+)
+
+// How long a single write or ping may take before the page counts as gone
+const writeTimeout = 10 * time.Second
+
+// Routes mounts the /events WebSocket read by htmx's ws extension (see
+// views.LiveUpdates). Only same-origin pages may connect.
+func (h *Hub) Routes(r chi.Router) {
+    r.Get("/events", h.serve)
+}
+
+func (h *Hub) serve(w http.ResponseWriter, r *http.Request) {
+    // The connection outlives the server's read and write timeouts
+    rc := http.NewResponseController(w)
+    rc.SetReadDeadline(time.Time{})
+    rc.SetWriteDeadline(time.Time{})
+
+    // Accept writes its own error response
+    conn, err := websocket.Accept(w, r, nil)
+    if err != nil {
+        return
+    }
+    defer conn.CloseNow()
+
+    updates, ok := h.subscribe()
+    if !ok {
+        conn.Close(websocket.StatusGoingAway, "server shutting down")
+        return
+    }
+    defer h.unsubscribe(updates)
+
+    // Pages only listen; CloseRead answers control frames and cancels ctx
+    // once the page goes away
+    ctx := conn.CloseRead(r.Context())
+
+    ping := time.NewTicker(heartbeat)
+    defer ping.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case e, open := <-updates:
+            if !open {
+                conn.Close(websocket.StatusGoingAway, "stream closed")
+                return
+            }
+            frag, err := h.fragment(ctx, e)
+            if err != nil {
+                logging.Error(ctx, "render live update", "event", e.EventName(), "err", err)
+                continue
+            }
+            if frag == nil {
+                continue
+            }
+            if err := write(ctx, conn, frag); err != nil {
+                return
+            }
+        case <-ping.C:
+            pingCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+            err := conn.Ping(pingCtx)
+            cancel()
+            if err != nil {
+                return
+            }
+        }
+    }
+}
+
+func write(ctx context.Context, conn *websocket.Conn, msg []byte) error {
+    ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+    defer cancel()
+    return conn.Write(ctx, websocket.MessageText, msg)
+}
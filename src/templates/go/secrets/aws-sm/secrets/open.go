@@ -0,0 +1,14 @@
+package secrets
+
+import "context"
+
+// Open returns the AWS Secrets Manager secret whose name or ARN is
+// location, or nil without one (local development, where .env holds the
+// secrets). Credentials and region come from the default AWS chain
+// (AWS_ACCESS_KEY_ID, AWS_REGION, ~/.aws, instance and task roles).
+func Open(ctx context.Context, location string) (Source, error) {
+    if location == "" {
+        return nil, nil
+    }
+    return NewSecretsManager(ctx, location)
+}
@@ -0,0 +1,50 @@
+package secrets
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    awsconfig "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// SecretsManager reads one key/value secret from AWS Secrets Manager:
+// the JSON object the console stores, whose keys are environment variable
+// names (DB_PASSWORD, SESSION_SECRET).
+type SecretsManager struct {
+    client *secretsmanager.Client
+    id     string
+}
+
+func NewSecretsManager(ctx context.Context, id string) (*SecretsManager, error) {
+    awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return &SecretsManager{client: secretsmanager.NewFromConfig(awsCfg), id: id}, nil
+}
+
+func (s *SecretsManager) Fetch(ctx context.Context) (map[string]string, error) {
+    out, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+        SecretId: aws.String(s.id),
+    })
+    if err != nil {
+        return nil, err
+    }
+    if out.SecretString == nil {
+        return nil, errors.New("binary secrets are not supported; store key/value pairs")
+    }
+    return parseKeyValues(*out.SecretString)
+}
+
+// parseKeyValues reads a secret string holding a flat JSON object of
+// string values.
+func parseKeyValues(s string) (map[string]string, error) {
+    var values map[string]string
+    if err := json.Unmarshal([]byte(s), &values); err != nil {
+        return nil, fmt.Errorf("want a JSON object of string values: %w", err)
+    }
+    return values, nil
+}
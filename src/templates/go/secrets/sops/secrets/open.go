@@ -0,0 +1,15 @@
+package secrets
+
+import "context"
+
+// Open returns the SOPS-encrypted file at location (secrets.enc.env,
+// .json or .yaml), or nil without one (local development, where .env
+// holds the secrets). SOPS finds the decryption key as the sops CLI does:
+// SOPS_AGE_KEY_FILE or ~/.config/sops/age/keys.txt for age, the default
+// AWS, GCP or Azure credentials for a cloud KMS, or the GPG agent.
+func Open(ctx context.Context, location string) (Source, error) {
+    if location == "" {
+        return nil, nil
+    }
+    return SOPSFile{Path: location}, nil
+}
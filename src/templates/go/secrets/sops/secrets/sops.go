@@ -0,0 +1,47 @@
+package secrets
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "path/filepath"
+    "github.com/getsops/sops/v3/decrypt"
+    "github.com/joho/godotenv"
+    "gopkg.in/yaml.v3"
+)
+
+// SOPSFile is a secrets file encrypted with SOPS and committed next to
+// the code; its format follows the extension. Keys are environment
+// variable names (DB_PASSWORD, SESSION_SECRET).
+type SOPSFile struct {
+    Path string
+}
+
+func (f SOPSFile) Fetch(ctx context.Context) (map[string]string, error) {
+    cleartext, err := decrypt.File(f.Path, "")
+    if err != nil {
+        return nil, err
+    }
+    return parseSecretsFile(cleartext, filepath.Ext(f.Path))
+}
+
+// parseSecretsFile reads decrypted key/value pairs in the format of ext:
+// dotenv (.env), JSON or YAML, the last two as flat maps.
+func parseSecretsFile(cleartext []byte, ext string) (map[string]string, error) {
+    values := map[string]string{}
+    var err error
+    switch ext {
+    case ".env":
+        values, err = godotenv.UnmarshalBytes(cleartext)
+    case ".json":
+        err = json.Unmarshal(cleartext, &values)
+    case ".yaml", ".yml":
+        err = yaml.Unmarshal(cleartext, &values)
+    default:
+        return nil, fmt.Errorf("unsupported secrets file %q: want .env, .json or .yaml", ext)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("parse %s secrets: %w", ext, err)
+    }
+    return values, nil
+}
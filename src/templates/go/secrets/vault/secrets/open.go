@@ -0,0 +1,15 @@
+package secrets
+
+import "context"
+
+// Open returns the Vault KV v2 secret at location, written mount/path
+// (secret/myapp), or nil without one (local development, where .env
+// holds the secrets). The client finds Vault through VAULT_ADDR and signs
+// in with VAULT_TOKEN; VAULT_NAMESPACE, VAULT_CACERT and the other VAULT_*
+// variables apply as they do for the vault CLI.
+func Open(ctx context.Context, location string) (Source, error) {
+    if location == "" {
+        return nil, nil
+    }
+    return NewVault(location)
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    vault "github.com/hashicorp/vault/api"
+)
+
+// Vault reads one secret from a KV v2 secrets engine. Each key of the
+// secret is an environment variable name (DB_PASSWORD, SESSION_SECRET).
+type Vault struct {
+    kv   *vault.KVv2
+    path string
+}
+
+func NewVault(location string) (*Vault, error) {
+    mount, path, ok := strings.Cut(location, "/")
+    if !ok || mount == "" || path == "" {
+        return nil, fmt.Errorf("SECRETS_PATH=%q: want a KV v2 mount and secret path such as secret/myapp", location)
+    }
+
+    cfg := vault.DefaultConfig()
+    if cfg.Error != nil {
+        return nil, fmt.Errorf("vault config: %w", cfg.Error)
+    }
+    client, err := vault.NewClient(cfg)
+    if err != nil {
+        return nil, fmt.Errorf("vault client: %w", err)
+    }
+    return &Vault{kv: client.KVv2(mount), path: path}, nil
+}
+
+func (v *Vault) Fetch(ctx context.Context) (map[string]string, error) {
+    secret, err := v.kv.Get(ctx, v.path)
+    if err != nil {
+        return nil, err
+    }
+    values := make(map[string]string, len(secret.Data))
+    for name, value := range secret.Data {
+        s, ok := value.(string)
+        if !ok {
+            return nil, fmt.Errorf("%s: want a string value, got %T", name, value)
+        }
+        values[name] = s
+    }
+    return values, nil
+}
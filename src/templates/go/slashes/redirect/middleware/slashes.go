@@ -0,0 +1,35 @@
+package middleware
+
+import (
+    "net/http"
+    "strings"
+)
+
+// TrailingSlashes is the trailing-slash handling picked at generation
+// (--trailing-slash redirect): /items/ gets a 301 to /items.
+func TrailingSlashes(prefix string) func(http.Handler) http.Handler {
+    return RedirectSlashes(prefix)
+}
+
+// RedirectSlashes sends a 301 from /items/ to /items like chi's version,
+// but keeps prefix (the app's base path, already stripped from the request)
+// in the Location so redirects work behind a path-rewriting proxy.
+func RedirectSlashes(prefix string) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            path := r.URL.Path
+            if len(path) > 1 && strings.HasSuffix(path, "/") {
+                // Collapse leading slashes (and backslashes, which
+                // browsers read as slashes) so "//evil.example/" can't
+                // become a protocol-relative redirect
+                target := prefix + "/" + strings.TrimLeft(path[:len(path)-1], "/\\")
+                if r.URL.RawQuery != "" {
+                    target += "?" + r.URL.RawQuery
+                }
+                http.Redirect(w, r, target, http.StatusMovedPermanently)
+                return
+            }
+            next.ServeHTTP(w, r)
+        })
+    }
+}
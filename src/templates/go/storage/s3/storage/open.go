@@ -0,0 +1,32 @@
+package storage
+
+import (
+    "context"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    awsconfig "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/service/s3"
+    "myapp/config"
+)
+
+// Open returns the attachment storage: the S3 bucket cfg.Bucket, or files
+// in cfg.Dir when no bucket is set (local development). Credentials and
+// region come from the default AWS chain (AWS_ACCESS_KEY_ID,
+// AWS_REGION, ~/.aws, instance roles); cfg.Endpoint points it at an
+// S3-compatible service instead.
+func Open(ctx context.Context, cfg config.Storage) (Blob, error) {
+    if cfg.Bucket == "" {
+        return &Local{Dir: cfg.Dir}, nil
+    }
+
+    awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+    if err != nil {
+        return nil, err
+    }
+    client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+        if cfg.Endpoint != "" {
+            o.BaseEndpoint = aws.String(cfg.Endpoint)
+        }
+        o.UsePathStyle = cfg.PathStyle
+    })
+    return NewS3(client, cfg.Bucket, cfg.Prefix, cfg.URLTTL), nil
+}
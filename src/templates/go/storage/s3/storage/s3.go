@@ -0,0 +1,92 @@
+package storage
+
+import (
+    "context"
+    "errors"
+    "io"
+    "path"
+    "time"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+    "github.com/aws/aws-sdk-go-v2/service/s3"
+    "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3 keeps blobs in an S3 (or S3-compatible) bucket, under prefix.
+type S3 struct {
+    client   *s3.Client
+    presign  *s3.PresignClient
+    uploader *manager.Uploader
+    bucket   string
+    prefix   string
+    urlTTL   time.Duration
+}
+
+func NewS3(client *s3.Client, bucket, prefix string, urlTTL time.Duration) *S3 {
+    return &S3{
+        client:   client,
+        presign:  s3.NewPresignClient(client),
+        uploader: manager.NewUploader(client),
+        bucket:   bucket,
+        prefix:   prefix,
+        urlTTL:   urlTTL,
+    }
+}
+
+func (s *S3) key(key string) string {
+    return path.Join(s.prefix, path.Base(key))
+}
+
+// Put streams r to the bucket, in parts when it is large, so uploads are
+// never held in memory whole.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+    _, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+        Bucket:      aws.String(s.bucket),
+        Key:         aws.String(s.key(key)),
+        Body:        r,
+        ContentType: aws.String(contentType),
+    })
+    return err
+}
+
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, time.Time, error) {
+    out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+        Bucket: aws.String(s.bucket),
+        Key:    aws.String(s.key(key)),
+    })
+    var missing *types.NoSuchKey
+    if errors.As(err, &missing) {
+        return nil, time.Time{}, ErrNotFound
+    }
+    if err != nil {
+        return nil, time.Time{}, err
+    }
+    return out.Body, aws.ToTime(out.LastModified), nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+    _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+        Bucket: aws.String(s.bucket),
+        Key:    aws.String(s.key(key)),
+    })
+    return err
+}
+
+// URL presigns a GET valid for urlTTL. The bucket serves it with the
+// given Content-Disposition, so downloads keep their original filename.
+func (s *S3) URL(ctx context.Context, key, disposition string) (string, error) {
+    req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+        Bucket:                     aws.String(s.bucket),
+        Key:                        aws.String(s.key(key)),
+        ResponseContentDisposition: aws.String(disposition),
+    }, s3.WithPresignExpires(s.urlTTL))
+    if err != nil {
+        return "", err
+    }
+    return req.URL, nil
+}
+
+func (s *S3) Ping(ctx context.Context) error {
+    _, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+    return err
+}
@@ -0,0 +1,106 @@
+import { test, before, after } from 'node:test';
+import assert from 'node:assert/strict';
+import { createApp } from '../src/app.js';
+import { loadConfig } from '../src/config.js';
+import { MemoryStore } from '../src/store.js';
+
+// Each test talks to the app over HTTP on a random port, with a fresh
+// store it can also inspect directly
+let server;
+let base;
+const store = new MemoryStore();
+
+before(async () => {
+  const app = createApp({ config: loadConfig({ NODE_ENV: 'test' }), store });
+  server = app.listen(0);
+  await new Promise(resolve => server.once('listening', resolve));
+  base = `http://localhost:${server.address().port}`;
+});
+
+after(() => {
+  server.close();
+});
+
+const htmx = { 'HX-Request': 'true' };
+const form = fields => ({
+  method: 'POST',
+  headers: { ...htmx, 'Content-Type': 'application/x-www-form-urlencoded' },
+  body: new URLSearchParams(fields)
+});
+
+test('healthz and readyz answer ok', async () => {
+  for (const path of ['/healthz', '/readyz']) {
+    const res = await fetch(`${base}${path}`);
+    assert.equal(res.status, 200, path);
+    assert.match(res.headers.get('content-type'), /json/);
+  }
+});
+
+test('home page loads the list with htmx', async () => {
+  const res = await fetch(`${base}/`);
+  assert.equal(res.status, 200);
+  const body = await res.text();
+  assert.match(body, /<!DOCTYPE html>/);
+  assert.match(body, /hx-get="\/items"/);
+});
+
+test('creating an item renders the list', async () => {
+  const res = await fetch(`${base}/items`, form({ title: 'Buy milk', description: 'Two litres' }));
+  assert.equal(res.status, 201);
+  const body = await res.text();
+  assert.match(body, /Buy milk/);
+  assert.doesNotMatch(body, /<html/);
+  const { items } = await store.list({ q: 'milk' });
+  assert.equal(items.length, 1);
+});
+
+test('an invalid item re-renders the form with errors', async () => {
+  const res = await fetch(`${base}/items`, form({ title: '  ' }));
+  assert.equal(res.status, 422);
+  assert.equal(res.headers.get('hx-retarget'), '#new-item');
+  assert.match(await res.text(), /Title is required/);
+});
+
+test('without htmx, creating an item redirects home', async () => {
+  const res = await fetch(`${base}/items`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/x-www-form-urlencoded' },
+    body: new URLSearchParams({ title: 'No script' }),
+    redirect: 'manual'
+  });
+  assert.equal(res.status, 303);
+  assert.equal(res.headers.get('location'), '/');
+});
+
+test('items can be viewed, updated and deleted', async () => {
+  const item = await store.create({ title: 'Draft' });
+
+  let res = await fetch(`${base}/items/${item.id}`);
+  assert.equal(res.status, 200);
+  assert.match(await res.text(), /<html[\s\S]*Draft/);
+
+  res = await fetch(`${base}/items/${item.id}`, { ...form({ title: 'Final' }), method: 'PUT' });
+  assert.equal(res.status, 200);
+  assert.match(await res.text(), /Final/);
+  assert.equal((await store.get(item.id)).title, 'Final');
+
+  res = await fetch(`${base}/items/${item.id}`, { ...form({ title: '' }), method: 'PUT' });
+  assert.equal(res.status, 422);
+
+  res = await fetch(`${base}/items/${item.id}`, { method: 'DELETE', headers: htmx });
+  assert.equal(res.status, 200);
+  await assert.rejects(store.get(item.id));
+});
+
+test('unknown items are 404s', async () => {
+  const res = await fetch(`${base}/items/missing`, { headers: htmx });
+  assert.equal(res.status, 404);
+});
+
+test('search filters the list', async () => {
+  await store.create({ title: 'Walk the dog' });
+  const res = await fetch(`${base}/items?q=dog`, { headers: htmx });
+  const body = await res.text();
+  assert.match(body, /Walk the dog/);
+  assert.doesNotMatch(body, /Buy milk/);
+});
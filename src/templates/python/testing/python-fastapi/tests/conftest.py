@@ -0,0 +1,17 @@
+import pytest
+from fastapi.testclient import TestClient
+
+from app.config import Settings
+from app.main import create_app
+
+
+@pytest.fixture
+def settings() -> Settings:
+    # A fresh in-memory SQLite database per test, whatever .env says
+    return Settings(_env_file=None, database_url="sqlite://", create_tables=True, page_size=2)
+
+
+@pytest.fixture
+def client(settings: Settings):
+    with TestClient(create_app(settings)) as client:
+        yield client
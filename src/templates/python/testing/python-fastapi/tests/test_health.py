@@ -0,0 +1,27 @@
+from fastapi.testclient import TestClient
+
+from app.config import Settings
+from app.main import create_app
+
+
+def test_healthz(client):
+    response = client.get("/healthz")
+    assert response.status_code == 200
+    assert response.json() == {"status": "ok"}
+
+
+def test_readyz_checks_the_database(client):
+    response = client.get("/readyz")
+    assert response.status_code == 200
+    assert response.json()["checks"]["database"]["status"] == "up"
+
+
+def test_readyz_fails_without_the_database(tmp_path):
+    settings = Settings(_env_file=None, database_url=f"sqlite:///{tmp_path}/missing/app.db", create_tables=False)
+    with TestClient(create_app(settings)) as client:
+        response = client.get("/readyz")
+    assert response.status_code == 503
+    assert response.json()["status"] == "unhealthy"
+    # Liveness doesn't depend on the database
+    with TestClient(create_app(settings)) as client:
+        assert client.get("/healthz").status_code == 200
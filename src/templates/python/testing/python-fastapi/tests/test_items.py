@@ -0,0 +1,73 @@
+import uuid
+
+
+def create(client, **fields):
+    response = client.post("/api/items", json={"title": "Buy milk", **fields})
+    assert response.status_code == 201, response.text
+    return response.json()
+
+
+def test_create_and_get(client):
+    item = create(client, description="  Two litres  ")
+    assert item["description"] == "Two litres"
+
+    response = client.get(f"/api/items/{item['id']}")
+    assert response.status_code == 200
+    assert response.json()["title"] == "Buy milk"
+
+
+def test_create_sets_location(client):
+    response = client.post("/api/items", json={"title": "Located"})
+    assert response.headers["location"] == f"/api/items/{response.json()['id']}"
+
+
+def test_create_validates(client):
+    for body in ({}, {"title": "   "}, {"title": "x" * 201}, {"title": "ok", "description": "x" * 5001}):
+        response = client.post("/api/items", json=body)
+        assert response.status_code == 422, body
+
+
+def test_list_pages_and_searches(client):
+    for title in ("alpha", "beta", "gamma"):
+        create(client, title=title)
+
+    page = client.get("/api/items").json()
+    assert page["total"] == 3
+    assert page["limit"] == 2
+    assert [item["title"] for item in page["items"]] == ["gamma", "beta"]
+
+    page = client.get("/api/items", params={"offset": 2}).json()
+    assert [item["title"] for item in page["items"]] == ["alpha"]
+
+    page = client.get("/api/items", params={"q": "ALP"}).json()
+    assert page["total"] == 1
+
+
+def test_list_caps_the_limit(client, settings):
+    page = client.get("/api/items", params={"limit": 1000}).json()
+    assert page["limit"] == settings.max_page_size
+
+
+def test_update_changes_only_sent_fields(client):
+    item = create(client, description="keep me")
+    response = client.patch(f"/api/items/{item['id']}", json={"title": "Buy oat milk"})
+    assert response.status_code == 200
+    assert response.json()["title"] == "Buy oat milk"
+    assert response.json()["description"] == "keep me"
+
+    response = client.patch(f"/api/items/{item['id']}", json={"title": ""})
+    assert response.status_code == 422
+
+
+def test_delete(client):
+    item = create(client)
+    assert client.delete(f"/api/items/{item['id']}").status_code == 204
+    assert client.get(f"/api/items/{item['id']}").status_code == 404
+
+
+def test_unknown_items_are_404(client):
+    missing = uuid.uuid4()
+    assert client.get(f"/api/items/{missing}").status_code == 404
+    assert client.patch(f"/api/items/{missing}", json={"title": "x"}).status_code == 404
+    assert client.delete(f"/api/items/{missing}").status_code == 404
+    assert client.get("/api/items/not-a-uuid").status_code == 422
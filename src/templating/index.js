@@ -0,0 +1,150 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { execa } from 'execa';
+
+// Project variables available to templates as {{ .Name }}
+export const variableNames = ['ModulePath', 'AppName', 'Port', 'Author', 'Year', 'Registry'];
+
+// Render {{ .Name }} placeholders. Unknown names are an error so a typo in
+// a template fails generation instead of shipping a literal placeholder.
+export function renderString(template, vars) {
+  return template.replace(/\{\{\s*\.(\w+)\s*\}\}/g, (match, name) => {
+    if (!(name in vars)) {
+      throw new Error(`Unknown template variable "${name}" (expected ${variableNames.join(', ')})`);
+    }
+    return String(vars[name]);
+  });
+}
+
+// Work out a project's variables from CLI options, defaulting the module
+// path and app name to the directory name and the author to git's user.name.
+// Regenerating a project (upgrade) passes the recorded author and year back.
+// The container registry defaults to the module path's forge (see
+// defaultRegistry) and is empty when there isn't one.
+export async function projectVariables(projectPath, options = {}, defaults = {}) {
+  const appName = path.basename(projectPath);
+  const modulePath = options.module || appName;
+  const vars = {
+    ModulePath: modulePath,
+    AppName: appName,
+    Port: String(options.port || defaults.Port || ''),
+    Author: options.author ?? await gitUserName(),
+    Year: String(options.year || new Date().getFullYear()),
+    Registry: (options.registry || defaultRegistry(modulePath)).replace(/\/+$/, '')
+  };
+
+  if (!/^[A-Za-z0-9][\w.\-~]*(\/[\w.\-~]+)*$/.test(vars.ModulePath)) {
+    throw new Error(`Invalid module path "${vars.ModulePath}"`);
+  }
+  if (vars.Port && !/^\d{1,5}$/.test(vars.Port)) {
+    throw new Error(`Invalid port "${vars.Port}"`);
+  }
+  if (vars.Registry && !/^[a-z0-9][a-z0-9.\-]*(:\d+)?(\/[a-z0-9][a-z0-9._\-]*)*$/.test(vars.Registry)) {
+    throw new Error(`Invalid registry "${vars.Registry}" (expected a lowercase host and path, e.g. ghcr.io/acme)`);
+  }
+  return vars;
+}
+
+// github.com/Acme/shop pushes images to ghcr.io/acme, gitlab.com/acme/shop
+// to that project's registry.gitlab.com/acme/shop
+export function defaultRegistry(modulePath) {
+  const [host, ...rest] = modulePath.toLowerCase().split('/');
+  if (host === 'github.com' && rest.length > 0) {
+    return `ghcr.io/${rest[0]}`;
+  }
+  if (host === 'gitlab.com' && rest.length > 1) {
+    return `registry.gitlab.com/${rest.join('/')}`;
+  }
+  return '';
+}
+
+async function gitUserName() {
+  try {
+    const { stdout } = await execa('git', ['config', 'user.name']);
+    return stdout.trim();
+  } catch {
+    return '';
+  }
+}
+
+// Samples are committed as working projects, so instead of placeholders
+// their variables are the literal values they ship with (module "myapp",
+// port 3000, ...). Each variable is replaced only where it can appear.
+const replacements = {
+  ModulePath: {
+    files: name => ['go.mod', 'buf.gen.yaml'].includes(name) || name.endsWith('.go') || name.endsWith('.templ'),
+    replace: (text, from, to) => text
+      .replace(new RegExp(`^module ${escape(from)}$`, 'm'), `module ${to}`)
+      .replace(new RegExp(`"${escape(from)}(/[^"]*)?"`, 'g'), (match, rest = '') => `"${to}${rest}"`)
+  },
+  AppName: {
+    files: name => ['README.md', '.env.example', 'package.json', 'pyproject.toml', 'config.py', 'index.html'].includes(name),
+    replace: (text, from, to) => text.replace(new RegExp(`\\b${escape(from)}\\b`, 'g'), to)
+  },
+  Port: {
+    files: name => ['main.go', 'config.go', 'config.js', 'config.py', '.env.example', '.air.toml', 'Dockerfile', 'docker-compose.yml', 'README.md', 'vite.config.ts'].includes(name),
+    replace: (text, from, to) => text.replace(new RegExp(`\\b${escape(from)}\\b`, 'g'), to)
+  },
+  // The Python samples' default DATABASE_URL, set from --database
+  DatabaseURL: {
+    files: name => ['config.py', '.env.example', 'README.md'].includes(name),
+    replace: (text, from, to) => text.split(from).join(to)
+  }
+};
+
+function escape(value) {
+  return value.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+}
+
+export async function walk(dir) {
+  const files = [];
+  for (const entry of await fs.readdir(dir, { withFileTypes: true })) {
+    const full = path.join(dir, entry.name);
+    if (entry.isDirectory()) {
+      files.push(...await walk(full));
+    } else {
+      files.push(full);
+    }
+  }
+  return files;
+}
+
+// Render a generated project in place: *.tmpl files are run through
+// renderString and lose their suffix, and the sample's literal values
+// (sampleVars) are swapped for the project's (vars).
+export async function renderProject(projectPath, sampleVars, vars) {
+  for (const file of await walk(projectPath)) {
+    let target = file;
+    let text = await fs.readFile(file, 'utf8');
+    let changed = false;
+
+    const tmpl = file.endsWith('.tmpl');
+    if (tmpl) {
+      target = file.slice(0, -'.tmpl'.length);
+      changed = true;
+    }
+
+    // Literal values go first: a rendered module path may itself start
+    // with the sample's (myapp/services/api)
+    const name = path.basename(target);
+    for (const [variable, rule] of Object.entries(replacements)) {
+      const from = sampleVars[variable];
+      const to = vars[variable];
+      if (from && to && from !== to && rule.files(name)) {
+        const next = rule.replace(text, from, to);
+        changed = changed || next !== text;
+        text = next;
+      }
+    }
+    if (tmpl) {
+      text = renderString(text, vars);
+    }
+
+    if (changed) {
+      await fs.writeFile(target, text);
+      if (target !== file) {
+        await fs.remove(file);
+      }
+    }
+  }
+}
@@ -0,0 +1,61 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import yaml from 'js-yaml';
+import { execa } from 'execa';
+
+// Templates list their post-generation commands in this file at the
+// project root. It is consumed by the CLI and not left in the project.
+export const hooksFile = 'hooks.yaml';
+
+// Read and remove the project's hooks.yaml. Each hook is a shell command
+// run in the project directory, in order:
+//
+//   hooks:
+//     - name: Tidy Go modules
+//       run: go mod tidy
+//     - name: Initialize git
+//       run: git init
+//       optional: true    # a failure only warns
+export async function takeHooks(projectPath) {
+  const file = path.join(projectPath, hooksFile);
+  if (!(await fs.pathExists(file))) {
+    return [];
+  }
+
+  let doc;
+  try {
+    doc = yaml.load(await fs.readFile(file, 'utf8')) || {};
+  } catch (error) {
+    throw new Error(`${hooksFile}: ${error.message}`);
+  }
+  await fs.remove(file);
+
+  if (!Array.isArray(doc.hooks)) {
+    throw new Error(`${hooksFile}: expected a "hooks" list`);
+  }
+  return doc.hooks.map((hook, i) => {
+    if (!hook || typeof hook.run !== 'string' || !hook.run.trim()) {
+      throw new Error(`${hooksFile}: hook ${i + 1} needs a "run" command`);
+    }
+    return { name: hook.name || hook.run, run: hook.run, optional: hook.optional === true };
+  });
+}
+
+// Run hooks in order through the shell. A failing hook stops the rest
+// unless it is optional. report(hook, status, error) is called as each
+// hook starts ("run") and finishes ("ok", "failed" or "skipped").
+export async function runHooks(projectPath, hooks, report = () => {}) {
+  for (const hook of hooks) {
+    report(hook, 'run');
+    try {
+      await execa(hook.run, { cwd: projectPath, shell: true });
+      report(hook, 'ok');
+    } catch (error) {
+      const detail = (error.stderr || error.shortMessage || error.message).trim();
+      report(hook, hook.optional ? 'skipped' : 'failed', detail);
+      if (!hook.optional) {
+        throw new Error(`Hook "${hook.name}" failed: ${detail}`);
+      }
+    }
+  }
+}
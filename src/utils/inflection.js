@@ -0,0 +1,89 @@
+// English inflection helpers used to derive routes and labels from resource names.
+
+const irregulars = {
+  person: 'people',
+  man: 'men',
+  woman: 'women',
+  child: 'children',
+  tooth: 'teeth',
+  foot: 'feet',
+  leaf: 'leaves',
+  loaf: 'loaves',
+  thief: 'thieves',
+  mouse: 'mice',
+  goose: 'geese',
+  ox: 'oxen',
+  datum: 'data',
+  criterion: 'criteria',
+  analysis: 'analyses',
+  index: 'indices',
+  matrix: 'matrices',
+  vertex: 'vertices',
+  status: 'statuses',
+  quiz: 'quizzes'
+};
+
+const uncountables = new Set([
+  'equipment', 'information', 'rice', 'money', 'species', 'series',
+  'fish', 'sheep', 'deer', 'news', 'metadata', 'feedback'
+]);
+
+const pluralRules = [
+  [/(quiz)$/i, '$1zes'],
+  [/(matr|vert|ind)(ix|ex)$/i, '$1ices'],
+  [/(x|ch|ss|sh|zz)$/i, '$1es'],
+  [/([^aeiouy])y$/i, '$1ies'],
+  [/(hive)$/i, '$1s'],
+  [/(?:([^f])fe|([lr])f)$/i, '$1$2ves'],
+  [/sis$/i, 'ses'],
+  [/([ti])um$/i, '$1a'],
+  [/(buffal|tomat|potat|her)o$/i, '$1oes'],
+  [/(bu|alia|statu)s$/i, '$1ses'],
+  [/(octop|vir)us$/i, '$1i'],
+  [/s$/i, 's'],
+  [/$/, 's']
+];
+
+// Helper: Keep the casing of the original word's first letter
+function matchCase(source, word) {
+  if (source[0] === source[0].toUpperCase()) {
+    return word[0].toUpperCase() + word.slice(1);
+  }
+  return word;
+}
+
+export function pluralize(word) {
+  const lower = word.toLowerCase();
+
+  if (uncountables.has(lower)) return word;
+  if (irregulars[lower]) return matchCase(word, irregulars[lower]);
+
+  for (const [pattern, replacement] of pluralRules) {
+    if (pattern.test(word)) {
+      return word.replace(pattern, replacement);
+    }
+  }
+  return word;
+}
+
+// Split PascalCase/camelCase/snake_case names into lowercase words
+export function words(name) {
+  return name
+    .replace(/([a-z0-9])([A-Z])/g, '$1 $2')
+    .split(/[\s_-]+/)
+    .filter(Boolean)
+    .map(w => w.toLowerCase());
+}
+
+// "OrderLine" -> "order-lines" (pluralizes the last word only)
+export function routeSegment(name, plural) {
+  if (plural) return words(plural).join('-');
+  const parts = words(name);
+  parts[parts.length - 1] = pluralize(parts[parts.length - 1]);
+  return parts.join('-');
+}
+
+// "OrderLine" -> "Order Line"
+export function humanize(name) {
+  return words(name).map(w => w[0].toUpperCase() + w.slice(1)).join(' ');
+}
@@ -0,0 +1,44 @@
+import fs from 'fs-extra';
+
+// Find the "// stack-app:<name>" marker line, returning its index and
+// indentation
+function findMarker(lines, marker) {
+  const index = lines.findIndex(line => line.trim() === `// stack-app:${marker}`);
+  if (index === -1) {
+    throw new Error(`marker "// stack-app:${marker}" not found`);
+  }
+  return { index, indent: lines[index].match(/^\s*/)[0] };
+}
+
+// Insert code just above a marker comment, indented like the marker.
+// Lines already present (ignoring indentation) are skipped, so running a
+// generator twice doesn't register anything twice.
+export function insertAtMarker(source, marker, code) {
+  const lines = source.split('\n');
+  const { index, indent } = findMarker(lines, marker);
+
+  const present = new Set(lines.map(line => line.trim()));
+  const added = code
+    .split('\n')
+    .filter(line => !present.has(line.trim()))
+    .map(line => (line ? indent + line : line));
+
+  lines.splice(index, 0, ...added);
+  return lines.join('\n');
+}
+
+// Apply every edit to a file, or none of them: a missing marker throws
+// before anything is written.
+export async function injectFile(filePath, edits) {
+  let source = await fs.readFile(filePath, 'utf8');
+  for (const { marker, code } of edits) {
+    try {
+      source = insertAtMarker(source, marker, code);
+    } catch (error) {
+      throw new Error(`${filePath}: ${error.message}`);
+    }
+  }
+  const tmp = `${filePath}.tmp`;
+  await fs.writeFile(tmp, source);
+  await fs.rename(tmp, filePath);
+}
@@ -0,0 +1,75 @@
+import chalk from 'chalk';
+import fs from 'fs-extra';
+import path from 'node:path';
+import { execa } from 'execa';
+import { walk } from '../templating/index.js';
+
+// Compare a generated project (sourceDir) with the directory it is about
+// to be written into. Each file is new (not in the target), same
+// (identical bytes) or changed; files only the target has are left alone
+// and not listed.
+export async function planPlacement(sourceDir, targetDir) {
+  const files = (await walk(sourceDir)).map(file => path.relative(sourceDir, file).split(path.sep).join('/')).sort();
+  const entries = [];
+
+  for (const file of files) {
+    const target = path.join(targetDir, file);
+    let status = 'new';
+    if (await fs.pathExists(target)) {
+      const [ours, theirs] = await Promise.all([fs.readFile(target), fs.readFile(path.join(sourceDir, file))]);
+      status = ours.equals(theirs) ? 'same' : 'changed';
+    }
+    entries.push({ file, status });
+  }
+  return entries;
+}
+
+const statusLabels = {
+  new: chalk.green('new'),
+  same: chalk.dim('unchanged'),
+  changed: chalk.yellow('changed')
+};
+
+// Print entries as a directory tree. Statuses are shown only when the
+// project is going into an existing directory (withStatus).
+export function printTree(entries, withStatus) {
+  const root = new Map();
+  for (const entry of entries) {
+    const parts = entry.file.split('/');
+    let node = root;
+    for (const dir of parts.slice(0, -1)) {
+      if (!node.has(`${dir}/`)) node.set(`${dir}/`, new Map());
+      node = node.get(`${dir}/`);
+    }
+    node.set(parts[parts.length - 1], entry);
+  }
+
+  const print = (node, indent) => {
+    // Directories first, then files, each alphabetically
+    const names = [...node.keys()].sort((a, b) => (b.endsWith('/') - a.endsWith('/')) || a.localeCompare(b));
+    names.forEach((name, i) => {
+      const last = i === names.length - 1;
+      const child = node.get(name);
+      const branch = `${indent}${last ? '└── ' : '├── '}`;
+      if (child instanceof Map) {
+        console.log(`${branch}${chalk.blue(name)}`);
+        print(child, `${indent}${last ? '    ' : '│   '}`);
+      } else {
+        console.log(`${branch}${name}${withStatus ? ` ${chalk.dim('(')}${statusLabels[child.status]}${chalk.dim(')')}` : ''}`);
+      }
+    });
+  };
+  print(root, '   ');
+}
+
+// Show the unified diff that writing theirs over ours would make. Falls
+// back to a note when git isn't available.
+export async function showDiff(ours, theirs, file) {
+  try {
+    const { stdout } = await execa('git', ['diff', '--no-index', '--color=always', '--', ours, theirs], { reject: false });
+    // Both paths are absolute; show the project-relative name instead
+    console.log(stdout.split(ours).join(`/${file}`).split(theirs).join(`/${file}`));
+  } catch {
+    console.log(chalk.dim(`   ${file} differs (install git to see the diff)`));
+  }
+}
@@ -0,0 +1,206 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import { execa } from 'execa';
+import { registryHome } from './registry.js';
+import { cliVersion } from './project-manifest.js';
+import { projectVariables } from '../templating/index.js';
+
+// Plugins are executables named stack-app-<name>, found in
+// ~/.stack-app/plugins and then on PATH (the first one wins, as for
+// kubectl). "create-stack-app <name> ..." runs the plugin with the rest of
+// the arguments. Plugins that answer "manifest" with JSON can also offer
+// templates (new --template <name>:<template>) and features
+// (add <name>:<feature>); see docs/PLUGINS.md for the protocol.
+export const pluginPrefix = 'stack-app-';
+export const pluginApiVersion = 1;
+
+export function pluginDir() {
+  return path.join(registryHome(), 'plugins');
+}
+
+// "acme:api" refers to template (or feature) "api" of plugin "acme"
+export function isPluginRef(id) {
+  return /^[a-z0-9][a-z0-9_-]*:[\w.-]+$/.test(id);
+}
+
+function executableNames(file) {
+  if (process.platform !== 'win32') {
+    return [file];
+  }
+  const extensions = (process.env.PATHEXT || '.EXE;.CMD;.BAT').split(';').map(ext => ext.toLowerCase());
+  const ext = path.extname(file).toLowerCase();
+  return extensions.includes(ext) ? [file.slice(0, -ext.length)] : [];
+}
+
+async function isExecutable(file) {
+  try {
+    const stat = await fs.stat(file);
+    if (!stat.isFile()) return false;
+    if (process.platform !== 'win32') {
+      await fs.access(file, fs.constants.X_OK);
+    }
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+// Every plugin by name, with the executables it shadows further down the
+// search path
+export async function discoverPlugins() {
+  const dirs = [pluginDir(), ...(process.env.PATH || '').split(path.delimiter).filter(Boolean)];
+  const plugins = new Map();
+
+  for (const dir of dirs) {
+    let files;
+    try {
+      files = await fs.readdir(dir);
+    } catch {
+      continue;
+    }
+    for (const file of files.sort()) {
+      const [base] = executableNames(file);
+      if (!base || !base.startsWith(pluginPrefix) || base === pluginPrefix) continue;
+      const executable = path.join(dir, file);
+      if (!(await isExecutable(executable))) continue;
+
+      const name = base.slice(pluginPrefix.length);
+      if (plugins.has(name)) {
+        plugins.get(name).shadowed.push(executable);
+      } else {
+        plugins.set(name, { name, path: executable, shadowed: [] });
+      }
+    }
+  }
+  return [...plugins.values()].sort((a, b) => a.name.localeCompare(b.name));
+}
+
+export async function findPlugin(name) {
+  return (await discoverPlugins()).find(plugin => plugin.name === name) || null;
+}
+
+// Plugins learn how they were called, and where the CLI keeps its state,
+// from the environment
+async function pluginEnv(extra = {}) {
+  return {
+    ...process.env,
+    STACK_APP_CLI: process.argv[1],
+    STACK_APP_VERSION: await cliVersion(),
+    STACK_APP_HOME: registryHome(),
+    STACK_APP_PLUGIN_API: String(pluginApiVersion),
+    ...extra
+  };
+}
+
+// Run a plugin as a subcommand, attached to the terminal. Returns its exit
+// code.
+export async function runPlugin(plugin, args) {
+  const { exitCode } = await execa(plugin.path, args, { stdio: 'inherit', reject: false, env: await pluginEnv() });
+  return exitCode;
+}
+
+// Ask a plugin what it offers:
+//   { "apiVersion": 1, "name": "acme", "version": "0.3.0", "description": "...",
+//     "templates": [{ "id": "api", "name": "Acme API", "language": "Go", "description": "..." }],
+//     "features": [{ "name": "audit-log", "description": "..." }] }
+// Plugins that are only commands exit non-zero, and this throws.
+export async function readPluginManifest(plugin) {
+  let stdout;
+  try {
+    ({ stdout } = await execa(plugin.path, ['manifest'], { env: await pluginEnv(), timeout: 10000 }));
+  } catch (error) {
+    throw new Error(`${plugin.name}: "manifest" failed${error.stderr ? `: ${error.stderr.trim()}` : ''}`);
+  }
+
+  let manifest;
+  try {
+    manifest = JSON.parse(stdout);
+  } catch (error) {
+    throw new Error(`${plugin.name}: "manifest" did not print JSON (${error.message})`);
+  }
+  if (manifest.apiVersion !== pluginApiVersion) {
+    throw new Error(`${plugin.name}: plugin API version ${manifest.apiVersion} is not supported (expected ${pluginApiVersion})`);
+  }
+
+  const templates = Array.isArray(manifest.templates) ? manifest.templates : [];
+  const features = Array.isArray(manifest.features) ? manifest.features : [];
+  for (const entry of [...templates.map(t => t.id), ...features.map(f => f.name)]) {
+    if (typeof entry !== 'string' || !/^[\w.-]+$/.test(entry)) {
+      throw new Error(`${plugin.name}: template ids and feature names must be letters, numbers, dots, dashes or underscores`);
+    }
+  }
+  return {
+    name: plugin.name,
+    version: String(manifest.version || '0.0.0'),
+    description: manifest.description || '',
+    templates: templates.map(template => ({
+      id: template.id,
+      name: template.name || template.id,
+      description: template.description || '',
+      language: template.language || 'Unknown',
+      features: Array.isArray(template.features) ? template.features : []
+    })),
+    features: features.map(feature => ({ name: feature.name, description: feature.description || '' }))
+  };
+}
+
+async function pluginEntry(ref, kind) {
+  const [name, id] = ref.split(':');
+  const plugin = await findPlugin(name);
+  if (!plugin) {
+    throw new Error(`Plugin "${name}" not found: install ${pluginPrefix}${name} on PATH or in ${pluginDir()}`);
+  }
+  const manifest = await readPluginManifest(plugin);
+  const entry = manifest[kind].find(item => (item.id || item.name) === id);
+  if (!entry) {
+    throw new Error(`Plugin "${name}" ${manifest.version} has no ${kind === 'templates' ? 'template' : 'feature'} "${id}"`);
+  }
+  return { plugin, manifest, entry };
+}
+
+// Resolve "acme:api" into a template config for the generator
+export async function resolvePluginTemplate(ref) {
+  const { plugin, manifest, entry } = await pluginEntry(ref, 'templates');
+  return {
+    name: entry.name,
+    description: entry.description,
+    language: entry.language,
+    features: entry.features,
+    plugin: { name: plugin.name, path: plugin.path, template: entry.id, version: manifest.version }
+  };
+}
+
+// "generate <template> <dir>": the plugin writes the project into the
+// directory, which already holds the common files (README, .gitignore, and
+// the --features ones); it may replace them. Features and stack options
+// come in the environment, with the author and year resolved as
+// .stackapp.yaml records them so regenerating gives the same options.
+export async function generatePluginTemplate(projectPath, plugin, features, options = {}) {
+  const vars = await projectVariables(projectPath, options);
+  const env = await pluginEnv({
+    STACK_APP_PROJECT_NAME: path.basename(projectPath),
+    STACK_APP_FEATURES: features.join(','),
+    STACK_APP_OPTIONS: JSON.stringify({ ...options, author: vars.Author, year: Number(vars.Year) })
+  });
+  try {
+    await execa(plugin.path, ['generate', plugin.template, projectPath], { env });
+  } catch (error) {
+    throw new Error(`${plugin.name} generate ${plugin.template} failed: ${(error.stderr || error.message).trim()}`);
+  }
+}
+
+// "add <feature> [value]": the plugin changes the project in place, run
+// from its root. It prints what it changes, and only that with
+// STACK_APP_DRY_RUN=1.
+export async function addPluginFeature(ref, value, projectPath, options = {}) {
+  const { plugin, entry } = await pluginEntry(ref, 'features');
+  const env = await pluginEnv({
+    STACK_APP_PROJECT_DIR: projectPath,
+    ...(options.dryRun && { STACK_APP_DRY_RUN: '1' })
+  });
+  const args = ['add', entry.name, ...(value ? [value] : [])];
+  const { exitCode } = await execa(plugin.path, args, { cwd: projectPath, stdio: 'inherit', reject: false, env });
+  if (exitCode !== 0) {
+    throw new Error(`${plugin.name} add ${entry.name} exited with status ${exitCode}`);
+  }
+}
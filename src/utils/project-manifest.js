@@ -0,0 +1,137 @@
+import fs from 'fs-extra';
+import path from 'node:path';
+import crypto from 'node:crypto';
+import yaml from 'js-yaml';
+import { execa } from 'execa';
+import { fileURLToPath } from 'node:url';
+import { walk, projectVariables } from '../templating/index.js';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+// Every generated project records how it was made in this file at its
+// root, so "upgrade" can regenerate it with a newer template later
+export const projectManifestFile = '.stackapp.yaml';
+
+const header = [
+  '# Written by create-stack-app. "create-stack-app upgrade" uses it to merge',
+  '# newer template versions into this project; keep it under version control.',
+  ''
+].join('\n');
+
+// Version of the built-in templates: they ship with the CLI
+export async function cliVersion() {
+  const pkg = await fs.readJson(path.join(__dirname, '..', '..', 'package.json'));
+  return pkg.version;
+}
+
+// sha256 of every file in the project, keyed by its slash-separated path.
+// These are the "base" side of the next upgrade's three-way merge.
+export async function hashProject(projectPath) {
+  const files = {};
+  for (const file of await walk(projectPath)) {
+    const rel = path.relative(projectPath, file).split(path.sep).join('/');
+    if (rel === projectManifestFile || rel.split('/').includes('.git')) {
+      continue;
+    }
+    files[rel] = hashContent(await fs.readFile(file));
+  }
+  return Object.fromEntries(Object.entries(files).sort(([a], [b]) => a.localeCompare(b)));
+}
+
+export function hashContent(content) {
+  return crypto.createHash('sha256').update(content).digest('hex');
+}
+
+// Record a freshly generated project. generatorOptions are stored with the
+// author and year resolved so regenerating later produces the same files.
+//
+//   name: my-app
+//   template: go-htmx
+//   version: 1.0.0
+//   features: [docker, testing]
+//   options: { database: postgres, module: github.com/acme/app, author: Acme, year: 2026 }
+//   files: { main.go: <sha256>, ... }
+export async function writeProjectManifest(projectPath, { templateId, templateConfig, features, generatorOptions }) {
+  const vars = await projectVariables(projectPath, generatorOptions);
+  const options = { ...generatorOptions, author: vars.Author, year: Number(vars.Year) };
+  for (const key of Object.keys(options)) {
+    if (options[key] === undefined) delete options[key];
+  }
+
+  const manifest = {
+    name: path.basename(projectPath),
+    template: templateId,
+    version: await templateVersion(templateConfig),
+    ...(templateConfig.source && { source: { url: templateConfig.source.url, ref: templateConfig.source.ref || null } }),
+    features,
+    options,
+    files: await hashProject(projectPath)
+  };
+  await saveProjectManifest(projectPath, manifest);
+  return manifest;
+}
+
+export async function saveProjectManifest(projectPath, manifest) {
+  await fs.writeFile(path.join(projectPath, projectManifestFile), header + yaml.dump(manifest, { lineWidth: -1 }));
+}
+
+export async function readProjectManifest(projectPath) {
+  const file = path.join(projectPath, projectManifestFile);
+  if (!(await fs.pathExists(file))) {
+    throw new Error(`${projectManifestFile} not found in ${projectPath}; was it generated by create-stack-app?`);
+  }
+
+  let manifest;
+  try {
+    manifest = yaml.load(await fs.readFile(file, 'utf8')) || {};
+  } catch (error) {
+    throw new Error(`${projectManifestFile}: ${error.message}`);
+  }
+  if (typeof manifest.template !== 'string' || !manifest.files || typeof manifest.files !== 'object') {
+    throw new Error(`${projectManifestFile}: expected "template" and "files"`);
+  }
+  return {
+    ...manifest,
+    name: manifest.name || path.basename(path.resolve(projectPath)),
+    version: String(manifest.version ?? ''),
+    features: Array.isArray(manifest.features) ? manifest.features : [],
+    options: manifest.options || {}
+  };
+}
+
+// Built-in templates are versioned with the CLI; remote ones by the tag or
+// branch they were fetched at (the commit for unpinned templates), and
+// plugin ones with their plugin
+export async function templateVersion(templateConfig) {
+  if (templateConfig.plugin) {
+    return templateConfig.plugin.version;
+  }
+  if (!templateConfig.source) {
+    return cliVersion();
+  }
+  if (templateConfig.source.ref) {
+    return templateConfig.source.ref;
+  }
+  try {
+    const { stdout } = await execa('git', ['rev-parse', 'HEAD'], { cwd: templateConfig.source.dir });
+    return stdout.trim();
+  } catch {
+    return 'HEAD';
+  }
+}
+
+// Compare two file hash maps (as from hashProject): files whose content
+// differs, files only in expected and files only in actual
+export function compareHashes(expected, actual) {
+  const changed = [];
+  const missing = [];
+  for (const [file, hash] of Object.entries(expected)) {
+    if (!(file in actual)) {
+      missing.push(file);
+    } else if (actual[file] !== hash) {
+      changed.push(file);
+    }
+  }
+  const extra = Object.keys(actual).filter(file => !(file in expected));
+  return { changed, missing, extra };
+}
@@ -0,0 +1,138 @@
+import fs from 'fs-extra';
+import os from 'node:os';
+import path from 'node:path';
+import { execa } from 'execa';
+
+// Registered remote templates and fetched checkouts live under
+// ~/.stack-app (override with STACK_APP_HOME)
+export function registryHome() {
+  return process.env.STACK_APP_HOME || path.join(os.homedir(), '.stack-app');
+}
+
+const registryFile = () => path.join(registryHome(), 'templates.json');
+const cacheDir = () => path.join(registryHome(), 'cache');
+
+// Every remote template has this file at its root
+export const manifestName = 'stack-app.json';
+
+// A reference is remote when it looks like a repository location rather
+// than a built-in or registered template id
+export function isRemoteRef(ref) {
+  return ref.includes('/');
+}
+
+// Split "github.com/org/tpl@v1.2.0" into a clonable URL and a ref. Bare
+// host paths are fetched over https; https://, ssh:// and scp-style
+// (git@host:org/repo) URLs are used as given. The ref is a tag or branch.
+export function parseTemplateRef(spec) {
+  let location = spec;
+  let ref = null;
+
+  const at = spec.lastIndexOf('@');
+  if (at > spec.lastIndexOf('/')) {
+    location = spec.slice(0, at);
+    ref = spec.slice(at + 1);
+    if (!/^\w[\w.-]*$/.test(ref)) {
+      throw new Error(`Invalid template version "${ref}"`);
+    }
+  }
+
+  let url = location;
+  if (!/^[a-z+]+:\/\//.test(location) && !/^[\w.-]+@[\w.-]+:/.test(location)) {
+    url = `https://${location}`;
+  }
+  return { url, ref };
+}
+
+export async function loadRegistry() {
+  const file = registryFile();
+  if (!(await fs.pathExists(file))) {
+    return { templates: {} };
+  }
+  return fs.readJson(file);
+}
+
+export async function saveRegistry(registry) {
+  await fs.ensureDir(registryHome());
+  await fs.writeJson(registryFile(), registry, { spaces: 2 });
+}
+
+// Shallow-clone url at ref into the cache. Pinned refs are reused once
+// fetched; unpinned ones track the default branch and are fetched fresh.
+export async function fetchTemplate(url, ref) {
+  const key = url.replace(/^[a-z+]+:\/\//, '').replace(/[^\w.-]+/g, '_');
+  const dir = path.join(cacheDir(), `${key}@${ref || 'HEAD'}`);
+
+  if (ref && (await fs.pathExists(path.join(dir, manifestName)))) {
+    return dir;
+  }
+  await fs.remove(dir);
+  await fs.ensureDir(cacheDir());
+
+  const args = ['clone', '--depth', '1', '--quiet'];
+  if (ref) {
+    args.push('--branch', ref);
+  }
+  try {
+    await execa('git', [...args, '--', url, dir]);
+  } catch (error) {
+    await fs.remove(dir);
+    throw new Error(`Could not fetch ${url}${ref ? `@${ref}` : ''}: ${(error.stderr || error.message).trim()}`);
+  }
+  return dir;
+}
+
+// Read and check a checkout's stack-app.json:
+//   { "name": "acme-api", "description": "...", "language": "Go",
+//     "features": ["Chi"], "root": "template" }
+// "root" is the directory copied into new projects (default: the repo root).
+export async function readManifest(dir) {
+  const file = path.join(dir, manifestName);
+  if (!(await fs.pathExists(file))) {
+    throw new Error(`Not a stack-app template: ${manifestName} is missing`);
+  }
+
+  const manifest = await fs.readJson(file);
+  if (typeof manifest.name !== 'string' || !/^[a-z0-9][a-z0-9-_]*$/.test(manifest.name)) {
+    throw new Error(`${manifestName}: "name" must be lowercase letters, numbers, dashes or underscores`);
+  }
+
+  const base = path.resolve(dir);
+  const root = path.resolve(base, manifest.root || '.');
+  if (root !== base && !root.startsWith(base + path.sep)) {
+    throw new Error(`${manifestName}: "root" must stay inside the template`);
+  }
+
+  return {
+    name: manifest.name,
+    description: manifest.description || '',
+    language: manifest.language || 'Unknown',
+    features: Array.isArray(manifest.features) ? manifest.features : [],
+    root
+  };
+}
+
+// Resolve a registered name or a remote reference into a template config
+// for the generator. Returns null for anything else.
+export async function resolveRemoteTemplate(id) {
+  let url;
+  let ref;
+  if (isRemoteRef(id)) {
+    ({ url, ref } = parseTemplateRef(id));
+  } else {
+    const { templates } = await loadRegistry();
+    const entry = templates[id];
+    if (!entry) return null;
+    ({ url, ref } = entry);
+  }
+
+  const dir = await fetchTemplate(url, ref);
+  const manifest = await readManifest(dir);
+  return {
+    name: manifest.name,
+    description: manifest.description,
+    language: manifest.language,
+    features: manifest.features,
+    source: { url, ref, dir: manifest.root }
+  };
+}
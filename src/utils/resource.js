@@ -0,0 +1,77 @@
+import { humanize, pluralize, routeSegment } from './inflection.js';
+
+const fieldTypes = ['string', 'text', 'int', 'float', 'bool', 'time'];
+
+// Parse a resource spec such as:
+//   "Person:name:string,age:int plural=People display=Team Member"
+// Options after the field list are key=value pairs; values may contain spaces
+// up to the next key.
+export function parseResourceSpec(spec) {
+  const match = spec.trim().match(/^([A-Za-z][A-Za-z0-9]*)(?::([^\s]*))?\s*(.*)$/);
+  if (!match) {
+    throw new Error(`Invalid resource spec "${spec}"`);
+  }
+
+  const [, name, fieldList = '', optionList] = match;
+  const options = parseOptions(optionList);
+
+  const fields = fieldList
+    .split(',')
+    .filter(Boolean)
+    .map(parseField);
+
+  const plural = options.plural || pluralizeName(name);
+  const listColumns = parseListColumns(options.list, fields);
+
+  return {
+    name,
+    plural,
+    displayName: options.display || humanize(name),
+    displayPlural: options['display-plural'] || humanize(plural),
+    route: '/' + routeSegment(name, options.plural),
+    fields,
+    listColumns,
+    options
+  };
+}
+
+// Helper: pluralize only the final word of a compound name
+function pluralizeName(name) {
+  const parts = name.split(/(?=[A-Z])/);
+  parts[parts.length - 1] = pluralize(parts[parts.length - 1]);
+  return parts.join('');
+}
+
+// Columns shown in the list view; defaults to every field
+function parseListColumns(list, fields) {
+  if (!list) return fields.map(f => f.name);
+
+  const columns = list.split(',').map(c => c.trim()).filter(Boolean);
+  const known = new Set(fields.map(f => f.name));
+  const unknown = columns.filter(c => !known.has(c));
+  if (unknown.length > 0) {
+    throw new Error(`Unknown list column(s): ${unknown.join(', ')}`);
+  }
+  return columns;
+}
+
+function parseField(field) {
+  const [name, type = 'string'] = field.split(':');
+  if (!/^[a-z][a-zA-Z0-9_]*$/.test(name)) {
+    throw new Error(`Invalid field name "${name}"`);
+  }
+  if (!fieldTypes.includes(type)) {
+    throw new Error(`Unknown type "${type}" for field "${name}" (expected one of: ${fieldTypes.join(', ')})`);
+  }
+  return { name, type };
+}
+
+function parseOptions(optionList) {
+  const options = {};
+  const pattern = /([a-z-]+)=(.*?)(?=\s+[a-z-]+=|$)/g;
+  let m;
+  while ((m = pattern.exec(optionList.trim())) !== null) {
+    options[m[1]] = m[2].trim();
+  }
+  return options;
+}
@@ -0,0 +1,203 @@
+import fs from 'fs-extra';
+import os from 'node:os';
+import path from 'node:path';
+import crypto from 'node:crypto';
+import { execa } from 'execa';
+import { fileURLToPath } from 'node:url';
+import { hashContent } from './project-manifest.js';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+// The installed CLI: the package directory holding package.json and src/
+export const installRoot = path.resolve(__dirname, '..', '..');
+
+// Stable releases only, or prereleases (1.2.0-beta.1) too
+export const channels = ['stable', 'beta'];
+
+// Releases are published on GitHub with the "npm pack" tarball and a
+// SHA256SUMS file listing it (override the repository with
+// STACK_APP_UPDATE_REPO, e.g. for a fork)
+export function releaseRepo() {
+  return process.env.STACK_APP_UPDATE_REPO || 'Maneesh-Relanto/create-stack-app';
+}
+
+export const checksumsAsset = 'SHA256SUMS';
+export const signatureAsset = 'SHA256SUMS.sig';
+
+// "v1.2.0-beta.1" → { major: 1, minor: 2, patch: 0, pre: ['beta', '1'] }
+export function parseVersion(tag) {
+  const match = /^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?$/.exec(tag);
+  if (!match) {
+    return null;
+  }
+  return {
+    major: Number(match[1]),
+    minor: Number(match[2]),
+    patch: Number(match[3]),
+    pre: match[4] ? match[4].split('.') : []
+  };
+}
+
+// Semver precedence: a release sorts after its prereleases, and prerelease
+// identifiers compare numerically when both are numbers
+export function compareVersions(a, b) {
+  const x = typeof a === 'string' ? parseVersion(a) : a;
+  const y = typeof b === 'string' ? parseVersion(b) : b;
+  for (const key of ['major', 'minor', 'patch']) {
+    if (x[key] !== y[key]) {
+      return x[key] - y[key];
+    }
+  }
+  if (!x.pre.length || !y.pre.length) {
+    return y.pre.length - x.pre.length;
+  }
+  for (let i = 0; i < Math.max(x.pre.length, y.pre.length); i++) {
+    if (x.pre[i] === undefined) return -1;
+    if (y.pre[i] === undefined) return 1;
+    if (x.pre[i] === y.pre[i]) continue;
+    const [m, n] = [x.pre[i], y.pre[i]];
+    if (/^\d+$/.test(m) && /^\d+$/.test(n)) return Number(m) - Number(n);
+    if (/^\d+$/.test(m)) return -1;
+    if (/^\d+$/.test(n)) return 1;
+    return m < n ? -1 : 1;
+  }
+  return 0;
+}
+
+// A prerelease CLI stays on the beta channel unless told otherwise
+export function defaultChannel(version) {
+  const parsed = parseVersion(version);
+  return parsed && parsed.pre.length ? 'beta' : 'stable';
+}
+
+async function github(url, accept = 'application/vnd.github+json') {
+  const headers = { Accept: accept, 'User-Agent': 'stack-app-cli' };
+  if (process.env.GITHUB_TOKEN) {
+    headers.Authorization = `Bearer ${process.env.GITHUB_TOKEN}`;
+  }
+  const response = await fetch(url, { headers });
+  if (!response.ok) {
+    throw new Error(`GET ${url} failed: ${response.status} ${response.statusText}`);
+  }
+  return response;
+}
+
+// The newest published release on a channel, or null. Drafts are skipped,
+// and so are tags that aren't versions.
+export async function latestRelease(channel = 'stable') {
+  const response = await github(`https://api.github.com/repos/${releaseRepo()}/releases?per_page=100`);
+  let latest = null;
+  for (const release of await response.json()) {
+    const version = parseVersion(release.tag_name);
+    if (release.draft || !version) continue;
+    if (channel === 'stable' && (release.prerelease || version.pre.length)) continue;
+    if (!latest || compareVersions(version, latest.parsed) > 0) {
+      latest = {
+        version: release.tag_name.replace(/^v/, ''),
+        parsed: version,
+        url: release.html_url,
+        assets: release.assets || []
+      };
+    }
+  }
+  return latest;
+}
+
+// Compare the running CLI with the newest release on the channel
+export async function checkForUpdate(current, channel) {
+  const latest = await latestRelease(channel);
+  return {
+    current,
+    latest,
+    outdated: Boolean(latest && compareVersions(latest.version, current) > 0)
+  };
+}
+
+async function downloadAsset(release, name) {
+  const asset = release.assets.find(item => item.name === name);
+  if (!asset) {
+    return null;
+  }
+  const response = await github(asset.browser_download_url, 'application/octet-stream');
+  return Buffer.from(await response.arrayBuffer());
+}
+
+// Download the release tarball and check it against SHA256SUMS. With a
+// public key (PEM, Ed25519) the checksums file must also carry a valid
+// detached signature in SHA256SUMS.sig (base64).
+export async function downloadRelease(release, publicKey) {
+  const pkg = await fs.readJson(path.join(installRoot, 'package.json'));
+  const tarball = `${pkg.name}-${release.version}.tgz`;
+
+  const data = await downloadAsset(release, tarball);
+  if (!data) {
+    throw new Error(`Release ${release.version} has no ${tarball}`);
+  }
+  const sums = await downloadAsset(release, checksumsAsset);
+  if (!sums) {
+    throw new Error(`Release ${release.version} has no ${checksumsAsset}; refusing to install it unverified`);
+  }
+
+  if (publicKey) {
+    const signature = await downloadAsset(release, signatureAsset);
+    if (!signature) {
+      throw new Error(`Release ${release.version} has no ${signatureAsset} to check against the public key`);
+    }
+    const valid = crypto.verify(null, sums, publicKey, Buffer.from(signature.toString().trim(), 'base64'));
+    if (!valid) {
+      throw new Error(`${signatureAsset} does not match ${checksumsAsset} for the given public key`);
+    }
+  }
+
+  const expected = sums.toString().split('\n')
+    .map(line => line.trim().split(/\s+\*?/))
+    .find(([, name]) => name === tarball);
+  if (!expected) {
+    throw new Error(`${checksumsAsset} does not list ${tarball}`);
+  }
+  if (hashContent(data) !== expected[0].toLowerCase()) {
+    throw new Error(`${tarball} does not match its sha256 in ${checksumsAsset}`);
+  }
+  return { tarball, data };
+}
+
+// Replace the installed CLI with a verified release tarball. The release
+// is unpacked and its dependencies installed next to the current install,
+// then swapped in with two renames; if the second fails the first is
+// undone, so a failed update leaves the old CLI in place.
+export async function installRelease(tarball, data, version) {
+  if (await fs.pathExists(path.join(installRoot, '.git'))) {
+    throw new Error(`${installRoot} is a git checkout; update it with git pull instead`);
+  }
+
+  const staging = `${installRoot}.update-${process.pid}`;
+  const previous = `${installRoot}.previous`;
+  const download = path.join(await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-update-')), tarball);
+
+  try {
+    await fs.writeFile(download, data);
+    await fs.emptyDir(staging);
+    await execa('tar', ['-xzf', download, '-C', staging, '--strip-components=1']);
+
+    const pkg = await fs.readJson(path.join(staging, 'package.json'));
+    if (pkg.version !== version) {
+      throw new Error(`${tarball} contains version ${pkg.version}, expected ${version}`);
+    }
+    await execa('npm', ['install', '--omit=dev', '--ignore-scripts', '--no-audit', '--no-fund'], { cwd: staging });
+
+    await fs.remove(previous);
+    await fs.rename(installRoot, previous);
+    try {
+      await fs.rename(staging, installRoot);
+    } catch (error) {
+      await fs.rename(previous, installRoot);
+      throw error;
+    }
+  } finally {
+    await fs.remove(staging);
+    await fs.remove(path.dirname(download));
+  }
+
+  // Windows keeps the running files locked; the next update clears them
+  await fs.remove(previous).catch(() => {});
+}
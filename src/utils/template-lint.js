@@ -0,0 +1,246 @@
+import fs from 'fs-extra';
+import os from 'node:os';
+import path from 'node:path';
+import { execa } from 'execa';
+import { templates } from '../config/templates.js';
+import { manifestName, readManifest } from './registry.js';
+import { variableNames } from '../templating/index.js';
+import { generateRemote } from '../generators/remote.js';
+import { takeHooks, runHooks } from './hooks.js';
+
+// What a template can declare in "provides", and the generated files that
+// prove it
+export const provisions = {
+  docker: { label: 'Dockerfile', test: files => files.some(file => path.posix.basename(file) === 'Dockerfile') },
+  compose: { label: 'docker-compose.yml or compose.yaml', test: files => files.some(file => /^(docker-compose|compose)\.ya?ml$/.test(path.posix.basename(file))) },
+  makefile: { label: 'Makefile or Taskfile.yml', test: files => files.some(file => ['Makefile', 'Taskfile.yml'].includes(path.posix.basename(file))) },
+  tests: { label: 'test files', test: files => files.some(file => /(_test\.go|\.(test|spec)\.[jt]sx?|(^|\/)test_[^/]*\.py|_test\.exs)$/.test(file)) },
+  ci: { label: 'workflow in .github/workflows or .gitlab-ci.yml', test: files => files.some(file => /^(\.github\/workflows\/[^/]+|\.gitlab-ci)\.ya?ml$/.test(file)) }
+};
+
+const manifestKeys = ['name', 'description', 'language', 'features', 'root', 'provides'];
+
+// Template files by slash-separated path, without git metadata or
+// installed dependencies
+async function templateFiles(dir, base = dir) {
+  const files = [];
+  for (const entry of await fs.readdir(dir, { withFileTypes: true })) {
+    if (['.git', 'node_modules'].includes(entry.name)) continue;
+    const full = path.join(dir, entry.name);
+    if (entry.isDirectory()) {
+      files.push(...await templateFiles(full, base));
+    } else {
+      files.push(path.relative(base, full).split(path.sep).join('/'));
+    }
+  }
+  return files.sort();
+}
+
+// stack-app.json: what "templates add" requires, plus the optional fields'
+// types, "provides" values and keys nothing reads
+async function lintMetadata(dir, report) {
+  const file = path.join(dir, manifestName);
+  if (!(await fs.pathExists(file))) {
+    report.errors.push(`${manifestName} is missing`);
+    return null;
+  }
+  let raw;
+  try {
+    raw = await fs.readJson(file);
+  } catch (error) {
+    report.errors.push(`${manifestName} does not parse: ${error.message}`);
+    return null;
+  }
+  if (!raw || typeof raw !== 'object' || Array.isArray(raw)) {
+    report.errors.push(`${manifestName}: expected an object`);
+    return null;
+  }
+
+  let manifest;
+  try {
+    manifest = await readManifest(dir);
+  } catch (error) {
+    report.errors.push(error.message.replace(/^Not a stack-app template: /, ''));
+    return null;
+  }
+
+  const { errors, warnings } = report;
+  if (templates[manifest.name]) {
+    errors.push(`${manifestName}: "${manifest.name}" is a built-in template id; "templates add" refuses it`);
+  }
+  if (typeof raw.description !== 'string' || !raw.description.trim()) {
+    warnings.push(`${manifestName}: "description" is empty; "templates list" shows it`);
+  }
+  if (raw.language === undefined) {
+    warnings.push(`${manifestName}: "language" is missing; the template is listed as Unknown`);
+  } else if (typeof raw.language !== 'string') {
+    errors.push(`${manifestName}: "language" must be a string`);
+  }
+  if (raw.features !== undefined && (!Array.isArray(raw.features) || raw.features.some(feature => typeof feature !== 'string'))) {
+    errors.push(`${manifestName}: "features" must be a list of strings`);
+  }
+  if (raw.root !== undefined && typeof raw.root !== 'string') {
+    errors.push(`${manifestName}: "root" must be a string`);
+  } else if (!(await fs.pathExists(manifest.root)) || !(await fs.stat(manifest.root)).isDirectory()) {
+    errors.push(`${manifestName}: "root" ${raw.root} is not a directory`);
+    return null;
+  }
+
+  const provides = [];
+  if (raw.provides !== undefined) {
+    if (!Array.isArray(raw.provides)) {
+      errors.push(`${manifestName}: "provides" must be a list`);
+    } else {
+      for (const item of raw.provides) {
+        if (provisions[item]) {
+          provides.push(item);
+        } else {
+          errors.push(`${manifestName}: unknown "provides" value ${JSON.stringify(item)} (expected ${Object.keys(provisions).join(', ')})`);
+        }
+      }
+    }
+  }
+  for (const key of Object.keys(raw).filter(key => !manifestKeys.includes(key))) {
+    warnings.push(`${manifestName}: "${key}" is not a manifest field and is ignored`);
+  }
+  return { ...manifest, provides };
+}
+
+function lineOf(text, index) {
+  return text.slice(0, index).split('\n').length;
+}
+
+// {{ .Name }} placeholders: unknown names fail generation; other {{ }}
+// in .tmpl files are copied as is, and placeholders outside .tmpl files
+// are never rendered
+async function lintPlaceholders(root, files, report) {
+  for (const rel of files) {
+    const content = await fs.readFile(path.join(root, rel));
+    if (content.includes(0)) continue;
+    const text = content.toString('utf8');
+    const tmpl = rel.endsWith('.tmpl');
+
+    for (const match of text.matchAll(/\{\{([\s\S]*?)\}\}/g)) {
+      const at = `${rel}:${lineOf(text, match.index)}`;
+      const variable = /^\s*\.(\w+)\s*$/.exec(match[1]);
+      if (tmpl && variable && !variableNames.includes(variable[1])) {
+        report.errors.push(`${at}: unknown variable {{ .${variable[1]} }} (expected ${variableNames.join(', ')})`);
+      } else if (tmpl && !variable) {
+        report.warnings.push(`${at}: ${match[0].replace(/\s+/g, ' ')} is not a {{ .Name }} placeholder and is copied as is`);
+      } else if (!tmpl && variable && variableNames.includes(variable[1])) {
+        report.warnings.push(`${at}: {{ .${variable[1]} }} is only rendered in .tmpl files; rename it to ${path.posix.basename(rel)}.tmpl`);
+      }
+    }
+    if (tmpl && files.includes(rel.slice(0, -'.tmpl'.length))) {
+      report.warnings.push(`${rel} overwrites ${rel.slice(0, -'.tmpl'.length)} when rendered`);
+    }
+  }
+}
+
+// gofmt -e: every generated Go file has to parse; unformatted ones warn
+async function lintGoSyntax(projectPath, report) {
+  try {
+    const { stdout } = await execa('gofmt', ['-l', '-e', '.'], { cwd: projectPath });
+    stdout.split('\n').filter(Boolean).forEach(file => {
+      report.warnings.push(`${file.replace(/^\.\//, '')} is not gofmt-formatted`);
+    });
+    return true;
+  } catch (error) {
+    if (error.code === 'ENOENT') {
+      report.warnings.push('gofmt was not found, so the Go files were not parsed');
+      return false;
+    }
+    (error.stderr || error.message).trim().split('\n').forEach(line => {
+      report.errors.push(`generated ${line.replace(/^\.\//, '')}`);
+    });
+    return false;
+  }
+}
+
+// Lint a template checkout for its author: the manifest, the placeholders,
+// then the project it renders (hooks.yaml, declared files, Go syntax and,
+// unless skipBuild, its setup hooks followed by go build ./...).
+//
+// Returns { errors, warnings, passed }, passed naming the checks that
+// found no errors.
+export async function lintTemplate(dir, options = {}) {
+  const report = { errors: [], warnings: [], passed: [] };
+  const check = async (name, run) => {
+    const before = report.errors.length;
+    const result = await run();
+    if (result !== false && report.errors.length === before) {
+      report.passed.push(name);
+    }
+  };
+
+  let manifest = null;
+  await check(`${manifestName}`, async () => {
+    manifest = await lintMetadata(dir, report);
+  });
+  if (!manifest) {
+    return report;
+  }
+
+  const files = (await templateFiles(manifest.root)).filter(file => file !== manifestName || manifest.root !== path.resolve(dir));
+  await check(`placeholders in ${files.filter(file => file.endsWith('.tmpl')).length} .tmpl file(s)`, () => lintPlaceholders(manifest.root, files, report));
+
+  const tempRoot = await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-lint-'));
+  const projectPath = path.join(tempRoot, 'lint-app');
+  try {
+    await fs.ensureDir(projectPath);
+    let hooks = [];
+    let rendered = false;
+    await check('renders', async () => {
+      try {
+        await generateRemote(projectPath, { dir: manifest.root }, { author: 'Stack App', year: new Date().getFullYear() });
+        hooks = await takeHooks(projectPath);
+        rendered = true;
+      } catch (error) {
+        report.errors.push(`Rendering failed: ${error.message}`);
+      }
+    });
+    if (!rendered) {
+      return report;
+    }
+
+    const generated = await templateFiles(projectPath);
+    if (manifest.provides.length > 0) {
+      await check(`provides ${manifest.provides.join(', ')}`, () => {
+        for (const item of manifest.provides) {
+          if (!provisions[item].test(generated)) {
+            report.errors.push(`"provides" lists ${item}, but the generated project has no ${provisions[item].label}`);
+          }
+        }
+      });
+    }
+
+    if (!generated.some(file => file.endsWith('.go'))) {
+      return report;
+    }
+    let parsed = false;
+    await check('Go files parse', async () => {
+      parsed = await lintGoSyntax(projectPath, report);
+      return parsed;
+    });
+
+    if (options.skipBuild || !generated.includes('go.mod') || !parsed) {
+      return report;
+    }
+    await check(`go build ./...${hooks.length ? ` (after ${hooks.length} hook(s))` : ''}`, async () => {
+      try {
+        await runHooks(projectPath, hooks);
+        await execa('go', ['build', './...'], { cwd: projectPath, env: { ...process.env, GOFLAGS: '-mod=mod' } });
+      } catch (error) {
+        if (error.code === 'ENOENT') {
+          report.warnings.push('go was not found, so the project was not built');
+          return false;
+        }
+        const output = (error.stderr || error.message).trim().split('\n');
+        report.errors.push(`go build failed:\n    ${output.slice(0, 20).join('\n    ')}${output.length > 20 ? '\n    ...' : ''}`);
+      }
+    });
+  } finally {
+    await fs.remove(tempRoot);
+  }
+  return report;
+}
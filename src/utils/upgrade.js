@@ -0,0 +1,137 @@
+import fs from 'fs-extra';
+import os from 'node:os';
+import path from 'node:path';
+import { execa } from 'execa';
+import { templates } from '../config/templates.js';
+import { generateProject } from '../generators/index.js';
+import { resolveRemoteTemplate } from './registry.js';
+import { isPluginRef, resolvePluginTemplate } from './plugins.js';
+import { takeHooks } from './hooks.js';
+import { hashContent, hashProject } from './project-manifest.js';
+
+// Resolve the template a project manifest refers to. Built-in templates
+// come with the CLI; remote ones are fetched at ref (null for the default
+// branch), and plugin ones come from the installed plugin.
+export async function manifestTemplate(manifest, ref) {
+  if (!manifest.source && isPluginRef(manifest.template)) {
+    return resolvePluginTemplate(manifest.template);
+  }
+  if (!manifest.source) {
+    const templateConfig = templates[manifest.template];
+    if (!templateConfig) {
+      throw new Error(`Unknown template "${manifest.template}"`);
+    }
+    return templateConfig;
+  }
+  return resolveRemoteTemplate(ref ? `${manifest.source.url}@${ref}` : manifest.source.url);
+}
+
+// Generate the manifest's project again, with its recorded choices, into a
+// temporary directory. Hooks are dropped: only the template's files matter.
+export async function regenerate(manifest, templateConfig) {
+  const tempRoot = await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-upgrade-'));
+  const projectPath = path.join(tempRoot, manifest.name);
+
+  await fs.ensureDir(projectPath);
+  await generateProject(projectPath, manifest.template, templateConfig, manifest.features, manifest.options);
+  const hooks = await takeHooks(projectPath);
+
+  return { tempRoot, projectPath, hooks };
+}
+
+async function hashFile(file) {
+  if (!(await fs.pathExists(file))) {
+    return undefined;
+  }
+  return hashContent(await fs.readFile(file));
+}
+
+// Work out what upgrading the project to the regenerated template at
+// nextPath involves. Each file is compared three ways: its hash when the
+// project was generated (base), the project's copy (ours) and the new
+// template's (theirs).
+//
+//   template unchanged, or project already matches   nothing to do
+//   project untouched since generation                add / update / remove
+//   both changed                                      merge (line by line)
+//   deleted on one side, changed on the other         conflict
+//
+// basePath, when given, holds the old template version regenerated; its
+// files become the common ancestor of line merges. Without it (or where it
+// doesn't match the recorded hash) both sides are merged against nothing,
+// so every differing line is a conflict.
+export async function planUpgrade(projectPath, manifest, nextPath, basePath) {
+  const next = await hashProject(nextPath);
+  const paths = [...new Set([...Object.keys(manifest.files), ...Object.keys(next)])].sort();
+  const steps = [];
+
+  for (const file of paths) {
+    const base = manifest.files[file];
+    const theirs = next[file];
+    const ours = await hashFile(path.join(projectPath, file));
+
+    if (theirs === base || ours === theirs) {
+      continue;
+    }
+    if (ours === base) {
+      steps.push({ file, action: !theirs ? 'remove' : ours ? 'update' : 'add' });
+    } else if (ours && theirs) {
+      const ancestor = basePath && base && (await hashFile(path.join(basePath, file))) === base
+        ? path.join(basePath, file)
+        : null;
+      steps.push({ file, action: 'merge', base: ancestor });
+    } else {
+      steps.push({ file, action: 'conflict', reason: ours ? 'changed here, removed from the template' : 'removed here, changed in the template' });
+    }
+  }
+  return { steps, files: next };
+}
+
+// Carry out a plan from planUpgrade. Merges that conflict are left in the
+// file with git-style markers and their step becomes a conflict.
+export async function applyUpgrade(projectPath, steps, nextPath, labels) {
+  const empty = path.join(await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-merge-')), 'empty');
+  await fs.writeFile(empty, '');
+
+  try {
+    for (const step of steps) {
+      const target = path.join(projectPath, step.file);
+      const source = path.join(nextPath, step.file);
+
+      if (step.action === 'add' || step.action === 'update') {
+        await fs.copy(source, target);
+      } else if (step.action === 'remove') {
+        await fs.remove(target);
+      } else if (step.action === 'merge') {
+        const conflicts = await mergeFile(target, step.base || empty, source, labels);
+        if (conflicts > 0) {
+          step.action = 'conflict';
+          step.reason = `${conflicts} conflicting change${conflicts === 1 ? '' : 's'}, marked in the file`;
+        } else if (conflicts < 0) {
+          step.action = 'conflict';
+          step.reason = 'binary file; kept this project\'s version';
+        }
+      }
+    }
+  } finally {
+    await fs.remove(path.dirname(empty));
+  }
+}
+
+// Merge theirs into ours in place (git merge-file). Returns the number of
+// conflicts, or -1 when the file can't be merged as text.
+async function mergeFile(ours, base, theirs, labels) {
+  for (const file of [ours, base, theirs]) {
+    if ((await fs.readFile(file)).includes(0)) {
+      return -1;
+    }
+  }
+
+  const { exitCode, stderr } = await execa('git', [
+    'merge-file', '-L', labels.ours, '-L', labels.base, '-L', labels.theirs, ours, base, theirs
+  ], { reject: false });
+  if (exitCode < 0 || exitCode > 127) {
+    throw new Error(`git merge-file ${ours}: ${stderr.trim()}`);
+  }
+  return exitCode;
+}
@@ -0,0 +1,19 @@
+import archiver from 'archiver';
+import path from 'node:path';
+
+// Stream a generated project into a zip archive without buffering it in
+// memory. Resolves once the archive has been fully written to `output`.
+export function streamProjectZip(projectPath, output, rootName = path.basename(projectPath)) {
+  return new Promise((resolve, reject) => {
+    const archive = archiver('zip', { zlib: { level: 9 } });
+
+    output.on('close', resolve);
+    output.on('finish', resolve);
+    archive.on('warning', reject);
+    archive.on('error', reject);
+
+    archive.pipe(output);
+    archive.directory(projectPath, rootName);
+    archive.finalize();
+  });
+}
@@ -0,0 +1,52 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import os from 'node:os';
+import path from 'node:path';
+import fs from 'fs-extra';
+import { scaffoldApiVersion } from '../src/commands/api-version.js';
+
+const latestSource = `// Package v5 serves items. v4 keeps serving the old shape.
+package v5
+
+import (
+    "net/http"
+    "github.com/go-chi/chi/v5"
+)
+
+func self(id string) string {
+    return "/v5/items/" + id
+}
+
+func docs() string {
+    return "/api/v5"
+}
+`;
+
+test('api version renames the package and route paths but not imports', async t => {
+  const root = await fs.mkdtemp(path.join(os.tmpdir(), 'api-version-'));
+  t.after(() => fs.remove(root));
+  await fs.outputFile(path.join(root, 'go.mod'), 'module example.com/shop\n\ngo 1.21\n');
+  await fs.outputFile(path.join(root, 'main.go'), 'r.Mount("/v5", apiv5.Routes(itemStore))\n');
+  await fs.outputFile(path.join(root, 'api/v4/items.go'), 'package v4\n');
+  await fs.outputFile(path.join(root, 'api/v5/items.go'), latestSource);
+
+  const logged = [];
+  const log = console.log;
+  console.log = (...args) => logged.push(args.join(' '));
+  try {
+    await scaffoldApiVersion(root);
+  } finally {
+    console.log = log;
+  }
+
+  const source = await fs.readFile(path.join(root, 'api/v6/items.go'), 'utf8');
+  assert.match(source, /^\/\/ Package v6 serves items\. v4 keeps/m);
+  assert.match(source, /^package v6$/m);
+  assert.match(source, /"github\.com\/go-chi\/chi\/v5"/);
+  assert.match(source, /"\/v6\/items\/"/);
+  assert.match(source, /"\/api\/v6"/);
+
+  const output = logged.join('\n');
+  assert.match(output, /import apiv6 "example\.com\/shop\/api\/v6"/);
+  assert.match(output, /r\.Mount\("\/v6", apiv6\.Routes\(itemStore\)\)/);
+});
@@ -0,0 +1,53 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import os from 'node:os';
+import path from 'node:path';
+import fs from 'fs-extra';
+import { generateGoHTMX } from '../src/generators/go.js';
+
+async function generate(t, options) {
+  const dir = await fs.mkdtemp(path.join(os.tmpdir(), 'go-defaults-'));
+  t.after(() => fs.remove(dir));
+  const projectPath = path.join(dir, 'shop');
+  await generateGoHTMX(projectPath, [], { author: 'Test', ...options });
+  return {
+    defaults: await fs.readFile(path.join(projectPath, 'config', 'defaults.go'), 'utf8'),
+    env: await fs.readFile(path.join(projectPath, '.env.example'), 'utf8')
+  };
+}
+
+test('options that change a default bake it into config and .env.example', async t => {
+  const { defaults, env } = await generate(t, { 'response-cache': 'memory' });
+  assert.match(defaults, /"RESPONSE_CACHE_TTL": "30s",/);
+  assert.match(env, /^RESPONSE_CACHE_TTL=30s$/m);
+});
+
+test('--log clf sets the access log format', async t => {
+  const { defaults, env } = await generate(t, { log: 'clf' });
+  assert.match(defaults, /"ACCESS_LOG_FORMAT": "clf",/);
+  assert.match(env, /^ACCESS_LOG_FORMAT=clf$/m);
+});
+
+test('--theme-toggle shows the toggle in the layout', async t => {
+  const { defaults, env } = await generate(t, { 'theme-toggle': 'cookie' });
+  assert.match(defaults, /"THEME_TOGGLE": "true",/);
+  assert.match(env, /^THEME_TOGGLE=true$/m);
+});
+
+test('--search-empty none lists nothing until the user types', async t => {
+  const { defaults, env } = await generate(t, { 'search-empty': 'none' });
+  assert.match(defaults, /"SEARCH_EMPTY": "none",/);
+  assert.match(env, /^SEARCH_EMPTY=none$/m);
+});
+
+test('--error-format problem answers errors as problem+json', async t => {
+  const { defaults, env } = await generate(t, { 'error-format': 'problem' });
+  assert.match(defaults, /"ERROR_FORMAT": "problem",/);
+  assert.match(env, /^ERROR_FORMAT=problem$/m);
+});
+
+test('without such options the defaults map stays empty', async t => {
+  const { defaults, env } = await generate(t, {});
+  assert.match(defaults, /var defaults = map\[string\]string\{\}/);
+  assert.match(env, /^RESPONSE_CACHE_TTL=0$/m);
+});
@@ -0,0 +1,57 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import { pluralize, routeSegment, humanize } from '../src/utils/inflection.js';
+import { parseResourceSpec } from '../src/utils/resource.js';
+
+test('pluralize handles irregular, uncountable and rule-based words', () => {
+  const cases = {
+    person: 'people',
+    Person: 'People',
+    child: 'children',
+    mouse: 'mice',
+    leaf: 'leaves',
+    analysis: 'analyses',
+    index: 'indices',
+    status: 'statuses',
+    sheep: 'sheep',
+    news: 'news',
+    category: 'categories',
+    box: 'boxes',
+    knife: 'knives',
+    potato: 'potatoes',
+    day: 'days',
+    item: 'items'
+  };
+  for (const [word, plural] of Object.entries(cases)) {
+    assert.equal(pluralize(word), plural, word);
+  }
+});
+
+test('routes pluralize the last word only', () => {
+  assert.equal(routeSegment('Person'), 'people');
+  assert.equal(routeSegment('SalesPerson'), 'sales-people');
+  assert.equal(routeSegment('OrderLine'), 'order-lines');
+  assert.equal(routeSegment('SearchIndex'), 'search-indices');
+  assert.equal(routeSegment('Sheep'), 'sheep');
+  assert.equal(routeSegment('Person', 'Staff'), 'staff');
+});
+
+test('resource specs derive routes and labels from irregular plurals', () => {
+  const cases = [
+    ['Person:name:string', { plural: 'People', displayName: 'Person', displayPlural: 'People', route: '/people' }],
+    ['ChildRecord:name:string', { plural: 'ChildRecords', displayName: 'Child Record', displayPlural: 'Child Records', route: '/child-records' }],
+    ['GrandChild:name:string', { plural: 'GrandChildren', displayName: 'Grand Child', displayPlural: 'Grand Children', route: '/grand-children' }],
+    ['Criterion:name:string', { plural: 'Criteria', displayName: 'Criterion', displayPlural: 'Criteria', route: '/criteria' }],
+    ['Fish:name:string', { plural: 'Fish', displayName: 'Fish', displayPlural: 'Fish', route: '/fish' }],
+    ['Person:name:string plural=Folks display=Team Member', { plural: 'Folks', displayName: 'Team Member', displayPlural: 'Folks', route: '/folks' }]
+  ];
+  for (const [spec, want] of cases) {
+    const { plural, displayName, displayPlural, route } = parseResourceSpec(spec);
+    assert.deepEqual({ plural, displayName, displayPlural, route }, want, spec);
+  }
+});
+
+test('humanize splits compound names', () => {
+  assert.equal(humanize('OrderLine'), 'Order Line');
+  assert.equal(humanize('order_line'), 'Order Line');
+});
@@ -0,0 +1,62 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import net from 'node:net';
+import path from 'node:path';
+import fs from 'fs-extra';
+import { execa } from 'execa';
+import { generateToTemp } from '../src/commands/preview.js';
+import { runHooks } from '../src/utils/hooks.js';
+
+// Helper: Ask the OS for a port nothing is listening on
+function freePort() {
+  return new Promise((resolve, reject) => {
+    const server = net.createServer().listen(0, () => {
+      const { port } = server.address();
+      server.close(() => resolve(port));
+    });
+    server.on('error', reject);
+  });
+}
+
+// Helper: Poll url until it answers or the deadline passes
+async function waitFor(url, timeoutMs) {
+  const deadline = Date.now() + timeoutMs;
+  for (;;) {
+    try {
+      return await fetch(url);
+    } catch (error) {
+      if (Date.now() > deadline) throw error;
+      await new Promise(resolve => setTimeout(resolve, 250));
+    }
+  }
+}
+
+test('preview builds the default stack and serves its health check', { timeout: 10 * 60 * 1000 }, async t => {
+  try {
+    await execa('go', ['version']);
+  } catch {
+    t.skip('Go toolchain not found on PATH');
+    return;
+  }
+
+  const { tempRoot, projectPath, hooks } = await generateToTemp('go-htmx');
+  let app;
+  try {
+    assert.ok(!(await fs.pathExists(path.join(projectPath, 'hooks.yaml'))), 'hooks.yaml is taken out of the project');
+    await runHooks(projectPath, hooks);
+
+    // Build first so the test fails on compile errors rather than timing out
+    await execa('go', ['build', '-o', 'preview-app', '.'], { cwd: projectPath });
+    const port = await freePort();
+    app = execa(path.join(projectPath, 'preview-app'), [], { cwd: projectPath, env: { PORT: String(port) }, reject: false });
+
+    const res = await waitFor(`http://localhost:${port}/healthz`, 30 * 1000);
+    assert.equal(res.status, 200);
+  } finally {
+    if (app) {
+      app.kill('SIGTERM');
+      await app;
+    }
+    await fs.remove(tempRoot);
+  }
+});
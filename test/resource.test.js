@@ -0,0 +1,44 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import os from 'node:os';
+import path from 'node:path';
+import fs from 'fs-extra';
+import { describeResource, generateResource } from '../src/generators/resource.js';
+
+const mainSource = `package main
+
+func main() {
+    // stack-app:stores
+    // stack-app:routes
+}
+`;
+
+async function project(t, goMod) {
+  const root = await fs.mkdtemp(path.join(os.tmpdir(), 'resource-'));
+  t.after(() => fs.remove(root));
+  for (const dir of ['models', 'store', 'handlers', 'views']) {
+    await fs.ensureDir(path.join(root, dir));
+  }
+  if (goMod) await fs.outputFile(path.join(root, 'go.mod'), goMod);
+  await fs.outputFile(path.join(root, 'main.go'), mainSource);
+  return root;
+}
+
+test('resource imports use the module path from go.mod', async t => {
+  const root = await project(t, 'module example.com/shop\n\ngo 1.21\n');
+  await generateResource(root, describeResource('product', ['name:string', 'released:time']));
+
+  for (const file of ['store/product.go', 'handlers/product.go', 'handlers/resourceform.go', 'views/product.templ']) {
+    const source = await fs.readFile(path.join(root, file), 'utf8');
+    assert.match(source, /"example\.com\/shop\/\w+"/, file);
+    assert.doesNotMatch(source, /"myapp\//, file);
+  }
+});
+
+test('resource imports fall back to myapp without a go.mod', async t => {
+  const root = await project(t);
+  await generateResource(root, describeResource('product', ['name:string']));
+
+  const source = await fs.readFile(path.join(root, 'handlers/product.go'), 'utf8');
+  assert.match(source, /"myapp\/models"/);
+});
@@ -0,0 +1,93 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import { templates } from '../src/config/templates.js';
+import { listStacks } from '../src/commands/list.js';
+import { describeStack } from '../src/commands/describe.js';
+import { stackFlags } from '../src/commands/create.js';
+import * as go from '../src/generators/go.js';
+
+// Helper: Run fn and return what it printed, without colours
+function captureLog(fn) {
+  const lines = [];
+  const original = console.log;
+  console.log = (...args) => lines.push(args.join(' '));
+  try {
+    fn();
+  } finally {
+    console.log = original;
+  }
+  return lines.join('\n').replace(/\x1b\[[0-9;]*m/g, '');
+}
+
+// The generator table each go-htmx option chooses from
+const goHTMXTables = {
+  db: go.databases,
+  auth: go.auths,
+  logging: go.loggers,
+  log: go.accessLogs,
+  css: go.stylesheets,
+  bundler: go.bundlers,
+  frontend: go.frontends,
+  themeToggle: go.themeToggles,
+  trailingSlash: go.trailingSlashes,
+  searchEmpty: go.emptySearches,
+  errorFormat: go.errorFormats,
+  realtime: go.realtimes,
+  cache: go.caches,
+  observability: go.observabilities,
+  jobs: go.jobsBackends,
+  ratelimit: go.rateLimiters,
+  storage: go.storages,
+  i18n: go.translators,
+  mailer: go.mailers,
+  flags: go.flagProviders,
+  admin: go.admins,
+  cleanup: go.cleanups,
+  responseCache: go.responseCaches,
+  e2e: go.e2eSuites
+};
+
+test('list stacks includes go-htmx', () => {
+  const output = captureLog(listStacks);
+  assert.match(output, /go-htmx\s+Modern server-side rendering with HTMX/);
+});
+
+test('describe stack go-htmx shows every option with its flag, choices and default', () => {
+  const output = captureLog(() => describeStack('go-htmx'));
+  const { options } = templates['go-htmx'];
+
+  for (const [name, option] of Object.entries(options)) {
+    const header = option.flag ? `${name} ${option.flag}` : name;
+    assert.ok(output.includes(header), `missing option ${header}`);
+    assert.ok(output.includes(`Choices: ${option.choices.join(', ')}`), `missing choices for ${name}`);
+    assert.ok(output.includes(`Default: ${option.default}`), `missing default for ${name}`);
+  }
+});
+
+test('go-htmx options match the flags create accepts and the generator choices', () => {
+  const { options } = templates['go-htmx'];
+
+  for (const [name, option] of Object.entries(options)) {
+    if (!option.flag) continue;
+    const flag = option.flag.replace(/^--/, '');
+    assert.equal(stackFlags[flag], name, `${option.flag} does not set options.${name}`);
+    assert.ok(option.choices.includes(option.default), `${name} default is not a choice`);
+    if (option.enabled) {
+      assert.ok(option.choices.includes(option.enabled), `${name} bare flag value is not a choice`);
+    }
+
+    const table = goHTMXTables[name];
+    if (table) {
+      assert.deepEqual([...option.choices].sort(), Object.keys(table).sort(), `${name} choices differ from the generator's`);
+    }
+  }
+});
+
+test('go-htmx refuses --admin without --auth', async () => {
+  for (const auth of [undefined, 'none']) {
+    await assert.rejects(
+      go.generateGoHTMX('/nonexistent', [], { admin: 'htmx', auth }),
+      /--admin needs --auth/
+    );
+  }
+});
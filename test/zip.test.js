@@ -0,0 +1,73 @@
+import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import os from 'node:os';
+import path from 'node:path';
+import zlib from 'node:zlib';
+import fs from 'fs-extra';
+import { execa } from 'execa';
+import { generateToTemp } from '../src/commands/preview.js';
+import { streamProjectZip } from '../src/utils/zip.js';
+import { runHooks } from '../src/utils/hooks.js';
+
+// Helper: Read every file out of a zip archive via its central directory
+function readZip(buffer) {
+  const end = buffer.lastIndexOf(Buffer.from([0x50, 0x4b, 0x05, 0x06]));
+  assert.ok(end >= 0, 'zip end of central directory record');
+  const count = buffer.readUInt16LE(end + 10);
+  let offset = buffer.readUInt32LE(end + 16);
+
+  const files = new Map();
+  for (let i = 0; i < count; i++) {
+    const method = buffer.readUInt16LE(offset + 10);
+    const size = buffer.readUInt32LE(offset + 20);
+    const nameLength = buffer.readUInt16LE(offset + 28);
+    const extraLength = buffer.readUInt16LE(offset + 30);
+    const commentLength = buffer.readUInt16LE(offset + 32);
+    const local = buffer.readUInt32LE(offset + 42);
+    const name = buffer.toString('utf8', offset + 46, offset + 46 + nameLength);
+    offset += 46 + nameLength + extraLength + commentLength;
+    if (name.endsWith('/')) continue;
+
+    const start = local + 30 + buffer.readUInt16LE(local + 26) + buffer.readUInt16LE(local + 28);
+    const data = buffer.subarray(start, start + size);
+    files.set(name, method === 8 ? zlib.inflateRawSync(data) : data);
+  }
+  return files;
+}
+
+test('the project zip holds the generated files under one root and builds', { timeout: 10 * 60 * 1000 }, async t => {
+  const { tempRoot, projectPath, hooks } = await generateToTemp('go-htmx');
+  try {
+    const zipPath = path.join(tempRoot, 'project.zip');
+    await streamProjectZip(projectPath, fs.createWriteStream(zipPath), 'go-htmx-app');
+    const files = readZip(await fs.readFile(zipPath));
+
+    for (const name of ['go.mod', 'main.go', 'README.md', 'handlers/handlers.go', 'views/views.templ']) {
+      assert.ok(files.has(`go-htmx-app/${name}`), `zip has go-htmx-app/${name}`);
+    }
+    assert.ok([...files.keys()].every(name => name.startsWith('go-htmx-app/')), 'every entry is under the root directory');
+    assert.ok(!files.has('go-htmx-app/hooks.yaml'), 'hooks stay with the CLI');
+    assert.deepEqual(files.get('go-htmx-app/go.mod'), await fs.readFile(path.join(projectPath, 'go.mod')));
+
+    try {
+      await execa('go', ['version']);
+    } catch {
+      t.skip('Go toolchain not found on PATH; skipped building the extracted project');
+      return;
+    }
+    const extracted = await fs.mkdtemp(path.join(os.tmpdir(), 'stack-app-zip-'));
+    try {
+      for (const [name, data] of files) {
+        await fs.ensureDir(path.join(extracted, path.dirname(name)));
+        await fs.writeFile(path.join(extracted, name), data);
+      }
+      const root = path.join(extracted, 'go-htmx-app');
+      await runHooks(root, hooks);
+      await execa('go', ['build', './...'], { cwd: root });
+    } finally {
+      await fs.remove(extracted);
+    }
+  } finally {
+    await fs.remove(tempRoot);
+  }
+});